@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/config"
+)
+
+// runConfig ejecuta el subcomando de configuración y retorna el código de salida.
+// Uso: config show [-config archivo] [-work 30m ...]
+func runConfig(args []string) int {
+	if len(args) == 0 || (args[0] != "show" && args[0] != "mostrar") {
+		printConfigUsage()
+		return 2
+	}
+
+	loader := config.NewLoader()
+	flags := flag.NewFlagSet("config show", flag.ContinueOnError)
+	loader.RegisterFlags(flags)
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	resolved, err := loader.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	printResolvedConfig(resolved, loader.Path)
+	return 0
+}
+
+// printResolvedConfig muestra la configuración efectiva y el origen de cada valor
+func printResolvedConfig(resolved *config.Resolved, path string) {
+	fmt.Println("⚙️  Configuración efectiva")
+	switch {
+	case resolved.Path != "":
+		fmt.Printf("   Archivo: %s\n", resolved.Path)
+	case path != "":
+		fmt.Printf("   Archivo: %s (no existe)\n", path)
+	default:
+		fmt.Println("   Archivo: ninguno")
+	}
+	fmt.Println()
+
+	for _, entry := range resolved.Entries() {
+		fmt.Printf("   %-20s %-8s ← %s\n", entry.Key, entry.Value, entry.Origin)
+	}
+
+	if len(resolved.Config.Schedule) > 0 {
+		fmt.Println()
+		fmt.Println("   Reglas de horario (gana la primera que coincida):")
+		for _, rule := range resolved.Config.Schedule {
+			fmt.Printf("   %-20s %-24s %s\n", rule.Name, rule.Summary(), rule.Changes())
+		}
+		if _, name := resolved.Config.Resolve(time.Now()); name != "" {
+			fmt.Printf("   Ahora se aplica: %s\n", name)
+		}
+	}
+
+	if len(resolved.Aliases) > 0 {
+		names := make([]string, 0, len(resolved.Aliases))
+		for name := range resolved.Aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println()
+		fmt.Println("   Alias de comandos:")
+		for _, name := range names {
+			fmt.Printf("   %-20s %s\n", name, resolved.Aliases[name])
+		}
+	}
+
+	if count := resolved.Hooks.Count(); count > 0 {
+		fmt.Println()
+		fmt.Printf("   Hooks (%d, máximo %d a la vez, %s cada uno):\n",
+			count, resolved.Hooks.MaxConcurrent, config.FormatConfigDuration(resolved.Hooks.Timeout))
+		for _, eventType := range resolved.Hooks.EventTypes() {
+			for _, command := range resolved.Hooks.Commands[eventType] {
+				fmt.Printf("   %-20s %s\n", eventType, command)
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("   Límites:")
+	for _, line := range boundsLines(resolved.Bounds) {
+		fmt.Printf("   %s\n", line)
+	}
+
+	fmt.Println()
+	fmt.Printf("💡 Precedencia: flags > entorno (%s*) > archivo > preset > valores por defecto\n", config.EnvPrefix)
+}
+
+// boundsLines formatea los límites de validación, uno por línea
+func boundsLines(bounds config.Bounds) []string {
+	durationLine := func(key string, r config.DurationRange) string {
+		return fmt.Sprintf("%-20s %s – %s", key, config.FormatConfigDuration(r.Min), config.FormatConfigDuration(r.Max))
+	}
+	return []string{
+		durationLine("work_duration", bounds.WorkDuration),
+		durationLine("short_break", bounds.ShortBreak),
+		durationLine("long_break", bounds.LongBreak),
+		fmt.Sprintf("%-20s %d – %d", "long_break_interval", bounds.LongBreakInterval.Min, bounds.LongBreakInterval.Max),
+	}
+}
+
+// printConfigUsage muestra la ayuda del subcomando de configuración
+func printConfigUsage() {
+	fmt.Println("Uso: pomodoro config show [-config archivo] [-preset nombre] [flags de configuración]")
+	fmt.Println()
+	fmt.Println("Muestra la configuración efectiva y de dónde proviene cada valor:")
+	fmt.Println("valores por defecto, archivo, variables de entorno o flags.")
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-cli/internal/daemon"
+	"github.com/kubaliski/pomodoro-cli/internal/handlers"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// controlCommands son los subcomandos que controlan un daemon en marcha
+var controlCommands = map[string]bool{
+	"start": true, "pause": true, "resume": true, "skip": true,
+	"extend": true, "task": true, "events": true,
+}
+
+// runControl ejecuta un subcomando de control contra el daemon y retorna el código de salida
+// (3 si el daemon no está en marcha).
+// Uso: <start|pause|resume|skip|extend|task|events> [-socket ruta] [-json] [argumentos]
+func runControl(command string, args []string) int {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	socketPath := flags.String("socket", daemon.DefaultSocketPath(), "Socket de control del daemon")
+	rawJSON := flags.Bool("json", false, "Mostrar la respuesta del daemon en JSON")
+	flags.Usage = printControlUsage
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	client, err := daemon.Dial(*socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		fmt.Fprintln(os.Stderr, "💡 Inícialo con 'pomodoro daemon'")
+		return 3
+	}
+	defer client.Close()
+
+	if command == "events" {
+		return runEvents(client, flags.Args(), *rawJSON)
+	}
+
+	method, params, err := controlRequest(command, flags.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	var result json.RawMessage
+	if err := client.Call(method, params, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		if errors.Is(err, daemon.ErrNotRunning) {
+			return 3
+		}
+		return 1
+	}
+
+	if *rawJSON {
+		fmt.Println(string(result))
+		return 0
+	}
+	if method == daemon.MethodTask {
+		var labels stats.Labels
+		if err := json.Unmarshal(result, &labels); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		printRemoteTask(labels)
+		return 0
+	}
+
+	var status daemon.Status
+	if err := json.Unmarshal(result, &status); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	printControlResult(command, flags.Args(), status)
+	return 0
+}
+
+// controlRequest traduce un subcomando y sus argumentos a una petición del protocolo
+func controlRequest(command string, args []string) (string, interface{}, error) {
+	switch command {
+	case "extend":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("uso: pomodoro extend <duración> (ej: 5m)")
+		}
+		return daemon.MethodExtend, daemon.ExtendParams{Duration: args[0]}, nil
+
+	case "task":
+		if len(args) == 0 {
+			return daemon.MethodTask, nil, nil
+		}
+		if len(args) == 1 && (strings.EqualFold(args[0], "clear") || strings.EqualFold(args[0], "limpiar") || args[0] == "-") {
+			return daemon.MethodTask, daemon.TaskParams{}, nil
+		}
+		labels, err := handlers.ParseLabels(args)
+		if err != nil {
+			return "", nil, err
+		}
+		return daemon.MethodTask, daemon.TaskParams{Labels: labels}, nil
+
+	default:
+		if len(args) > 0 {
+			return "", nil, fmt.Errorf("%s no admite argumentos", command)
+		}
+		return command, nil, nil
+	}
+}
+
+// printControlResult muestra el resultado de un subcomando de control
+func printControlResult(command string, args []string, status daemon.Status) {
+	switch command {
+	case "start":
+		fmt.Printf("▶️  Pomodoro #%d iniciado (%s)\n", status.Pomodoro, ui.FormatDuration(status.Total))
+	case "pause":
+		fmt.Printf("⏸️  Timer pausado (%s restantes)\n", ui.FormatDuration(status.Remaining))
+	case "resume":
+		fmt.Printf("▶️  Timer reanudado (%s restantes)\n", ui.FormatDuration(status.Remaining))
+	case "skip":
+		fmt.Println("⏭️  Sesión saltada")
+	case "extend":
+		fmt.Printf("⏱️  +%s · %s restantes\n", args[0], ui.FormatDuration(status.Remaining))
+	default:
+		printRemoteStatus(status)
+	}
+}
+
+// printRemoteStatus muestra el estado del daemon
+func printRemoteStatus(status daemon.Status) {
+	if !status.Started {
+		fmt.Printf("⏳ Listo para el Pomodoro #%d (%s) · usa 'pomodoro start'\n", status.Pomodoro, ui.FormatDuration(status.Total))
+	} else {
+		state := ui.SessionLabel(status.Session)
+		if status.Session.IsWork() {
+			state += fmt.Sprintf(" · Pomodoro #%d", status.Pomodoro)
+		}
+		if status.Paused {
+			state += " · PAUSADO"
+		}
+		fmt.Printf("🍅 %s\n", state)
+		fmt.Printf("   %s / %s (%.0f%%)\n", ui.FormatDuration(status.Remaining), ui.FormatDuration(status.Total), status.Progress*100)
+	}
+
+	if !status.Labels.IsEmpty() {
+		fmt.Printf("   Tarea: %s\n", status.Labels.Summary())
+	}
+	if status.Rule != "" {
+		fmt.Printf("   Regla: %s\n", status.Rule)
+	}
+	if status.Today != nil {
+		fmt.Printf("   Hoy: %d completados · %d saltados · %s de enfoque\n",
+			status.Today.PomodorosCompleted, status.Today.PomodorosSkipped, stats.FormatDuration(status.Today.FocusTime))
+	}
+}
+
+// printRemoteTask muestra la tarea asignada en el daemon
+func printRemoteTask(labels stats.Labels) {
+	if labels.IsEmpty() {
+		fmt.Println("🏷️  Sin tarea asignada")
+		return
+	}
+	fmt.Printf("🏷️  Tarea actual: %s\n", labels.Summary())
+}
+
+// runEvents muestra los eventos del daemon hasta que se interrumpe o se cierra la conexión
+func runEvents(client *daemon.Client, args []string, rawJSON bool) int {
+	types := make([]events.EventType, 0, len(args))
+	for _, arg := range args {
+		types = append(types, events.EventType(arg))
+	}
+
+	err := client.Subscribe(types, nil, func(event daemon.RemoteEvent) {
+		if rawJSON {
+			line, _ := json.Marshal(event)
+			fmt.Println(string(line))
+			return
+		}
+		fmt.Printf("%s %s %s\n", event.Timestamp.Format("15:04:05"), event.Type, event.Data)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printControlUsage muestra la ayuda de los subcomandos de control
+func printControlUsage() {
+	fmt.Println("Uso: pomodoro <comando> [-socket ruta] [-json] [argumentos]")
+	fmt.Println()
+	fmt.Println("Comandos (requieren 'pomodoro daemon' en marcha):")
+	fmt.Println("   start                 Empezar la primera sesión")
+	fmt.Println("   pause | resume        Pausar o reanudar el timer")
+	fmt.Println("   skip                  Saltar la sesión actual")
+	fmt.Println("   status                Mostrar el estado actual (ver 'pomodoro status -h')")
+	fmt.Println("   extend <duración>     Añadir tiempo a la sesión actual (ej: 5m)")
+	fmt.Println("   task [nombre @proyecto #tag ~N | clear]")
+	fmt.Println("                         Consultar o cambiar la tarea actual")
+	fmt.Println("   events [tipo ...]     Seguir los eventos del engine (ej: pomodoro_completed)")
+}
@@ -0,0 +1,176 @@
+// Plugin de ejemplo para la CLI: cuenta los pomodoros completados, avisa cada cuatro y añade
+// los comandos count, remind y crash. Implementa el protocolo sin depender de la CLI, como
+// lo haría un plugin externo: JSON por líneas en la entrada y la salida estándar, y los
+// mensajes de depuración en la salida de error.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// protocolVersion es la versión del protocolo que habla este plugin
+const protocolVersion = 1
+
+// message es un mensaje del protocolo (solo los campos que usa este plugin)
+type message struct {
+	Type     string        `json:"type"`
+	ID       int64         `json:"id,omitempty"`
+	Protocol int           `json:"protocol,omitempty"`
+	Name     string        `json:"name,omitempty"`
+	Version  string        `json:"version,omitempty"`
+	Commands []commandSpec `json:"commands,omitempty"`
+	Events   []string      `json:"events,omitempty"`
+	Event    *event        `json:"event,omitempty"`
+	Command  string        `json:"command,omitempty"`
+	Args     []string      `json:"args,omitempty"`
+	Action   string        `json:"action,omitempty"`
+	Text     string        `json:"text,omitempty"`
+	Output   string        `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+type commandSpec struct {
+	Name  string `json:"name"`
+	Usage string `json:"usage,omitempty"`
+	Help  string `json:"help,omitempty"`
+}
+
+type event struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// plugin guarda el estado del contador y serializa la escritura de mensajes
+type plugin struct {
+	mu        sync.Mutex
+	encoder   *json.Encoder
+	completed int
+	skipped   int
+	actions   int64 // IDs de las acciones enviadas
+}
+
+func main() {
+	log.SetOutput(os.Stderr)
+	log.SetPrefix("counter: ")
+
+	p := &plugin{encoder: json.NewEncoder(os.Stdout)}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	// Handshake: la CLI saluda primero con su versión del protocolo
+	if !scanner.Scan() {
+		return
+	}
+	var hello message
+	if err := json.Unmarshal(scanner.Bytes(), &hello); err != nil || hello.Type != "hello" {
+		log.Fatalf("expected hello, got %q", scanner.Text())
+	}
+	if hello.Protocol < protocolVersion {
+		log.Fatalf("host speaks protocol %d, this plugin needs %d", hello.Protocol, protocolVersion)
+	}
+	p.send(message{
+		Type:     "hello",
+		Protocol: protocolVersion,
+		Name:     "counter",
+		Version:  "1.0.0",
+		Events:   []string{"pomodoro_completed", "pomodoro_skipped"},
+		Commands: []commandSpec{
+			{Name: "count", Help: "Pomodoros contados por el plugin"},
+			{Name: "remind", Usage: "texto", Help: "Mostrar un recordatorio como aviso"},
+			{Name: "crash", Help: "Terminar el plugin con error (prueba de reinicio)"},
+		},
+	})
+
+	// Al cerrarse la entrada (la CLI terminó) el plugin también termina
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			log.Printf("invalid message: %v", err)
+			continue
+		}
+
+		switch msg.Type {
+		case "event":
+			p.handleEvent(msg.Event)
+		case "invoke":
+			p.handleInvoke(msg)
+		case "result":
+			if msg.Error != "" {
+				log.Printf("action %d failed: %s", msg.ID, msg.Error)
+			}
+		case "shutdown":
+			return
+		}
+	}
+}
+
+// handleEvent cuenta los pomodoros y avisa cada cuatro completados
+func (p *plugin) handleEvent(ev *event) {
+	if ev == nil {
+		return
+	}
+
+	p.mu.Lock()
+	switch ev.Type {
+	case "pomodoro_completed":
+		p.completed++
+	case "pomodoro_skipped":
+		p.skipped++
+	}
+	completed := p.completed
+	p.mu.Unlock()
+
+	if ev.Type == "pomodoro_completed" && completed%4 == 0 {
+		p.send(message{Type: "action", Action: "message",
+			Text: fmt.Sprintf("🍅 %d pomodoros en esta sesión, toca un descanso largo", completed)})
+	}
+}
+
+// handleInvoke ejecuta uno de los comandos del plugin y contesta con su resultado
+func (p *plugin) handleInvoke(msg message) {
+	result := message{Type: "result", ID: msg.ID}
+
+	switch msg.Command {
+	case "count":
+		p.mu.Lock()
+		result.Output = fmt.Sprintf("🍅 %d completados, %d saltados desde que arrancó el plugin", p.completed, p.skipped)
+		p.mu.Unlock()
+	case "remind":
+		if len(msg.Args) == 0 {
+			result.Error = "uso: remind <texto>"
+			break
+		}
+		// Las acciones con ID reciben un "result"; sin ID, los fallos los muestra la CLI
+		p.send(message{Type: "action", ID: p.nextActionID(), Action: "message", Text: "⏰ " + strings.Join(msg.Args, " ")})
+		result.Output = "Recordatorio enviado"
+	case "crash":
+		log.Fatal("crash requested")
+	default:
+		result.Error = fmt.Sprintf("unknown command %s", msg.Command)
+	}
+
+	p.send(result)
+}
+
+// nextActionID retorna el ID de la siguiente acción
+func (p *plugin) nextActionID() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.actions++
+	return p.actions
+}
+
+// send escribe un mensaje en la salida estándar
+func (p *plugin) send(msg message) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.encoder.Encode(msg); err != nil {
+		log.Fatalf("failed to write message: %v", err)
+	}
+}
@@ -2,6 +2,11 @@ module github.com/kubaliski/pomodoro-cli
 
 go 1.24.0
 
-require github.com/kubaliski/pomodoro-core v0.1.1
+require (
+	github.com/kubaliski/pomodoro-core v0.1.1
+	golang.org/x/term v0.30.0
+)
+
+require golang.org/x/sys v0.31.0 // indirect
 
 replace github.com/kubaliski/pomodoro-core => ../../core
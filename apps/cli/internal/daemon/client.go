@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// dialTimeout es el tiempo máximo para conectar con el daemon
+const dialTimeout = 2 * time.Second
+
+// ErrNotRunning indica que no hay ningún daemon escuchando en el socket
+var ErrNotRunning = errors.New("daemon is not running")
+
+// Client es una conexión de control con el daemon
+type Client struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	encoder *json.Encoder
+
+	mu     sync.Mutex
+	nextID int64
+}
+
+// Dial conecta con el daemon que escucha en socketPath
+func Dial(socketPath string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w on %s: %v", ErrNotRunning, socketPath, err)
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &Client{
+		conn:    conn,
+		scanner: scanner,
+		encoder: json.NewEncoder(conn),
+	}, nil
+}
+
+// Close cierra la conexión
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Call envía una petición y decodifica su resultado en result (puede ser nil)
+func (c *Client) Call(method string, params, result interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	response, err := c.send(method, params)
+	if err != nil {
+		return err
+	}
+	if result != nil && len(response.Result) > 0 {
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// Status consulta el estado del daemon
+func (c *Client) Status() (Status, error) {
+	var status Status
+	err := c.Call(MethodStatus, nil, &status)
+	return status, err
+}
+
+// Subscribe pide los eventos de los tipos indicados (todos si no se indica ninguno) y llama a
+// handler con cada uno hasta que la conexión se cierra. Antes del primer evento llama a
+// started (si no es nil) con el estado del daemon.
+func (c *Client) Subscribe(types []events.EventType, started func(Status), handler func(RemoteEvent)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	response, err := c.send(MethodSubscribe, SubscribeParams{Events: types})
+	if err != nil {
+		return err
+	}
+	if started != nil {
+		var status Status
+		if err := json.Unmarshal(response.Result, &status); err != nil {
+			return fmt.Errorf("failed to decode subscribe result: %w", err)
+		}
+		started(status)
+	}
+
+	for c.scanner.Scan() {
+		var message struct {
+			Event *RemoteEvent `json:"event"`
+		}
+		if err := json.Unmarshal(c.scanner.Bytes(), &message); err != nil {
+			return fmt.Errorf("invalid event from daemon: %w", err)
+		}
+		if message.Event != nil {
+			handler(*message.Event)
+		}
+	}
+	if err := c.scanner.Err(); err != nil {
+		return fmt.Errorf("connection to daemon lost: %w", err)
+	}
+	return nil
+}
+
+// RemoteEvent es un evento del engine recibido del daemon. Data conserva el JSON original
+// porque su estructura depende del tipo.
+type RemoteEvent struct {
+	Type      events.EventType `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// send escribe una petición y lee su respuesta (requiere c.mu)
+func (c *Client) send(method string, params interface{}) (Response, error) {
+	c.nextID++
+	request := Request{ID: c.nextID, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return Response{}, fmt.Errorf("failed to encode %s params: %w", method, err)
+		}
+		request.Params = data
+	}
+
+	if err := c.encoder.Encode(request); err != nil {
+		return Response{}, fmt.Errorf("failed to send %s: %w", method, err)
+	}
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return Response{}, fmt.Errorf("failed to read %s response: %w", method, err)
+		}
+		return Response{}, fmt.Errorf("daemon closed the connection")
+	}
+
+	var response Response
+	if err := json.Unmarshal(c.scanner.Bytes(), &response); err != nil {
+		return Response{}, fmt.Errorf("invalid response from daemon: %w", err)
+	}
+	if response.Error != "" {
+		return response, errors.New(response.Error)
+	}
+	return response, nil
+}
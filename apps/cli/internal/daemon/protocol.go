@@ -0,0 +1,104 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// El protocolo es JSON delimitado por líneas sobre un socket Unix: el cliente escribe una
+// Request por línea y el daemon contesta con una Response por línea con el mismo ID.
+// Tras "subscribe" la conexión queda dedicada a recibir eventos hasta que se cierra.
+
+// Métodos del protocolo de control
+const (
+	MethodStart     = "start"
+	MethodPause     = "pause"
+	MethodResume    = "resume"
+	MethodSkip      = "skip"
+	MethodStatus    = "status"
+	MethodExtend    = "extend"
+	MethodTask      = "task"
+	MethodSubscribe = "subscribe"
+)
+
+// Request es una petición de un cliente
+type Request struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response es la respuesta a una petición o, tras "subscribe", un evento del engine
+type Response struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Event  *events.Event   `json:"event,omitempty"`
+}
+
+// ExtendParams son los parámetros de "extend"
+type ExtendParams struct {
+	Duration string `json:"duration"` // Ej: "5m", "90s"
+}
+
+// TaskParams son los parámetros de "task". Sin parámetros solo se consulta la tarea actual;
+// con Labels vacías se elimina.
+type TaskParams struct {
+	Labels stats.Labels `json:"labels"`
+}
+
+// SubscribeParams son los parámetros de "subscribe" (sin tipos se reciben todos los eventos)
+type SubscribeParams struct {
+	Events []events.EventType `json:"events,omitempty"`
+}
+
+// Status es el resultado de "status"
+type Status struct {
+	State     engine.State       `json:"state"`
+	Started   bool               `json:"started"` // Ya empezó la primera sesión
+	Session   events.SessionKind `json:"session"`
+	Paused    bool               `json:"paused"`
+	Remaining time.Duration      `json:"remaining"`
+	Total     time.Duration      `json:"total"`
+	Progress  float64            `json:"progress"`
+	Pomodoro  int                `json:"pomodoro"`  // Número del pomodoro en curso o del siguiente
+	Completed int                `json:"completed"` // Pomodoros completados desde que arrancó el daemon
+	Labels    stats.Labels       `json:"labels"`
+	Rule      string             `json:"rule,omitempty"`
+	Today     *TodayStatus       `json:"today,omitempty"` // Solo con historial
+}
+
+// TodayStatus son los totales de hoy según el historial
+type TodayStatus struct {
+	PomodorosCompleted int           `json:"pomodoros_completed"`
+	PomodorosSkipped   int           `json:"pomodoros_skipped"`
+	FocusTime          time.Duration `json:"focus_time"`
+}
+
+// DefaultSocketPath retorna la ruta del socket de control según XDG
+// ($XDG_RUNTIME_DIR/gomodoro.sock, o un archivo por usuario en el directorio temporal)
+func DefaultSocketPath() string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "gomodoro.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gomodoro-%d.sock", os.Getuid()))
+}
+
+// newResponse construye la respuesta a una petición con su resultado o error
+func newResponse(id int64, result interface{}, err error) Response {
+	if err != nil {
+		return Response{ID: id, Error: err.Error()}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return Response{ID: id, Error: fmt.Sprintf("failed to encode result: %v", err)}
+	}
+	return Response{ID: id, Result: data}
+}
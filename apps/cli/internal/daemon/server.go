@@ -0,0 +1,411 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kubaliski/pomodoro-cli/internal/notifications"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// subscriberBuffer es el número de eventos pendientes por suscriptor; si un cliente no
+// los lee a tiempo se descartan en lugar de bloquear el engine
+const subscriberBuffer = 64
+
+// Server mantiene el engine en segundo plano y lo expone por un socket Unix
+type Server struct {
+	engine              engine.EngineInterface
+	notificationManager *notifications.Manager
+	socketPath          string
+
+	mu            sync.Mutex
+	historyStore  stats.Store
+	historyUserID string
+	rule          string
+	subscribers   map[*subscriber]struct{}
+}
+
+// subscriber es una conexión que recibe eventos del engine
+type subscriber struct {
+	types  map[events.EventType]bool // Vacío = todos
+	events chan events.Event
+}
+
+// NewServer crea el daemon para el engine, escuchando en socketPath al ejecutarse
+func NewServer(eng engine.EngineInterface, socketPath string) *Server {
+	server := &Server{
+		engine:      eng,
+		socketPath:  socketPath,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+
+	// Sin terminal no hay avisos visuales; se mantiene el sonido y los mensajes van al log
+	server.notificationManager = notifications.NewManager(notifications.DefaultConfig())
+	soundNotifier := notifications.NewSoundNotifier()
+	soundNotifier.SetOutput(io.Discard)
+	if err := server.notificationManager.RegisterNotifier(soundNotifier); err != nil {
+		log.Printf("⚠️ Sonido no disponible: %v", err)
+	}
+
+	return server
+}
+
+// GetNotificationManager retorna el gestor de notificaciones del daemon
+func (s *Server) GetNotificationManager() *notifications.Manager {
+	return s.notificationManager
+}
+
+// SetHistoryStore establece el historial con el que "status" calcula los totales de hoy
+func (s *Server) SetHistoryStore(store stats.Store, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.historyStore = store
+	s.historyUserID = userID
+}
+
+// Notice escribe un aviso en el log del daemon
+func (s *Server) Notice(text string) {
+	log.Print(strings.TrimSpace(text))
+}
+
+// Run inicia el engine y atiende conexiones hasta que se cancela el contexto o llega SIGINT/SIGTERM
+func (s *Server) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := listen(s.socketPath)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	s.subscribe(s.engine.GetEventBus())
+	if err := s.engine.Start(ctx); err != nil {
+		return fmt.Errorf("error starting engine: %w", err)
+	}
+	defer s.engine.Stop()
+
+	log.Printf("🍅 Daemon escuchando en %s", s.socketPath)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("👋 Daemon detenido")
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go s.serve(ctx, conn)
+	}
+}
+
+// listen abre el socket de control. Si queda un socket de una ejecución anterior que ya
+// no responde se elimina; si responde es que hay otro daemon en marcha.
+func listen(socketPath string) (net.Listener, error) {
+	if _, err := os.Stat(socketPath); err == nil {
+		if conn, err := net.DialTimeout("unix", socketPath, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("daemon already running on %s", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	// Solo el usuario que lanzó el daemon puede controlarlo
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// serve atiende las peticiones de una conexión, una por línea
+func (s *Server) serve(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var request Request
+		if err := json.Unmarshal([]byte(line), &request); err != nil {
+			encoder.Encode(Response{Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+
+		if request.Method == MethodSubscribe {
+			s.stream(ctx, conn, encoder, request)
+			return
+		}
+
+		result, err := s.handle(request)
+		if err := encoder.Encode(newResponse(request.ID, result, err)); err != nil {
+			return
+		}
+	}
+}
+
+// handle ejecuta una petición y retorna su resultado
+func (s *Server) handle(request Request) (interface{}, error) {
+	switch request.Method {
+	case MethodStart:
+		if s.engine.GetState() != engine.StateIdle {
+			return nil, fmt.Errorf("session already started")
+		}
+		if err := s.engine.StartFirstSession(); err != nil {
+			return nil, err
+		}
+		return s.status(), nil
+
+	case MethodPause:
+		if err := s.requireStarted(); err != nil {
+			return nil, err
+		}
+		if err := s.engine.Pause(); err != nil {
+			return nil, err
+		}
+		return s.status(), nil
+
+	case MethodResume:
+		if err := s.requireStarted(); err != nil {
+			return nil, err
+		}
+		if err := s.engine.Resume(); err != nil {
+			return nil, err
+		}
+		return s.status(), nil
+
+	case MethodSkip:
+		if err := s.requireStarted(); err != nil {
+			return nil, err
+		}
+		if err := s.engine.Skip(); err != nil {
+			return nil, err
+		}
+		return s.status(), nil
+
+	case MethodStatus:
+		return s.status(), nil
+
+	case MethodExtend:
+		var params ExtendParams
+		if err := decodeParams(request.Params, &params); err != nil {
+			return nil, err
+		}
+		d, err := config.ParseConfigDuration(params.Duration)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.requireStarted(); err != nil {
+			return nil, err
+		}
+		if err := s.engine.Extend(d); err != nil {
+			return nil, err
+		}
+		return s.status(), nil
+
+	case MethodTask:
+		if len(request.Params) > 0 {
+			var params TaskParams
+			if err := decodeParams(request.Params, &params); err != nil {
+				return nil, err
+			}
+			s.engine.SetLabels(params.Labels)
+		}
+		return s.engine.GetLabels(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown method: %s", request.Method)
+	}
+}
+
+// stream envía los eventos del engine a la conexión hasta que el cliente la cierra
+func (s *Server) stream(ctx context.Context, conn net.Conn, encoder *json.Encoder, request Request) {
+	var params SubscribeParams
+	if err := decodeParams(request.Params, &params); err != nil {
+		encoder.Encode(newResponse(request.ID, nil, err))
+		return
+	}
+
+	sub := &subscriber{
+		types:  make(map[events.EventType]bool, len(params.Events)),
+		events: make(chan events.Event, subscriberBuffer),
+	}
+	for _, eventType := range params.Events {
+		sub.types[eventType] = true
+	}
+
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, sub)
+		s.mu.Unlock()
+	}()
+
+	if err := encoder.Encode(newResponse(request.ID, s.status(), nil)); err != nil {
+		return
+	}
+
+	// El cliente no envía nada más: una lectura que termina indica que cerró la conexión
+	closed := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, conn)
+		close(closed)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closed:
+			return
+		case event := <-sub.events:
+			if err := encoder.Encode(Response{ID: request.ID, Event: &event}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// publish reparte un evento entre los suscriptores interesados sin bloquear
+func (s *Server) publish(event events.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		if len(sub.types) > 0 && !sub.types[event.Type] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// requireStarted retorna un error si todavía no ha empezado la primera sesión
+func (s *Server) requireStarted() error {
+	if s.engine.GetState() == engine.StateIdle {
+		return fmt.Errorf("no session started yet, use start first")
+	}
+	return nil
+}
+
+// status construye el estado actual del daemon
+func (s *Server) status() Status {
+	state := s.engine.GetState()
+	status := Status{
+		State:     state,
+		Started:   state != engine.StateIdle,
+		Session:   s.engine.GetCurrentSession(),
+		Paused:    state == engine.StatePaused,
+		Completed: s.engine.GetPomodoroCount(),
+		Pomodoro:  s.engine.GetPomodoroCount() + 1,
+		Labels:    s.engine.GetLabels(),
+	}
+	if status.Started {
+		timer := s.engine.GetTimerData()
+		status.Remaining = timer.Remaining
+		status.Total = timer.Total
+		status.Progress = timer.Progress
+	} else {
+		idle, _ := s.engine.GetConfig().Resolve(time.Now())
+		status.Remaining = idle.WorkDuration
+		status.Total = idle.WorkDuration
+	}
+
+	// El informe lee el historial: se genera fuera del cerrojo para no frenar publish
+	s.mu.Lock()
+	status.Rule = s.rule
+	store, userID := s.historyStore, s.historyUserID
+	s.mu.Unlock()
+	if store != nil {
+		report, err := stats.GenerateReport(store, userID, stats.PeriodDay, time.Now(), time.Local)
+		if err == nil {
+			status.Today = &TodayStatus{
+				PomodorosCompleted: report.Total.PomodorosCompleted,
+				PomodorosSkipped:   report.Total.PomodorosSkipped,
+				FocusTime:          report.Total.FocusTime,
+			}
+		}
+	}
+	return status
+}
+
+// subscribe sigue los eventos del engine para el estado, las notificaciones y los suscriptores
+func (s *Server) subscribe(eventBus *events.EventBus) {
+	eventBus.SubscribeGlobalFunc(s.publish)
+
+	eventBus.SubscribeFunc(events.PomodoroStarted, func(event events.Event) {
+		if data, ok := event.Data.(events.PomodoroEventData); ok {
+			s.setRule(data.Rule)
+			log.Printf("🍅 Pomodoro #%d iniciado (%s)", data.Number, ui.FormatDuration(data.Duration))
+		}
+	})
+	eventBus.SubscribeFunc(events.BreakStarted, func(event events.Event) {
+		if data, ok := event.Data.(events.BreakEventData); ok {
+			s.setRule(data.Rule)
+			log.Printf("☕ %s iniciado (%s)", ui.SessionLabel(data.Type), ui.FormatDuration(data.Duration))
+		}
+	})
+	eventBus.SubscribeFunc(events.PomodoroCompleted, func(event events.Event) {
+		if data, ok := event.Data.(events.PomodoroEventData); ok {
+			s.notificationManager.NotifyPomodoroCompleted(data.Number, data.NextDuration)
+		}
+	})
+	eventBus.SubscribeFunc(events.BreakCompleted, func(event events.Event) {
+		if data, ok := event.Data.(events.BreakEventData); ok {
+			s.notificationManager.NotifyBreakCompleted(ui.SessionLabel(data.Type), s.engine.GetPomodoroCount()+1)
+		}
+	})
+}
+
+// setRule guarda la regla de horario de la sesión que empieza
+func (s *Server) setRule(rule string) {
+	s.mu.Lock()
+	s.rule = rule
+	s.mu.Unlock()
+}
+
+// decodeParams interpreta los parámetros de una petición (pueden faltar)
+func decodeParams(raw json.RawMessage, target interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
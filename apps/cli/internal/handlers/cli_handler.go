@@ -3,12 +3,18 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/kubaliski/pomodoro-cli/internal/notifications"
+	"github.com/kubaliski/pomodoro-cli/internal/plugins"
+	"github.com/kubaliski/pomodoro-core/config"
 	"github.com/kubaliski/pomodoro-core/engine"
 	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
 )
 
 // CLIHandler maneja la interfaz CLI conectando el core con la UI
@@ -16,6 +22,9 @@ type CLIHandler struct {
 	// Core components
 	engine              engine.EngineInterface
 	notificationManager *notifications.Manager
+	historyStore        stats.Store
+	historyUserID       string
+	presets             *config.PresetRegistry
 
 	// Estado de la UI
 	currentTimerData    events.TimerEventData
@@ -36,6 +45,8 @@ type CLIHandler struct {
 	commandProcessor *CommandProcessor
 	notificationCmds *NotificationCommands
 	statsCmds        *StatsCommands
+	taskCmds         *TaskCommands
+	pluginCmds       *PluginCommands
 	uiHelpers        *UIHelpers
 	inputManager     *InputManager
 }
@@ -64,10 +75,13 @@ func NewCLIHandler(eng engine.EngineInterface) *CLIHandler {
 	// Inicializar sub-componentes
 	handler.inputManager = NewInputManager()
 	handler.eventHandler = NewEventHandler(handler)
-	handler.commandProcessor = NewCommandProcessor(handler)
 	handler.notificationCmds = NewNotificationCommands(handler)
 	handler.statsCmds = NewStatsCommands(handler)
+	handler.taskCmds = NewTaskCommands(handler)
+	handler.pluginCmds = NewPluginCommands(handler)
 	handler.uiHelpers = NewUIHelpers(handler)
+	// Al final: el registro de comandos enlaza con los demás sub-componentes
+	handler.commandProcessor = NewCommandProcessor(handler)
 
 	// Configurar eventos
 	handler.eventHandler.SetupEventHandlers(eng.GetEventBus())
@@ -80,17 +94,28 @@ func NewCLIHandler(eng engine.EngineInterface) *CLIHandler {
 
 // Run ejecuta la interfaz CLI
 func (h *CLIHandler) Run(ctx context.Context) error {
+	// Ctrl+C también termina de forma ordenada, como el comando q
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	h.uiHelpers.ShowConfiguration()
 
 	if err := h.engine.Start(ctx); err != nil {
 		return fmt.Errorf("error starting engine: %w", err)
 	}
+	defer h.engine.Stop()
 
 	h.uiHelpers.ShowInitialPrompt()
-	h.inputManager.HandleInput(h.commandProcessor.ProcessCommand)
+	h.inputManager.HandleInput(ctx, h.commandProcessor.ProcessCommand)
 	return nil
 }
 
+// Notice muestra un aviso y vuelve a mostrar el prompt de comandos
+func (h *CLIHandler) Notice(text string) {
+	fmt.Printf("\n%s\n", text)
+	fmt.Print("Comando > ")
+}
+
 // Getters para acceso a componentes internos
 func (h *CLIHandler) GetEngine() engine.EngineInterface              { return h.engine }
 func (h *CLIHandler) GetNotificationManager() *notifications.Manager { return h.notificationManager }
@@ -99,8 +124,60 @@ func (h *CLIHandler) GetEventHandler() *EventHandler                 { return h.
 func (h *CLIHandler) GetCommandProcessor() *CommandProcessor         { return h.commandProcessor }
 func (h *CLIHandler) GetNotificationCommands() *NotificationCommands { return h.notificationCmds }
 func (h *CLIHandler) GetStatsCommands() *StatsCommands               { return h.statsCmds }
+func (h *CLIHandler) GetTaskCommands() *TaskCommands                 { return h.taskCmds }
+func (h *CLIHandler) GetPluginCommands() *PluginCommands             { return h.pluginCmds }
 func (h *CLIHandler) GetUIHelpers() *UIHelpers                       { return h.uiHelpers }
 
+// SetPresets establece los presets que puede aplicar el comando preset
+func (h *CLIHandler) SetPresets(presets *config.PresetRegistry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.presets = presets
+}
+
+// GetPresets retorna los presets disponibles (los incluidos si no se estableció ninguno)
+func (h *CLIHandler) GetPresets() *config.PresetRegistry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.presets == nil {
+		return config.NewPresetRegistry()
+	}
+	return h.presets
+}
+
+// SetAliases sustituye los alias definidos en el archivo de configuración
+func (h *CLIHandler) SetAliases(aliases map[string]string) error {
+	return h.commandProcessor.SetFileMacros(aliases)
+}
+
+// SetPlugins registra los comandos de los plugins. Debe llamarse antes de SetAliases para
+// que los alias del archivo no oculten esos comandos.
+func (h *CLIHandler) SetPlugins(manager *plugins.Manager) error {
+	return h.pluginCmds.Register(manager)
+}
+
+// SetHistoryStore establece el almacenamiento del historial persistente y su usuario
+func (h *CLIHandler) SetHistoryStore(store stats.Store, userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.historyStore = store
+	h.historyUserID = userID
+}
+
+// GetHistoryStore retorna el historial persistente (nil si está desactivado)
+func (h *CLIHandler) GetHistoryStore() stats.Store {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.historyStore
+}
+
+// GetHistoryUserID retorna el usuario con el que se registra el historial
+func (h *CLIHandler) GetHistoryUserID() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.historyUserID
+}
+
 // Thread-safe getters para estado
 func (h *CLIHandler) IsFirstSessionStarted() bool {
 	h.mu.RLock()
@@ -176,9 +253,15 @@ func (h *CLIHandler) UpdateLastAlert(minute int) {
 	h.lastAlertTime = time.Now()
 }
 
+// GetCurrentConfig retorna la configuración que aplicaría ahora y la regla de horario usada
+func (h *CLIHandler) GetCurrentConfig() (*config.Config, string) {
+	return h.engine.GetConfig().Resolve(time.Now())
+}
+
 // Helper method para obtener información del próximo descanso
 func (h *CLIHandler) GetNextBreakInfo(pomodoroNumber int) (string, time.Duration) {
-	cfg := h.engine.GetConfig()
+	// El descanso empieza ahora, así que se usan los tiempos de la regla de horario actual
+	cfg, _ := h.GetCurrentConfig()
 
 	// Si es el primer pomodoro (número 1), siempre es descanso corto
 	if pomodoroNumber == 1 {
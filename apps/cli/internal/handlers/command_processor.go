@@ -2,19 +2,43 @@ package handlers
 
 import (
 	"fmt"
-	"os"
+	"sort"
+	"strings"
 
+	"github.com/kubaliski/pomodoro-cli/internal/notifications"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/config"
 	"github.com/kubaliski/pomodoro-core/engine"
 )
 
+// Secciones de la ayuda
+const (
+	groupTimer         = "⏱️  CONTROL DEL TIMER"
+	groupStats         = "📊 ESTADÍSTICAS"
+	groupTasks         = "🏷️  TAREAS"
+	groupNotifications = "🔔 NOTIFICACIONES"
+	groupAliases       = "⚡ ALIAS"
+	groupPlugins       = "🧩 PLUGINS"
+	groupExtras        = "🎨 EXTRAS"
+)
+
 // CommandProcessor maneja el procesamiento y routing de comandos
 type CommandProcessor struct {
-	handler *CLIHandler
+	handler  *CLIHandler
+	registry *CommandRegistry
+	macros   *MacroSet
 }
 
 // NewCommandProcessor crea un nuevo procesador de comandos
 func NewCommandProcessor(h *CLIHandler) *CommandProcessor {
-	return &CommandProcessor{handler: h}
+	cp := &CommandProcessor{handler: h, registry: NewCommandRegistry(), macros: NewMacroSet()}
+	cp.registerCommands()
+	return cp
+}
+
+// GetRegistry retorna el registro de comandos del modo principal
+func (cp *CommandProcessor) GetRegistry() *CommandRegistry {
+	return cp.registry
 }
 
 // ProcessCommand procesa un comando de entrada y lo rutea al handler apropiado
@@ -22,60 +46,186 @@ func (cp *CommandProcessor) ProcessCommand(input string) {
 	// Mostrar el comando escrito
 	fmt.Printf("%s\n", input)
 
-	switch input {
-	// Control básico del timer
-	case "p", "pause":
-		cp.handlePause()
-	case "r", "resume":
-		cp.handleResume()
-	case "s", "skip":
-		cp.handleSkip()
-	case "q", "quit":
-		cp.handleQuit()
-	case "h", "help":
-		cp.handler.GetUIHelpers().ShowInlineHelp()
-
-	// Estadísticas
-	case "stats", "estadisticas":
-		cp.handler.GetStatsCommands().ShowDetailedStats()
-	case "compact", "compacto":
-		cp.handler.GetStatsCommands().ShowCompactStats()
-	case "status", "estado":
-		cp.handler.GetStatsCommands().ShowQuickStatus()
-
-	// Notificaciones
-	case "test-sound", "test-audio":
-		cp.handler.GetNotificationCommands().TestNotifications()
-	case "notifications", "notif", "notificaciones":
-		cp.handler.GetNotificationCommands().ShowSettings()
-	case "sound-on", "audio-on":
-		cp.handler.GetNotificationCommands().ToggleSound(true)
-	case "sound-off", "audio-off":
-		cp.handler.GetNotificationCommands().ToggleSound(false)
-	case "notif-stats", "notification-stats":
-		cp.handler.GetNotificationCommands().ShowStats()
-	case "volume-up", "vol+":
-		cp.handler.GetNotificationCommands().AdjustVolume(0.1)
-	case "volume-down", "vol-":
-		cp.handler.GetNotificationCommands().AdjustVolume(-0.1)
-
-	// UI y demos
-	case "demo", "themes", "temas":
-		cp.handler.GetUIHelpers().ShowThemeDemo()
-	case "test", "prueba":
-		cp.handler.GetUIHelpers().RunFeatureTest()
-
-	// Continuar
-	case "c", "continue", "":
-		cp.handleContinue()
-
-	default:
-		cp.handleUnknownCommand(input)
+	if err := cp.runLine(input, nil); err != nil {
+		printCommandError(err, "h")
 	}
 
 	cp.showPromptIfNeeded()
 }
 
+// registerCommands registra los comandos del modo principal (los argumentos conservan mayúsculas)
+func (cp *CommandProcessor) registerCommands() {
+	h := cp.handler
+	run := func(action func()) func(Args) error {
+		return func(Args) error {
+			action()
+			return nil
+		}
+	}
+	rest := func(action func([]string)) func(Args) error {
+		return func(args Args) error {
+			action(args.Rest())
+			return nil
+		}
+	}
+
+	cp.registry.MustRegister(
+		// Control básico del timer
+		&Command{Name: "continue", Aliases: []string{"c", ""}, Group: groupTimer, Basic: true,
+			Help: "Empezar el primer pomodoro o continuar al siguiente", Run: run(cp.handleContinue)},
+		&Command{Name: "pause", Aliases: []string{"p"}, Group: groupTimer,
+			Help: "Pausar timer actual", Run: run(cp.handlePause)},
+		&Command{Name: "resume", Aliases: []string{"r"}, Group: groupTimer,
+			Help: "Reanudar timer pausado", Run: run(cp.handleResume)},
+		&Command{Name: "skip", Aliases: []string{"s"}, Group: groupTimer,
+			Help: "Saltar sesión actual", Run: run(cp.handleSkip)},
+
+		&Command{Name: "preset", Aliases: []string{"tiempos"}, Group: groupTimer,
+			Args: []Arg{{Name: "nombre|trabajo/descanso[/largo]", Optional: true}},
+			Help: "Ver los presets o cambiar los tiempos desde la próxima sesión (ej: preset 90/20)",
+			Run:  cp.handlePreset},
+
+		// Estadísticas
+		&Command{Name: "stats", Aliases: []string{"estadisticas"}, Group: groupStats,
+			Args: []Arg{{Name: "tasks task|project|tag|rule", Optional: true, Rest: true}},
+			Help: "Estadísticas detalladas, o por tarea con 'stats tasks'",
+			Run: func(args Args) error {
+				if len(args.Rest()) > 0 {
+					h.GetStatsCommands().ShowTaskStats(args.Rest())
+				} else {
+					h.GetStatsCommands().ShowDetailedStats()
+				}
+				return nil
+			}},
+		&Command{Name: "compact", Aliases: []string{"compacto"}, Group: groupStats,
+			Help: "Ver estadísticas compactas", Run: run(h.GetStatsCommands().ShowCompactStats)},
+		&Command{Name: "status", Aliases: []string{"estado"}, Group: groupStats,
+			Help: "Estado rápido del timer", Run: run(h.GetStatsCommands().ShowQuickStatus)},
+		&Command{Name: "report", Aliases: []string{"reporte"}, Group: groupStats,
+			Args: []Arg{{Name: "day|week|month [zona]", Optional: true, Rest: true}},
+			Help: "Reporte del historial", Run: rest(h.GetStatsCommands().ShowReport)},
+		&Command{Name: "heatmap", Aliases: []string{"mapa"}, Group: groupStats,
+			Args: []Arg{{Name: "días [zona]", Optional: true, Rest: true}},
+			Help: "Mapa de actividad por día y hora", Run: rest(h.GetStatsCommands().ShowHeatmap)},
+		&Command{Name: "history", Aliases: []string{"historial"}, Group: groupStats,
+			Args: []Arg{{Name: "filtros", Optional: true, Rest: true}},
+			Help: "Historial filtrado y paginado", Run: rest(h.GetStatsCommands().ShowHistory)},
+
+		// Tareas e historial
+		&Command{Name: "task", Aliases: []string{"tarea"}, Group: groupTasks,
+			Args: []Arg{{Name: "nombre @proyecto #tag ~N | clear", Optional: true, Rest: true}},
+			Help: "Ver, asignar o quitar la tarea", Run: rest(h.GetTaskCommands().SetTask)},
+		&Command{Name: "export", Aliases: []string{"exportar"}, Group: groupTasks,
+			Args: []Arg{{Name: "csv|md|ics|json filtros", Optional: true, Rest: true}},
+			Help: "Exportar historial a archivo", Run: rest(h.GetStatsCommands().ExportHistory)},
+		&Command{Name: "import", Aliases: []string{"importar"}, Group: groupTasks,
+			Args: []Arg{{Name: "archivo"}},
+			Help: "Fusionar estadísticas de otro dispositivo",
+			Run: func(args Args) error {
+				h.GetStatsCommands().ImportHistory([]string{args.String("archivo")})
+				return nil
+			}},
+
+		// Notificaciones
+		&Command{Name: "test-sound", Aliases: []string{"test-audio"}, Group: groupNotifications, Basic: true,
+			Args: []Arg{{Name: "tipo", Optional: true, Choices: []string{"success", "gentle", "warning", "urgent", "start", "pause", "resume"}}},
+			Help: "Probar sonidos (todos o uno)",
+			Run: func(args Args) error {
+				if args.Has("tipo") {
+					h.GetNotificationCommands().TestSpecificSound(args.String("tipo"))
+				} else {
+					h.GetNotificationCommands().TestNotifications()
+				}
+				return nil
+			}},
+		&Command{Name: "notifications", Aliases: []string{"notif", "notificaciones"}, Group: groupNotifications, Basic: true,
+			Help: "Ver configuración", Run: run(h.GetNotificationCommands().ShowSettings)},
+		&Command{Name: "sound-on", Aliases: []string{"audio-on"}, Group: groupNotifications,
+			Help: "Activar sonido", Run: run(func() { h.GetNotificationCommands().ToggleSound(true) })},
+		&Command{Name: "sound-off", Aliases: []string{"audio-off"}, Group: groupNotifications,
+			Help: "Desactivar sonido", Run: run(func() { h.GetNotificationCommands().ToggleSound(false) })},
+		&Command{Name: "volume", Aliases: []string{"vol", "volumen"}, Group: groupNotifications,
+			Args: []Arg{{Name: "nivel", Type: ArgFloat}},
+			Help: "Fijar el volumen (0-1 o porcentaje, ej: 0.5 o 50%)",
+			Run: func(args Args) error {
+				return h.GetNotificationCommands().SetVolume(args.Float("nivel"))
+			}},
+		&Command{Name: "volume-up", Aliases: []string{"vol+"}, Group: groupNotifications,
+			Help: "Subir el volumen un 10%", Run: run(func() { h.GetNotificationCommands().AdjustVolume(0.1) })},
+		&Command{Name: "volume-down", Aliases: []string{"vol-"}, Group: groupNotifications,
+			Help: "Bajar el volumen un 10%", Run: run(func() { h.GetNotificationCommands().AdjustVolume(-0.1) })},
+		&Command{Name: "profile", Aliases: []string{"perfil"}, Group: groupNotifications,
+			Args: []Arg{{Name: "nombre", Optional: true, Choices: profileNames()}},
+			Help: "Ver los perfiles de notificación o aplicar uno",
+			Run: func(args Args) error {
+				if args.Has("nombre") {
+					h.GetNotificationCommands().ApplyProfile(args.String("nombre"))
+				} else {
+					h.GetNotificationCommands().ShowAvailableProfiles()
+				}
+				return nil
+			}},
+		&Command{Name: "notif-stats", Aliases: []string{"notification-stats"}, Group: groupNotifications,
+			Help: "Estadísticas de notificaciones", Run: run(h.GetNotificationCommands().ShowStats)},
+
+		// Alias de usuario
+		&Command{Name: "alias", Group: groupAliases,
+			Args: []Arg{{Name: "nombre = comando; comando $1", Optional: true, Rest: true}},
+			Help: "Ver los alias, uno concreto o definir uno para esta sesión",
+			Run:  cp.handleAlias},
+		&Command{Name: "unalias", Group: groupAliases,
+			Args: []Arg{{Name: "nombre"}},
+			Help: "Quitar un alias en esta sesión",
+			Run: func(args Args) error {
+				name := args.String("nombre")
+				if !cp.macros.Remove(name) {
+					return fmt.Errorf("no existe el alias '%s'", name)
+				}
+				fmt.Printf("🗑️  Alias '%s' eliminado\n", strings.ToLower(name))
+				return nil
+			}},
+
+		// Plugins (sus comandos se añaden a esta sección al registrarlos)
+		&Command{Name: "plugins", Group: groupPlugins,
+			Help: "Ver los plugins, su estado y sus comandos", Run: run(h.GetPluginCommands().ShowPlugins)},
+
+		// UI y demos
+		&Command{Name: "demo", Aliases: []string{"themes", "temas"}, Group: groupExtras, Basic: true,
+			Help: "Demostración de temas", Run: run(h.GetUIHelpers().ShowThemeDemo)},
+		&Command{Name: "test", Aliases: []string{"prueba"}, Group: groupExtras, Basic: true,
+			Help: "Prueba de características", Run: run(h.GetUIHelpers().RunFeatureTest)},
+		&Command{Name: "help", Aliases: []string{"h", "ayuda"}, Group: groupExtras, Basic: true,
+			Help: "Esta ayuda", Run: run(h.GetUIHelpers().ShowInlineHelp)},
+		&Command{Name: "quit", Aliases: []string{"q", "salir"}, Group: groupExtras, Basic: true,
+			Help: "Salir del programa", Run: run(cp.handleQuit)},
+	)
+}
+
+// profileNames retorna los nombres de los perfiles de notificación predefinidos
+func profileNames() []string {
+	profiles := notifications.GetPredefinedProfiles()
+	names := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// printCommandError informa de un comando desconocido (con sugerencias) o mal usado
+func printCommandError(err error, helpCommand string) {
+	// Solo se sugieren comandos si lo desconocido es lo que se escribió, no un paso de un alias
+	if unknown, ok := err.(*UnknownCommandError); ok {
+		fmt.Printf("❌ Comando '%s' no reconocido.\n", unknown.Name)
+		if len(unknown.Suggestions) > 0 {
+			fmt.Printf("💡 ¿Quisiste decir '%s'?\n", strings.Join(unknown.Suggestions, "', '"))
+		} else {
+			fmt.Printf("💡 Usa '%s' para ver comandos disponibles\n", helpCommand)
+		}
+		return
+	}
+	fmt.Printf("❌ %v\n", err)
+}
+
 // Timer control commands
 
 func (cp *CommandProcessor) handlePause() {
@@ -110,8 +260,8 @@ func (cp *CommandProcessor) handleSkip() {
 
 func (cp *CommandProcessor) handleQuit() {
 	fmt.Println("👋 Saliendo...")
-	cp.handler.GetEngine().Stop()
-	os.Exit(0)
+	// Run retorna y main cierra el historial, los plugins y los hooks antes de salir
+	cp.handler.GetInputManager().Stop()
 }
 
 func (cp *CommandProcessor) handleContinue() {
@@ -124,14 +274,215 @@ func (cp *CommandProcessor) handleContinue() {
 	// Si ya hay sesión corriendo, no hacer nada (el engine maneja las transiciones)
 }
 
-func (cp *CommandProcessor) handleUnknownCommand(input string) {
-	fmt.Printf("❌ Comando '%s' no reconocido.\n", input)
-	fmt.Println("💡 Usa 'h' para ver comandos disponibles")
+// handlePreset aplica un preset por nombre o unos tiempos como "90/20"; sin argumentos lista los presets
+func (cp *CommandProcessor) handlePreset(args Args) error {
+	spec := args.String("nombre|trabajo/descanso[/largo]")
+	presets := cp.handler.GetPresets()
+	eng := cp.handler.GetEngine()
+
+	if spec == "" {
+		current := config.Preset{Config: eng.GetConfig()}
+		fmt.Printf("⏱️  Tiempos actuales: %s\n", current.Summary())
+		for _, preset := range presets.List() {
+			fmt.Printf("   • %-12s %-18s %s\n", preset.Name, preset.Summary(), preset.Description)
+		}
+		fmt.Println("💡 Uso: preset <nombre> o preset trabajo/descanso[/largo] (ej: preset 90/20)")
+		return nil
+	}
+
+	cfg := eng.GetConfig()
+	name := spec
+	if strings.Contains(spec, "/") {
+		timings, err := config.ParseTimings(spec, cfg)
+		if err != nil {
+			return err
+		}
+		cfg = timings
+	} else {
+		preset, err := presets.Get(spec)
+		if err != nil {
+			return err
+		}
+		// Las reglas de horario se conservan: el preset solo cambia los tiempos base
+		cfg.WorkDuration = preset.Config.WorkDuration
+		cfg.ShortBreak = preset.Config.ShortBreak
+		cfg.LongBreak = preset.Config.LongBreak
+		cfg.LongBreakInterval = preset.Config.LongBreakInterval
+		name = preset.Name
+	}
+
+	if err := eng.UpdateConfig(cfg); err != nil {
+		return err
+	}
+	fmt.Printf("⏱️  Preset %s aplicado: %s\n", name, config.Preset{Config: cfg}.Summary())
+	return nil
 }
 
 func (cp *CommandProcessor) showPromptIfNeeded() {
+	if cp.handler.GetInputManager().Stopped() {
+		return
+	}
 	// Nuevo prompt para el siguiente comando (solo si no estamos en sesión activa)
 	if !cp.handler.IsFirstSessionStarted() || cp.handler.GetEngine().GetState() == engine.StateIdle {
 		fmt.Print("Comando > ")
 	}
 }
+
+// Alias de usuario
+
+// runLine ejecuta una línea: un comando del registro o un alias de usuario.
+// stack son los alias que se están expandiendo (para detectar la recursión).
+func (cp *CommandProcessor) runLine(line string, stack []string) error {
+	fields, err := SplitFields(line)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		if macro, ok := cp.macros.Get(fields[0]); ok {
+			return cp.runMacro(macro, fields[1:], stack)
+		}
+	}
+	return cp.registry.Execute(line)
+}
+
+// runMacro ejecuta en orden los comandos de un alias y se detiene en el primero que falla.
+// Si el alias no usa $1..$9 ni $*, los argumentos se añaden al último comando.
+func (cp *CommandProcessor) runMacro(macro Macro, args []string, stack []string) error {
+	for _, name := range stack {
+		if name == macro.Name {
+			return fmt.Errorf("alias recursivo: %s → %s", strings.Join(stack, " → "), macro.Name)
+		}
+	}
+	if len(stack) >= maxMacroDepth {
+		return fmt.Errorf("demasiados alias anidados (máximo %d): %s", maxMacroDepth, strings.Join(stack, " → "))
+	}
+	stack = append(stack[:len(stack):len(stack)], macro.Name)
+
+	commands, err := SplitCommands(macro.Commands)
+	if err != nil {
+		return fmt.Errorf("alias '%s': %w", macro.Name, err)
+	}
+
+	steps := make([][]string, 0, len(commands))
+	usesArgs := false
+	for _, command := range commands {
+		fields, err := SplitFields(command)
+		if err != nil {
+			return fmt.Errorf("alias '%s': %w", macro.Name, err)
+		}
+		expanded, used, err := ExpandArgs(fields, args)
+		if err != nil {
+			return fmt.Errorf("alias '%s': %w", macro.Name, err)
+		}
+		usesArgs = usesArgs || used
+		steps = append(steps, expanded)
+	}
+	if !usesArgs && len(args) > 0 {
+		steps[len(steps)-1] = append(steps[len(steps)-1], args...)
+	}
+
+	for _, step := range steps {
+		if len(step) == 0 {
+			continue // "$*" sin argumentos
+		}
+		line := JoinFields(step)
+		fmt.Printf("↪ %s\n", line)
+		if err := cp.runLine(line, stack); err != nil {
+			if _, nested := cp.macros.Get(step[0]); nested {
+				// El alias anidado ya indica dónde se detuvo
+				return err
+			}
+			return fmt.Errorf("alias '%s' detenido en '%s': %w", macro.Name, line, err)
+		}
+	}
+	return nil
+}
+
+// DefineMacro define o reemplaza un alias. No puede tener el nombre de un comando.
+func (cp *CommandProcessor) DefineMacro(macro Macro) error {
+	if cmd, ok := cp.registry.Lookup(macro.Name); ok {
+		return fmt.Errorf("'%s' ya es un comando (%s), elige otro nombre para el alias", macro.Name, cmd.Name)
+	}
+	return cp.macros.Set(macro)
+}
+
+// SetFileMacros sustituye los alias del archivo de configuración. Los que tienen el nombre
+// de un comando se ignoran y se retornan como error.
+func (cp *CommandProcessor) SetFileMacros(aliases map[string]string) error {
+	macros := make([]Macro, 0, len(aliases))
+	var conflicts []string
+	for name, commands := range aliases {
+		if _, ok := cp.registry.Lookup(name); ok {
+			conflicts = append(conflicts, name)
+			continue
+		}
+		macros = append(macros, Macro{Name: name, Commands: commands})
+	}
+	cp.macros.ReplaceFromFile(macros)
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("aliases shadow built-in commands and were ignored: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// GetMacros retorna los alias de usuario
+func (cp *CommandProcessor) GetMacros() *MacroSet {
+	return cp.macros
+}
+
+// handleAlias lista los alias, muestra uno o define uno nuevo con "alias nombre = comandos"
+func (cp *CommandProcessor) handleAlias(args Args) error {
+	raw := args.Raw()
+	if raw == "" {
+		cp.showMacros()
+		return nil
+	}
+
+	name, commands, found := strings.Cut(raw, "=")
+	name = strings.TrimSpace(name)
+	if !found {
+		macro, ok := cp.macros.Get(name)
+		if !ok {
+			return fmt.Errorf("no existe el alias '%s'", name)
+		}
+		fmt.Printf("⚡ %s = %s\n", macro.Name, macro.Commands)
+		return nil
+	}
+
+	_, existed := cp.macros.Get(name)
+	if err := cp.DefineMacro(Macro{Name: name, Commands: commands}); err != nil {
+		return err
+	}
+	macro, _ := cp.macros.Get(name)
+	action := "definido"
+	if existed {
+		action = "actualizado"
+	}
+	fmt.Printf("✅ Alias '%s' %s: %s\n", macro.Name, action, macro.Commands)
+	fmt.Println("💡 Dura hasta salir; para conservarlo añádelo a la clave 'aliases' del archivo de configuración")
+	return nil
+}
+
+// showMacros muestra los alias definidos y su origen
+func (cp *CommandProcessor) showMacros() {
+	macros := cp.macros.List()
+	if len(macros) == 0 {
+		fmt.Println("⚡ No hay alias definidos")
+		fmt.Println("💡 Uso: alias nombre = comando; comando $1 (o la clave 'aliases' del archivo de configuración)")
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Colorize("⚡ ALIAS", ui.ColorCyan, true))
+	fmt.Println(ui.Colorize("───────", ui.ColorGray, true))
+	for _, macro := range macros {
+		origin := "sesión"
+		if macro.FromFile {
+			origin = "archivo"
+		}
+		fmt.Printf("   • %-12s %s %s\n", macro.Name, macro.Commands, ui.Colorize("("+origin+")", ui.ColorGray, true))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,496 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ArgType es el tipo de un argumento de comando
+type ArgType int
+
+const (
+	ArgString ArgType = iota
+	ArgInt
+	ArgFloat
+	ArgDuration
+)
+
+// Arg describe un argumento de comando
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Optional bool
+	Rest     bool     // Recoge el resto de la línea sin interpretarlo (solo el último; Name lo describe)
+	Choices  []string // Valores permitidos (solo ArgString, vacío = cualquiera)
+}
+
+// Command es un comando de la CLI con sus alias, argumentos y ayuda
+type Command struct {
+	Name    string
+	Aliases []string
+	Args    []Arg
+	Group   string // Sección de la ayuda
+	Help    string
+	Basic   bool // Se muestra en la ayuda antes de empezar la primera sesión
+	Run     func(args Args) error
+}
+
+// Usage retorna la forma de uso del comando (ej: "volume <nivel>", "report [periodo]")
+func (c *Command) Usage() string {
+	parts := []string{c.Name}
+	for _, arg := range c.Args {
+		name := arg.Name
+		if len(arg.Choices) > 0 {
+			name = strings.Join(arg.Choices, "|")
+		}
+		if arg.Optional {
+			parts = append(parts, "["+name+"]")
+		} else {
+			parts = append(parts, "<"+name+">")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Parse interpreta los argumentos de una línea según la definición del comando
+func (c *Command) Parse(fields []string) (Args, error) {
+	args := Args{values: make(map[string]interface{})}
+
+	for i, arg := range c.Args {
+		if arg.Rest {
+			if len(fields) <= i && !arg.Optional {
+				return args, fmt.Errorf("falta %s", arg.Name)
+			}
+			if len(fields) > i {
+				args.rest = fields[i:]
+			}
+			return args, nil
+		}
+
+		if len(fields) <= i {
+			if !arg.Optional {
+				return args, fmt.Errorf("falta %s", arg.Name)
+			}
+			continue
+		}
+
+		value, err := arg.parse(fields[i])
+		if err != nil {
+			return args, err
+		}
+		args.values[arg.Name] = value
+	}
+
+	if len(fields) > len(c.Args) {
+		return args, fmt.Errorf("sobran argumentos: %s", strings.Join(fields[len(c.Args):], " "))
+	}
+	return args, nil
+}
+
+// parse convierte un valor de texto al tipo del argumento
+func (a Arg) parse(text string) (interface{}, error) {
+	switch a.Type {
+	case ArgInt:
+		value, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s debe ser un número entero: '%s'", a.Name, text)
+		}
+		return value, nil
+	case ArgFloat:
+		value, err := strconv.ParseFloat(strings.TrimSuffix(text, "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s debe ser un número: '%s'", a.Name, text)
+		}
+		if strings.HasSuffix(text, "%") {
+			value /= 100
+		}
+		return value, nil
+	case ArgDuration:
+		value, err := time.ParseDuration(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s debe ser una duración como 5m o 90s: '%s'", a.Name, text)
+		}
+		return value, nil
+	default:
+		if len(a.Choices) == 0 {
+			return text, nil
+		}
+		for _, choice := range a.Choices {
+			if strings.EqualFold(choice, text) {
+				return choice, nil
+			}
+		}
+		return nil, fmt.Errorf("%s '%s' no válido, usa: %s", a.Name, text, strings.Join(a.Choices, ", "))
+	}
+}
+
+// Args son los argumentos ya interpretados de un comando
+type Args struct {
+	values map[string]interface{}
+	rest   []string
+	raw    string // Texto de la línea tras el nombre del comando
+}
+
+// Has indica si se indicó un argumento opcional
+func (a Args) Has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+// String retorna un argumento de texto ("" si no se indicó)
+func (a Args) String(name string) string {
+	value, _ := a.values[name].(string)
+	return value
+}
+
+// Int retorna un argumento entero (0 si no se indicó)
+func (a Args) Int(name string) int {
+	value, _ := a.values[name].(int)
+	return value
+}
+
+// Float retorna un argumento decimal (0 si no se indicó)
+func (a Args) Float(name string) float64 {
+	value, _ := a.values[name].(float64)
+	return value
+}
+
+// Duration retorna un argumento de duración (0 si no se indicó)
+func (a Args) Duration(name string) time.Duration {
+	value, _ := a.values[name].(time.Duration)
+	return value
+}
+
+// Rest retorna el resto de la línea recogido por un argumento Rest
+func (a Args) Rest() []string {
+	return a.rest
+}
+
+// Raw retorna el texto de la línea tras el nombre del comando tal como se escribió
+// (con comillas y ';'), para comandos que interpretan su propia sintaxis
+func (a Args) Raw() string {
+	return a.raw
+}
+
+// UnknownCommandError indica un comando que no está registrado
+type UnknownCommandError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *UnknownCommandError) Error() string {
+	return fmt.Sprintf("comando '%s' no reconocido", e.Name)
+}
+
+// UsageError indica argumentos no válidos para un comando
+type UsageError struct {
+	Command *Command
+	Err     error
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("%v (uso: %s)", e.Err, e.Command.Usage())
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// CommandRegistry guarda los comandos de un modo de la CLI por nombre y alias
+type CommandRegistry struct {
+	commands []*Command
+	byName   map[string]*Command
+}
+
+// NewCommandRegistry crea un registro de comandos vacío
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{byName: make(map[string]*Command)}
+}
+
+// Register añade un comando; los nombres y alias no distinguen mayúsculas y no pueden repetirse
+func (r *CommandRegistry) Register(cmd *Command) error {
+	names := append([]string{cmd.Name}, cmd.Aliases...)
+	for _, name := range names {
+		if _, exists := r.byName[strings.ToLower(name)]; exists {
+			return fmt.Errorf("command %q already registered", name)
+		}
+	}
+	for _, name := range names {
+		r.byName[strings.ToLower(name)] = cmd
+	}
+	r.commands = append(r.commands, cmd)
+	return nil
+}
+
+// MustRegister añade varios comandos y falla si alguno está repetido (error de programación)
+func (r *CommandRegistry) MustRegister(cmds ...*Command) {
+	for _, cmd := range cmds {
+		if err := r.Register(cmd); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Lookup busca un comando por nombre o alias
+func (r *CommandRegistry) Lookup(name string) (*Command, bool) {
+	cmd, ok := r.byName[strings.ToLower(name)]
+	return cmd, ok
+}
+
+// Commands retorna los comandos en orden de registro
+func (r *CommandRegistry) Commands() []*Command {
+	return r.commands
+}
+
+// Execute interpreta una línea y ejecuta el comando. Retorna *UnknownCommandError o
+// *UsageError si la línea no corresponde a un comando válido.
+func (r *CommandRegistry) Execute(line string) error {
+	fields, err := SplitFields(line)
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(fields) > 0 {
+		name = fields[0]
+	}
+
+	cmd, ok := r.Lookup(name)
+	if !ok {
+		return &UnknownCommandError{Name: name, Suggestions: r.Suggest(name)}
+	}
+
+	var rest []string
+	if len(fields) > 1 {
+		rest = fields[1:]
+	}
+	args, err := cmd.Parse(rest)
+	if err != nil {
+		return &UsageError{Command: cmd, Err: err}
+	}
+	args.raw = rawRest(line)
+	return cmd.Run(args)
+}
+
+// rawRest retorna el texto de la línea tras el nombre del comando, sin interpretar
+func rawRest(line string) string {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return strings.TrimSpace(line[i:])
+	}
+	return ""
+}
+
+// SplitFields separa una línea en palabras respetando comillas dobles o simples
+// (ej: task "deep work" @proyecto). Dentro de las comillas dobles \" y \\ son caracteres literales.
+func SplitFields(line string) ([]string, error) {
+	var fields []string
+	var current strings.Builder
+	inField := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if quote == '"' && c == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+				current.WriteRune(runes[i])
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inField = true
+		case unicode.IsSpace(c):
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(c)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("faltan comillas de cierre (%c)", quote)
+	}
+	if inField {
+		fields = append(fields, current.String())
+	}
+	return fields, nil
+}
+
+// JoinFields une palabras en una línea que SplitFields vuelve a separar igual,
+// entrecomillando las que tienen espacios, comillas o ';'
+func JoinFields(fields []string) string {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		if field != "" && !strings.ContainsAny(field, " \t\"';\\") {
+			quoted[i] = field
+			continue
+		}
+		escaped := strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(field)
+		quoted[i] = "\"" + escaped + "\""
+	}
+	return strings.Join(quoted, " ")
+}
+
+// maxSuggestions es el número máximo de comandos sugeridos
+const maxSuggestions = 3
+
+// Suggest retorna comandos parecidos a name: primero los que empiezan por él y después los
+// que están a poca distancia de edición de algún nombre o alias
+func (r *CommandRegistry) Suggest(name string) []string {
+	name = strings.ToLower(name)
+	if name == "" {
+		return nil
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	best := make(map[string]int) // Comando -> mejor distancia
+	for alias, cmd := range r.byName {
+		if alias == "" {
+			continue
+		}
+		distance := -1
+		switch {
+		case len(name) >= 2 && strings.HasPrefix(alias, name):
+			distance = 0
+		default:
+			d := editDistance(name, alias)
+			if d <= maxEditDistance(name) {
+				distance = d
+			}
+		}
+		if distance < 0 {
+			continue
+		}
+		if current, ok := best[cmd.Name]; !ok || distance < current {
+			best[cmd.Name] = distance
+		}
+	}
+
+	candidates := make([]candidate, 0, len(best))
+	for cmdName, distance := range best {
+		candidates = append(candidates, candidate{cmdName, distance})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for _, c := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+// maxEditDistance es la distancia de edición tolerada según la longitud de lo escrito
+func maxEditDistance(name string) int {
+	switch {
+	case len(name) <= 2:
+		return 0
+	case len(name) <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance calcula la distancia de edición entre dos textos contando el intercambio de
+// dos letras seguidas como un solo cambio (ej: "hepl" -> "help")
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(min(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// helpColumn es el ancho de la columna de uso en la ayuda
+const helpColumn = 34
+
+// PrintHelp muestra los comandos agrupados por sección en orden de registro.
+// filter decide qué comandos se muestran (nil = todos).
+func (r *CommandRegistry) PrintHelp(filter func(*Command) bool) {
+	var groups []string
+	byGroup := make(map[string][]*Command)
+	for _, cmd := range r.commands {
+		if cmd.Help == "" || (filter != nil && !filter(cmd)) {
+			continue
+		}
+		if _, ok := byGroup[cmd.Group]; !ok {
+			groups = append(groups, cmd.Group)
+		}
+		byGroup[cmd.Group] = append(byGroup[cmd.Group], cmd)
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		if group != "" {
+			fmt.Println(group + ":")
+		}
+		for _, cmd := range byGroup[group] {
+			usage := cmd.Usage()
+			if len(cmd.Aliases) > 0 {
+				usage += " (" + strings.Join(visibleAliases(cmd.Aliases), ", ") + ")"
+			}
+			if len([]rune(usage)) > helpColumn {
+				// Los usos largos van en su propia línea para no desalinear la columna de ayuda
+				fmt.Printf("   • %s\n     %-*s %s\n", usage, helpColumn, "", cmd.Help)
+				continue
+			}
+			fmt.Printf("   • %-*s %s\n", helpColumn, usage, cmd.Help)
+		}
+	}
+}
+
+// visibleAliases muestra el alias vacío como "Enter" en la ayuda
+func visibleAliases(aliases []string) []string {
+	visible := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		if alias == "" {
+			visible = append(visible, "Enter")
+			continue
+		}
+		visible = append(visible, alias)
+	}
+	return visible
+}
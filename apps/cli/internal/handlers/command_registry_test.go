@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitFields(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{line: "", want: nil},
+		{line: "  start  ", want: []string{"start"}},
+		{line: `task "deep work" @proyecto`, want: []string{"task", "deep work", "@proyecto"}},
+		{line: "say 'hola mundo'", want: []string{"say", "hola mundo"}},
+		{line: `a"b c"d`, want: []string{"ab cd"}},
+		{line: `'it''s'`, want: []string{"its"}},
+		{line: `""`, want: []string{""}},
+		{line: `say "" x`, want: []string{"say", "", "x"}},
+		// Dentro de comillas dobles solo se escapan \" y \\
+		{line: `"a \"b\" \\ c"`, want: []string{`a "b" \ c`}},
+		{line: `"c:\temp"`, want: []string{`c:\temp`}},
+		{line: `'a \"b'`, want: []string{`a \"b`}},
+		{line: `fuera\"`, wantErr: true}, // La comilla escapada fuera de comillas abre una
+		{line: `task "deep work`, wantErr: true},
+		{line: `task 'deep`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := SplitFields(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinFields(t *testing.T) {
+	if got := JoinFields([]string{"task", "deep work", ""}); got != `task "deep work" ""` {
+		t.Errorf("unexpected join: %s", got)
+	}
+
+	// SplitFields separa lo que une JoinFields en las mismas palabras
+	fields := []string{"plain", "with space", `quo"te`, `back\slash`, "", "semi;colon", "it's", "tab\there", `\"`}
+	line := JoinFields(fields)
+	got, err := SplitFields(line)
+	if err != nil {
+		t.Fatalf("split %s: %v", line, err)
+	}
+	if !reflect.DeepEqual(got, fields) {
+		t.Fatalf("round trip of %s: got %q, want %q", line, got, fields)
+	}
+}
+
+func TestCommandParse(t *testing.T) {
+	timer := &Command{Name: "timer", Args: []Arg{
+		{Name: "minutos", Type: ArgInt},
+		{Name: "modo", Choices: []string{"fast", "slow"}, Optional: true},
+	}}
+	volume := &Command{Name: "volume", Args: []Arg{{Name: "nivel", Type: ArgFloat}}}
+	snooze := &Command{Name: "snooze", Args: []Arg{{Name: "tiempo", Type: ArgDuration}}}
+	say := &Command{Name: "say", Args: []Arg{{Name: "canal", Type: ArgString}, {Name: "texto", Rest: true}}}
+	note := &Command{Name: "note", Args: []Arg{{Name: "texto", Rest: true, Optional: true}}}
+
+	tests := []struct {
+		name    string
+		cmd     *Command
+		fields  []string
+		check   func(Args) bool
+		wantErr string
+	}{
+		{name: "required int", cmd: timer, fields: []string{"5"},
+			check: func(a Args) bool { return a.Int("minutos") == 5 && !a.Has("modo") }},
+		{name: "choice ignores case", cmd: timer, fields: []string{"5", "FAST"},
+			check: func(a Args) bool { return a.String("modo") == "fast" }},
+		{name: "missing arg", cmd: timer, wantErr: "falta minutos"},
+		{name: "bad int", cmd: timer, fields: []string{"x"}, wantErr: "debe ser un número entero"},
+		{name: "bad choice", cmd: timer, fields: []string{"5", "medium"}, wantErr: "no válido, usa: fast, slow"},
+		{name: "extra args", cmd: timer, fields: []string{"5", "fast", "a", "b"}, wantErr: "sobran argumentos: a b"},
+		{name: "percent", cmd: volume, fields: []string{"50%"},
+			check: func(a Args) bool { return a.Float("nivel") == 0.5 }},
+		{name: "float", cmd: volume, fields: []string{"0.25"},
+			check: func(a Args) bool { return a.Float("nivel") == 0.25 }},
+		{name: "bad float", cmd: volume, fields: []string{"alto"}, wantErr: "debe ser un número"},
+		{name: "duration", cmd: snooze, fields: []string{"90s"},
+			check: func(a Args) bool { return a.Duration("tiempo") == 90*time.Second }},
+		{name: "bad duration", cmd: snooze, fields: []string{"5"}, wantErr: "debe ser una duración"},
+		{name: "rest", cmd: say, fields: []string{"general", "hola", "a todos"},
+			check: func(a Args) bool { return reflect.DeepEqual(a.Rest(), []string{"hola", "a todos"}) }},
+		{name: "missing rest", cmd: say, fields: []string{"general"}, wantErr: "falta texto"},
+		{name: "optional rest", cmd: note,
+			check: func(a Args) bool { return a.Rest() == nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := tt.cmd.Parse(tt.fields)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.check(args) {
+				t.Fatalf("unexpected args: %+v", args)
+			}
+		})
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	cmd := &Command{Name: "report", Args: []Arg{
+		{Name: "periodo", Choices: []string{"day", "week"}, Optional: true},
+		{Name: "usuario"},
+	}}
+	if got := cmd.Usage(); got != "report [day|week] <usuario>" {
+		t.Errorf("unexpected usage: %s", got)
+	}
+}
+
+func TestRegistryExecute(t *testing.T) {
+	registry := NewCommandRegistry()
+	var raw string
+	registry.MustRegister(&Command{Name: "task", Aliases: []string{"t"},
+		Args: []Arg{{Name: "texto", Rest: true}},
+		Run:  func(args Args) error { raw = args.Raw(); return nil }})
+
+	if err := registry.Execute(`T "deep work"; next`); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if raw != `"deep work"; next` {
+		t.Errorf("unexpected raw text: %q", raw)
+	}
+
+	var unknown *UnknownCommandError
+	if err := registry.Execute("tsak x"); !errors.As(err, &unknown) || unknown.Name != "tsak" {
+		t.Errorf("expected an unknown command error, got %v", err)
+	} else if !reflect.DeepEqual(unknown.Suggestions, []string{"task"}) {
+		t.Errorf("unexpected suggestions: %v", unknown.Suggestions)
+	}
+
+	var usage *UsageError
+	if err := registry.Execute("task"); !errors.As(err, &usage) || !strings.Contains(err.Error(), "uso: task <texto>") {
+		t.Errorf("expected a usage error, got %v", err)
+	}
+
+	if err := registry.Register(&Command{Name: "other", Aliases: []string{"TASK"}}); err == nil {
+		t.Errorf("a repeated alias must be rejected")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	registry := NewCommandRegistry()
+	for _, cmd := range []*Command{
+		{Name: "help", Aliases: []string{"h", "?"}},
+		{Name: "status", Aliases: []string{"st"}},
+		{Name: "start"},
+		{Name: "stop"},
+		{Name: "stats"},
+		{Name: "skip"},
+		{Name: "report"},
+	} {
+		registry.MustRegister(cmd)
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "", want: nil},
+		{name: "sta", want: []string{"start", "stats", "status"}}, // Prefijo antes que distancia
+		{name: "STO", want: []string{"stop", "status"}},           // status por el alias st
+		{name: "st", want: []string{"start", "stats", "status"}},  // Como mucho maxSuggestions
+		{name: "hepl", want: []string{"help"}},                    // Letras intercambiadas
+		{name: "reprot", want: []string{"report"}},
+		{name: "rpeort", want: []string{"report"}},
+		{name: "skp", want: []string{"skip"}},
+		{name: "s", want: []string{}}, // Una letra no busca prefijos ni admite cambios
+		{name: "xyz", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registry.Suggest(tt.name)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"help", "help", 0},
+		{"hepl", "help", 1},
+		{"kitten", "sitting", 3},
+		{"ñu", "nu", 1}, // Cuenta runas, no bytes
+		{"abcd", "badc", 2},
+		{"stats", "status", 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := editDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
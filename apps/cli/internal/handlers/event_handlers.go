@@ -6,6 +6,7 @@ import (
 
 	"github.com/kubaliski/pomodoro-cli/internal/ui"
 	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
 )
 
 // EventHandler maneja todos los eventos del core del pomodoro
@@ -61,7 +62,7 @@ func (eh *EventHandler) HandleTimerStarted(event events.Event) {
 		eh.handler.UpdateLastAlert(-1) // Reset alert tracking
 
 		// 🔊 Notificación de inicio de sesión
-		sessionType := data.State
+		sessionType := ui.SessionLabel(data.State)
 		duration := time.Duration(data.Total) * time.Nanosecond
 		eh.handler.GetNotificationManager().NotifySessionStarted(sessionType, duration)
 
@@ -96,7 +97,7 @@ func (eh *EventHandler) HandleTimerTick(event events.Event) {
 
 func (eh *EventHandler) handleTimeAlerts(data events.TimerEventData) {
 	timeRemaining := time.Duration(data.Remaining) * time.Nanosecond
-	sessionType := data.State
+	sessionType := ui.SessionLabel(data.State)
 	currentMinute := int(timeRemaining.Minutes())
 	lastAlertMinute := eh.handler.GetLastAlertMinute()
 
@@ -157,6 +158,13 @@ func (eh *EventHandler) HandleTimerSkipped(event events.Event) {
 func (eh *EventHandler) HandlePomodoroStarted(event events.Event) {
 	if data, ok := event.Data.(events.PomodoroEventData); ok {
 		fmt.Printf("\n🍅 Pomodoro #%d - Sesión de trabajo\n", data.Number)
+		if data.Task != "" || data.Project != "" {
+			labels := stats.Labels{Task: data.Task, Project: data.Project, Tags: data.Tags, Estimate: data.Estimate}
+			fmt.Printf("🏷️  %s\n", labels.Summary())
+		}
+		if data.Rule != "" {
+			fmt.Printf("🕒 Regla de horario: %s (%s)\n", data.Rule, ui.FormatDuration(data.Duration))
+		}
 		time.Sleep(2 * time.Second)
 	}
 }
@@ -235,7 +243,7 @@ func (eh *EventHandler) HandlePomodoroSkipped(event events.Event) {
 
 func (eh *EventHandler) HandleBreakStarted(event events.Event) {
 	if data, ok := event.Data.(events.BreakEventData); ok {
-		fmt.Printf("\n🧘 %s - Tiempo de descanso\n", data.Type)
+		fmt.Printf("\n🧘 %s - Tiempo de descanso\n", ui.SessionLabel(data.Type))
 		time.Sleep(2 * time.Second)
 	}
 }
@@ -246,7 +254,7 @@ func (eh *EventHandler) HandleBreakCompleted(event events.Event) {
 
 		// 🔊 NOTIFICACIÓN DE DESCANSO COMPLETADO
 		nextPomodoroNum := eh.handler.GetEngine().GetPomodoroCount() + 1
-		eh.handler.GetNotificationManager().NotifyBreakCompleted(data.Type, nextPomodoroNum)
+		eh.handler.GetNotificationManager().NotifyBreakCompleted(ui.SessionLabel(data.Type), nextPomodoroNum)
 
 		// Limpiar display antes de mostrar mensaje
 		fmt.Print("\r\033[K") // Limpiar línea actual
@@ -256,13 +264,14 @@ func (eh *EventHandler) HandleBreakCompleted(event events.Event) {
 		fmt.Println(ui.Colorize("+================================+", ui.ColorBlue, true))
 		fmt.Println(ui.Colorize("|      DESCANSO COMPLETADO!      |", ui.ColorBlue, true))
 		fmt.Println(ui.Colorize("+================================+", ui.ColorBlue, true))
-		fmt.Printf("✅ %s terminado\n", data.Type)
+		fmt.Printf("✅ %s terminado\n", ui.SessionLabel(data.Type))
 		fmt.Println("💪 ¡Listo para el siguiente pomodoro!")
 		fmt.Println()
 
 		// Mostrar qué pomodoro viene
+		nextConfig, _ := eh.handler.GetCurrentConfig()
 		fmt.Printf("🎯 Próximo: Pomodoro #%d (%s)\n",
-			nextPomodoroNum, ui.FormatDuration(eh.handler.GetEngine().GetConfig().WorkDuration))
+			nextPomodoroNum, ui.FormatDuration(nextConfig.WorkDuration))
 		fmt.Println()
 
 		fmt.Println(ui.Colorize("Escribe 'c' para continuar o 'q' para salir", ui.ColorYellow, true))
@@ -284,14 +293,15 @@ func (eh *EventHandler) HandleBreakSkipped(event events.Event) {
 		fmt.Println(ui.Colorize("+================================+", ui.ColorCyan, true))
 		fmt.Println(ui.Colorize("|      DESCANSO SALTADO!         |", ui.ColorCyan, true))
 		fmt.Println(ui.Colorize("+================================+", ui.ColorCyan, true))
-		fmt.Printf("⏭️  %s saltado\n", data.Type)
+		fmt.Printf("⏭️  %s saltado\n", ui.SessionLabel(data.Type))
 		fmt.Println("💪 ¡Listo para el siguiente pomodoro!")
 		fmt.Println()
 
 		// Mostrar qué pomodoro viene
 		nextPomodoroNum := eh.handler.GetEngine().GetPomodoroCount() + 1
+		nextConfig, _ := eh.handler.GetCurrentConfig()
 		fmt.Printf("🎯 Próximo: Pomodoro #%d (%s)\n",
-			nextPomodoroNum, ui.FormatDuration(eh.handler.GetEngine().GetConfig().WorkDuration))
+			nextPomodoroNum, ui.FormatDuration(nextConfig.WorkDuration))
 		fmt.Println()
 
 		fmt.Println(ui.Colorize("Escribe 'c' para continuar con el trabajo o 'q' para salir", ui.ColorYellow, true))
@@ -2,14 +2,18 @@ package handlers
 
 import (
 	"bufio"
+	"context"
 	"os"
 	"strings"
+	"sync"
 )
 
 // InputManager maneja la entrada de comandos del usuario
 type InputManager struct {
 	reader    *bufio.Reader
 	inputChan chan string
+	done      chan struct{}
+	stopOnce  sync.Once
 }
 
 // NewInputManager crea un nuevo gestor de input
@@ -17,6 +21,7 @@ func NewInputManager() *InputManager {
 	return &InputManager{
 		reader:    bufio.NewReader(os.Stdin),
 		inputChan: make(chan string, 10),
+		done:      make(chan struct{}),
 	}
 }
 
@@ -24,7 +29,8 @@ func NewInputManager() *InputManager {
 func (im *InputManager) StartListener() {
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
-		input := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		// Se conservan mayúsculas para argumentos (zonas horarias, nombres de tareas...)
+		input := strings.TrimSpace(scanner.Text())
 		select {
 		case im.inputChan <- input:
 		default:
@@ -33,10 +39,33 @@ func (im *InputManager) StartListener() {
 	}
 }
 
-// HandleInput procesa los comandos usando el procesador proporcionado
-func (im *InputManager) HandleInput(processor func(string)) {
-	for input := range im.inputChan {
-		processor(input)
+// HandleInput procesa los comandos usando el procesador proporcionado hasta que se
+// cancela el contexto o se llama a Stop
+func (im *InputManager) HandleInput(ctx context.Context, processor func(string)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-im.done:
+			return
+		case input := <-im.inputChan:
+			processor(input)
+		}
+	}
+}
+
+// Stop termina el bucle de HandleInput después del comando en curso
+func (im *InputManager) Stop() {
+	im.stopOnce.Do(func() { close(im.done) })
+}
+
+// Stopped indica si se llamó a Stop
+func (im *InputManager) Stopped() bool {
+	select {
+	case <-im.done:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kubaliski/pomodoro-core/config"
+)
+
+// maxMacroDepth es el número máximo de alias que se pueden anidar al expandir uno
+const maxMacroDepth = 8
+
+// Macro es un alias de usuario: una secuencia de comandos separados por ';'
+type Macro struct {
+	Name     string
+	Commands string
+	FromFile bool // Definido en el archivo de configuración (false si se definió en la sesión)
+}
+
+// MacroSet guarda los alias definidos por el usuario
+type MacroSet struct {
+	mu     sync.RWMutex
+	macros map[string]Macro
+}
+
+// NewMacroSet crea un conjunto de alias vacío
+func NewMacroSet() *MacroSet {
+	return &MacroSet{macros: make(map[string]Macro)}
+}
+
+// Get busca un alias por nombre (sin distinguir mayúsculas)
+func (m *MacroSet) Get(name string) (Macro, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	macro, ok := m.macros[strings.ToLower(name)]
+	return macro, ok
+}
+
+// Set define o reemplaza un alias
+func (m *MacroSet) Set(macro Macro) error {
+	macro.Name = strings.ToLower(strings.TrimSpace(macro.Name))
+	macro.Commands = strings.TrimSpace(macro.Commands)
+	if err := config.ValidateAlias(macro.Name, macro.Commands); err != nil {
+		return err
+	}
+	if _, err := SplitCommands(macro.Commands); err != nil {
+		return fmt.Errorf("alias %s: %w", macro.Name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.macros[macro.Name] = macro
+	return nil
+}
+
+// Remove elimina un alias y retorna si existía
+func (m *MacroSet) Remove(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = strings.ToLower(name)
+	_, ok := m.macros[name]
+	delete(m.macros, name)
+	return ok
+}
+
+// ReplaceFromFile sustituye los alias del archivo de configuración por los indicados.
+// Los definidos en la sesión se conservan aunque tengan el mismo nombre.
+func (m *MacroSet) ReplaceFromFile(macros []Macro) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, macro := range m.macros {
+		if macro.FromFile {
+			delete(m.macros, name)
+		}
+	}
+	for _, macro := range macros {
+		if current, ok := m.macros[macro.Name]; ok && !current.FromFile {
+			continue
+		}
+		macro.FromFile = true
+		m.macros[macro.Name] = macro
+	}
+}
+
+// List retorna los alias ordenados por nombre
+func (m *MacroSet) List() []Macro {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	list := make([]Macro, 0, len(m.macros))
+	for _, macro := range m.macros {
+		list = append(list, macro)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// SplitCommands separa los comandos de un alias por ';' fuera de las comillas
+func SplitCommands(text string) ([]string, error) {
+	var commands []string
+	var current strings.Builder
+	var quote rune
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if quote == '"' && c == '\\' && i+1 < len(runes) {
+				current.WriteRune(c)
+				i++
+				c = runes[i]
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ';':
+			commands = appendCommand(commands, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("faltan comillas de cierre (%c)", quote)
+	}
+	return appendCommand(commands, current.String()), nil
+}
+
+// appendCommand añade un comando a la lista ignorando los vacíos (ej: "a;; b")
+func appendCommand(commands []string, command string) []string {
+	if command = strings.TrimSpace(command); command != "" {
+		commands = append(commands, command)
+	}
+	return commands
+}
+
+// ExpandArgs sustituye en las palabras de un comando $1..$9 por los argumentos del alias,
+// $* por todos ellos y $$ por '$'. Retorna también si se usó algún argumento.
+func ExpandArgs(fields, args []string) ([]string, bool, error) {
+	expanded := make([]string, 0, len(fields))
+	used := false
+
+	for _, field := range fields {
+		if field == "$*" {
+			expanded = append(expanded, args...)
+			used = true
+			continue
+		}
+
+		var out strings.Builder
+		for i := 0; i < len(field); i++ {
+			if field[i] != '$' || i+1 == len(field) {
+				out.WriteByte(field[i])
+				continue
+			}
+			next := field[i+1]
+			switch {
+			case next == '$':
+				out.WriteByte('$')
+				i++
+			case next == '*':
+				out.WriteString(strings.Join(args, " "))
+				used = true
+				i++
+			case next >= '1' && next <= '9':
+				n, _ := strconv.Atoi(string(next))
+				if n > len(args) {
+					return nil, used, fmt.Errorf("falta el argumento $%d", n)
+				}
+				out.WriteString(args[n-1])
+				used = true
+				i++
+			default:
+				out.WriteByte('$')
+			}
+		}
+		expanded = append(expanded, out.String())
+	}
+	return expanded, used, nil
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommands(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    []string
+		wantErr bool
+	}{
+		{text: "", want: nil},
+		{text: "start; skip", want: []string{"start", "skip"}},
+		{text: " a;; b ; ", want: []string{"a", "b"}},
+		// Las comillas se conservan para que SplitFields separe después cada comando
+		{text: `task "a;b"; start`, want: []string{`task "a;b"`, "start"}},
+		{text: `say 'x;y'`, want: []string{`say 'x;y'`}},
+		{text: `say "a\";b"; c`, want: []string{`say "a\";b"`, "c"}},
+		{text: `say "abc`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := SplitCommands(tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		args     []string
+		want     []string
+		wantUsed bool
+		wantErr  string
+	}{
+		{name: "no placeholders", fields: []string{"task", "x"}, args: []string{"a"}, want: []string{"task", "x"}},
+		{name: "positional", fields: []string{"task", "$1"}, args: []string{"deep work"}, want: []string{"task", "deep work"}, wantUsed: true},
+		{name: "reordered", fields: []string{"$2-$1"}, args: []string{"a", "b"}, want: []string{"b-a"}, wantUsed: true},
+		{name: "ninth", fields: []string{"$9"}, args: strings.Fields("1 2 3 4 5 6 7 8 9"), want: []string{"9"}, wantUsed: true},
+		{name: "all as fields", fields: []string{"task", "$*"}, args: []string{"a", "b c"}, want: []string{"task", "a", "b c"}, wantUsed: true},
+		{name: "all without args", fields: []string{"$*"}, want: []string{}, wantUsed: true},
+		{name: "all inside a word", fields: []string{"pre$*"}, args: []string{"a", "b"}, want: []string{"prea b"}, wantUsed: true},
+		{name: "escaped dollar", fields: []string{"$$1"}, args: []string{"a"}, want: []string{"$1"}},
+		{name: "lone dollar", fields: []string{"$", "100$", "$x", "$0"}, want: []string{"$", "100$", "$x", "$0"}},
+		{name: "missing arg", fields: []string{"task", "$3"}, args: []string{"a"}, wantErr: "falta el argumento $3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, used, err := ExpandArgs(tt.fields, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || used != tt.wantUsed {
+				t.Fatalf("got %q (used %v), want %q (used %v)", got, used, tt.want, tt.wantUsed)
+			}
+		})
+	}
+}
+
+// newMacroProcessor crea un procesador sin CLI con los comandos echo (guarda su texto) y fail
+func newMacroProcessor(t *testing.T, aliases map[string]string) (*CommandProcessor, *[]string) {
+	t.Helper()
+	cp := &CommandProcessor{registry: NewCommandRegistry(), macros: NewMacroSet()}
+	var lines []string
+	cp.registry.MustRegister(
+		&Command{Name: "echo", Args: []Arg{{Name: "texto", Rest: true, Optional: true}},
+			Run: func(args Args) error { lines = append(lines, strings.Join(args.Rest(), " ")); return nil }},
+		&Command{Name: "fail", Run: func(Args) error { return errors.New("failed on purpose") }},
+	)
+	for name, commands := range aliases {
+		if err := cp.DefineMacro(Macro{Name: name, Commands: commands}); err != nil {
+			t.Fatalf("define %s: %v", name, err)
+		}
+	}
+	return cp, &lines
+}
+
+// macroChain define m0 → m1 → ... → m<n-1>, que ejecuta echo
+func macroChain(n int) map[string]string {
+	aliases := make(map[string]string, n)
+	for i := 0; i < n-1; i++ {
+		aliases[fmt.Sprintf("m%d", i)] = fmt.Sprintf("m%d", i+1)
+	}
+	aliases[fmt.Sprintf("m%d", n-1)] = "echo fondo"
+	return aliases
+}
+
+func TestRunMacro(t *testing.T) {
+	tests := []struct {
+		name      string
+		aliases   map[string]string
+		line      string
+		wantLines []string
+		wantErr   string
+	}{
+		{name: "positional args", aliases: map[string]string{"hi": "echo hola; echo $1"},
+			line: "hi ana", wantLines: []string{"hola", "ana"}},
+		{name: "args appended to the last command", aliases: map[string]string{"tag": "echo a; echo b"},
+			line: `tag x "y z"`, wantLines: []string{"a", "b x y z"}},
+		{name: "nested alias", aliases: map[string]string{"hi": "echo hola; echo $1", "outer": "hi bob; echo fin"},
+			line: "outer", wantLines: []string{"hola", "bob", "fin"}},
+		{name: "same alias twice in a row", aliases: map[string]string{"one": "echo 1", "two": "one; one"},
+			line: "two", wantLines: []string{"1", "1"}},
+		{name: "stops at the first error", aliases: map[string]string{"early": "echo a; fail; echo b"},
+			line: "early", wantLines: []string{"a"}, wantErr: "alias 'early' detenido en 'fail': failed on purpose"},
+		{name: "missing arg", aliases: map[string]string{"need": "echo $2"},
+			line: "need a", wantErr: "alias 'need': falta el argumento $2"},
+		{name: "self recursive", aliases: map[string]string{"loop": "echo x; loop"},
+			line: "loop", wantLines: []string{"x"}, wantErr: "alias recursivo: loop → loop"},
+		{name: "cycle", aliases: map[string]string{"a": "b", "b": "echo en b; a"},
+			line: "a", wantLines: []string{"en b"}, wantErr: "alias recursivo: a → b → a"},
+		{name: "deepest allowed", aliases: macroChain(maxMacroDepth),
+			line: "m0", wantLines: []string{"fondo"}},
+		{name: "too deep", aliases: macroChain(maxMacroDepth + 1),
+			line: "m0", wantErr: "demasiados alias anidados (máximo 8)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, lines := newMacroProcessor(t, tt.aliases)
+			err := cp.runLine(tt.line, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(*lines) != 0 || len(tt.wantLines) != 0 {
+				if !reflect.DeepEqual(*lines, tt.wantLines) {
+					t.Fatalf("got lines %q, want %q", *lines, tt.wantLines)
+				}
+			}
+		})
+	}
+}
+
+func TestDefineMacroRejectsCommandNames(t *testing.T) {
+	cp, _ := newMacroProcessor(t, nil)
+	if err := cp.DefineMacro(Macro{Name: "echo", Commands: "fail"}); err == nil {
+		t.Fatalf("an alias must not shadow a command")
+	}
+	if err := cp.DefineMacro(Macro{Name: "bad", Commands: `echo "open`}); err == nil {
+		t.Fatalf("an alias with unbalanced quotes must be rejected")
+	}
+}
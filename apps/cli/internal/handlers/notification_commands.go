@@ -230,8 +230,7 @@ func (nc *NotificationCommands) ToggleAllAlerts(enabled bool) {
 
 // AdjustVolume ajusta el volumen de las notificaciones
 func (nc *NotificationCommands) AdjustVolume(delta float64) {
-	config := nc.handler.GetNotificationManager().GetConfig()
-	newVolume := config.SoundVolume + delta
+	newVolume := nc.handler.GetNotificationManager().GetConfig().SoundVolume + delta
 
 	// Limitar entre 0.0 y 1.0
 	if newVolume < 0.0 {
@@ -240,14 +239,25 @@ func (nc *NotificationCommands) AdjustVolume(delta float64) {
 		newVolume = 1.0
 	}
 
-	config.SoundVolume = newVolume
+	if err := nc.SetVolume(newVolume); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
+
+// SetVolume fija el volumen de las notificaciones (0.0 a 1.0) y reproduce un sonido de prueba
+func (nc *NotificationCommands) SetVolume(volume float64) error {
+	if volume < 0.0 || volume > 1.0 {
+		return fmt.Errorf("el volumen debe estar entre 0 y 1 (o entre 0%% y 100%%)")
+	}
+
+	config := nc.handler.GetNotificationManager().GetConfig()
+	config.SoundVolume = volume
 
 	if err := nc.handler.GetNotificationManager().UpdateConfig(config); err != nil {
-		fmt.Printf("❌ Error actualizando volumen: %v\n", err)
-		return
+		return fmt.Errorf("error actualizando volumen: %v", err)
 	}
 
-	fmt.Printf("🔊 Volumen: %.0f%%", newVolume*100)
+	fmt.Printf("🔊 Volumen: %.0f%%", volume*100)
 
 	// Reproducir sonido de prueba con el nuevo volumen
 	if config.SoundEnabled {
@@ -255,13 +265,14 @@ func (nc *NotificationCommands) AdjustVolume(delta float64) {
 		nc.handler.GetNotificationManager().QuickNotify(
 			notifications.EventCustomAlert,
 			"🔊 Test de volumen",
-			fmt.Sprintf("Volumen ajustado a %.0f%%", newVolume*100),
+			fmt.Sprintf("Volumen ajustado a %.0f%%", volume*100),
 			notifications.PriorityNormal,
 		)
 		fmt.Println(" ✅")
 	} else {
 		fmt.Println(" (sonido deshabilitado)")
 	}
+	return nil
 }
 
 // ShowStats muestra las estadísticas de notificaciones
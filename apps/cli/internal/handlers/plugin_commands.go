@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-cli/internal/plugins"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+)
+
+// PluginCommands maneja los plugins externos y los comandos que añaden
+type PluginCommands struct {
+	handler *CLIHandler
+	manager *plugins.Manager
+}
+
+// NewPluginCommands crea un nuevo handler de comandos de plugins
+func NewPluginCommands(h *CLIHandler) *PluginCommands {
+	return &PluginCommands{handler: h}
+}
+
+// Register añade al registro los comandos que anunciaron los plugins en su handshake.
+// Los que coinciden con un comando existente se ignoran y se retornan como error.
+func (pc *PluginCommands) Register(manager *plugins.Manager) error {
+	pc.manager = manager
+	registry := pc.handler.GetCommandProcessor().GetRegistry()
+
+	var conflicts []string
+	for _, command := range manager.Commands() {
+		command := command
+		help := command.Help
+		if help == "" {
+			help = "Comando del plugin"
+		}
+		cmd := &Command{
+			Name:  command.Name,
+			Group: groupPlugins,
+			Args:  []Arg{{Name: "argumentos", Optional: true, Rest: true}},
+			Help:  fmt.Sprintf("%s (%s)", help, command.Plugin.Name()),
+			Run: func(args Args) error {
+				return pc.invoke(command, args.Rest())
+			},
+		}
+		if command.Usage != "" {
+			cmd.Args[0].Name = command.Usage
+		}
+		if err := registry.Register(cmd); err != nil {
+			conflicts = append(conflicts, command.Plugin.Name()+"/"+command.Name)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("plugin commands shadow existing commands and were ignored: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// invoke ejecuta el comando en su plugin y muestra su salida
+func (pc *PluginCommands) invoke(command plugins.Command, args []string) error {
+	output, err := command.Plugin.Invoke(command.Name, args)
+	if output = strings.TrimRight(output, "\n"); output != "" {
+		fmt.Println(output)
+	}
+	return err
+}
+
+// ShowPlugins muestra los plugins, su estado y sus comandos
+func (pc *PluginCommands) ShowPlugins() {
+	if pc.manager == nil || len(pc.manager.Plugins()) == 0 {
+		fmt.Println("🧩 No hay plugins instalados")
+		fmt.Printf("💡 Cada plugin va en un subdirectorio del directorio de plugins (-plugins, por defecto %s) con su manifiesto plugin.yaml\n", plugins.DefaultDir())
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Colorize("🧩 PLUGINS", ui.ColorCyan, true))
+	fmt.Println(ui.Colorize("─────────", ui.ColorGray, true))
+	for _, plugin := range pc.manager.Plugins() {
+		status := plugin.Status()
+
+		name := plugin.Name()
+		if status.Version != "" {
+			name += " " + status.Version
+		}
+		fmt.Printf("   • %-20s %s\n", name, pluginStateLabel(status))
+		if description := plugin.Manifest().Description; description != "" {
+			fmt.Printf("     %s\n", ui.Colorize(description, ui.ColorGray, true))
+		}
+		if len(status.Commands) > 0 {
+			names := make([]string, 0, len(status.Commands))
+			for _, command := range status.Commands {
+				names = append(names, command.Name)
+			}
+			fmt.Printf("     Comandos: %s\n", strings.Join(names, ", "))
+		}
+		if status.Dropped > 0 {
+			fmt.Printf("     ⚠️  %d eventos descartados (el plugin no los leía a tiempo)\n", status.Dropped)
+		}
+		if status.Error != "" && status.State != plugins.StateRunning {
+			fmt.Printf("     ❌ %s\n", status.Error)
+		}
+	}
+	fmt.Println()
+}
+
+// pluginStateLabel describe el estado de un plugin
+func pluginStateLabel(status plugins.Status) string {
+	switch status.State {
+	case plugins.StateRunning:
+		if status.Restarts > 0 {
+			return fmt.Sprintf("🟢 en marcha (%d reinicios)", status.Restarts)
+		}
+		return "🟢 en marcha"
+	case plugins.StateStarting:
+		return "🟡 arrancando"
+	case plugins.StateRestarting:
+		return fmt.Sprintf("🟡 reiniciando (%d)", status.Restarts)
+	case plugins.StateFailed:
+		return "🔴 desactivado por errores"
+	case plugins.StateDisabled:
+		return "⚪ desactivado en el manifiesto"
+	default:
+		return "⚪ detenido"
+	}
+}
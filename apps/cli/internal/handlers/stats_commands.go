@@ -1,21 +1,35 @@
 package handlers
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
 )
 
 // StatsCommands maneja todos los comandos relacionados con estadísticas
 type StatsCommands struct {
-	handler *CLIHandler
+	handler  *CLIHandler
+	registry *CommandRegistry // Comandos del modo estadísticas
 }
 
+// errLeaveStats indica que un comando termina el modo estadísticas
+var errLeaveStats = errors.New("leave stats mode")
+
 // NewStatsCommands crea un nuevo handler de comandos de estadísticas
 func NewStatsCommands(h *CLIHandler) *StatsCommands {
-	return &StatsCommands{handler: h}
+	sc := &StatsCommands{handler: h, registry: NewCommandRegistry()}
+	sc.registerCommands()
+	return sc
 }
 
 // ShowDetailedStats muestra estadísticas detalladas con modo interactivo
@@ -38,7 +52,11 @@ func (sc *StatsCommands) ShowDetailedStats() {
 	fmt.Println("\n" + ui.Colorize("─────────────────────────────────────────────────────────────", ui.ColorGray, true))
 	fmt.Println(ui.Colorize("📋 COMANDOS ADICIONALES:", ui.ColorYellow, true))
 	fmt.Println("   • 'compact' - Ver estadísticas compactas")
-	fmt.Println("   • 'export' - Exportar datos (próximamente)")
+	fmt.Println("   • 'export [csv|md|ics|json]' - Exportar historial a archivo")
+	fmt.Println("   • 'import <archivo>' - Fusionar estadísticas de otro dispositivo")
+	fmt.Println("   • 'heatmap [días]' - Mapa de actividad por día y hora")
+	fmt.Println("   • 'history [filtros]' - Historial filtrado y paginado")
+	fmt.Println("   • 'tasks [task|project|tag|rule]' - Estadísticas por tarea, proyecto, tag o regla")
 	fmt.Println("   • 'reset' - Reiniciar estadísticas de sesión")
 	fmt.Println("   • 'notif-stats' - Estadísticas de notificaciones")
 	fmt.Println("   • Enter o 'c' - Volver al timer")
@@ -89,9 +107,10 @@ func (sc *StatsCommands) ShowQuickStatus() {
 	fmt.Println(ui.Colorize("──────────────", ui.ColorGray, true))
 
 	// Estado del timer con color contextual
-	stateColor := ui.GetTimerStateColor(timerData.State)
+	state := ui.SessionLabel(timerData.State)
+	stateColor := ui.GetTimerStateColor(state)
 	fmt.Printf("⏱️  Timer: %s (%s)\n",
-		ui.Colorize(timerData.State, stateColor, true),
+		ui.Colorize(state, stateColor, true),
 		ui.Colorize(timerData.Status, ui.ColorGray, true))
 
 	if timerData.Remaining > 0 {
@@ -127,42 +146,429 @@ func (sc *StatsCommands) ShowQuickStatus() {
 	fmt.Println()
 }
 
-// handleStatsCommands maneja el loop interactivo de comandos de estadísticas
-func (sc *StatsCommands) handleStatsCommands() {
-	inputChan := sc.handler.GetInputManager().GetInputChannel()
+// ShowReport muestra un reporte agregado del historial persistente.
+// Argumentos: [day|week|month] [zona horaria IANA]
+func (sc *StatsCommands) ShowReport(args []string) {
+	store := sc.handler.GetHistoryStore()
+	if store == nil {
+		fmt.Println("❌ El historial persistente está desactivado (usa -history para activarlo)")
+		return
+	}
 
-	for {
-		select {
-		case input := <-inputChan:
-			switch strings.TrimSpace(strings.ToLower(input)) {
-			case "", "c", "continue", "back", "volver":
-				sc.handler.SetShowingStats(false)
-				ui.ClearScreen()
-				return
+	periodArg := ""
+	if len(args) > 0 {
+		periodArg = args[0]
+	}
+	period, err := stats.ParseReportPeriod(periodArg)
+	if err != nil {
+		fmt.Printf("❌ Período '%s' no válido. Usa: day, week o month\n", periodArg)
+		return
+	}
 
-			case "compact", "compacto":
-				sc.ShowCompactStats()
+	loc := time.Local
+	if len(args) > 1 {
+		loc, err = time.LoadLocation(args[1])
+		if err != nil {
+			fmt.Printf("❌ Zona horaria '%s' no válida\n", args[1])
+			return
+		}
+	}
 
-			case "detailed", "detallado", "full", "completo":
-				sc.ShowDetailedStats()
-				return
+	report, err := stats.GenerateReport(store, "", period, time.Now(), loc)
+	if err != nil {
+		fmt.Printf("❌ Error generando reporte: %v\n", err)
+		return
+	}
 
-			case "reset", "reiniciar":
-				sc.confirmResetStats()
+	fmt.Println()
+	fmt.Print(ui.ReportDisplay(report, ui.DefaultStatsConfig()))
+	fmt.Println()
+}
 
-			case "export", "exportar":
-				fmt.Println("🚧 Función de exportación próximamente...")
-				fmt.Print("Comando stats > ")
+// ExportHistory escribe el historial a un archivo en el formato elegido.
+// Argumentos: [csv|md|ics|json] [filtros de history] [-o archivo]
+func (sc *StatsCommands) ExportHistory(args []string) {
+	formatArg := "csv"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		formatArg, args = args[0], args[1:]
+	}
 
-			case "notif-stats", "notification-stats":
-				sc.handler.GetNotificationCommands().ShowStats()
-				fmt.Print("Comando stats > ")
+	format, err := stats.ParseExportFormat(formatArg)
+	if err != nil {
+		fmt.Printf("❌ Formato '%s' no válido. Usa: csv, md, ics o json\n", formatArg)
+		return
+	}
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	filters := registerQueryFlags(flags)
+	output := flags.String("o", "", "Archivo de salida")
+	if err := flags.Parse(args); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("💡 Uso: export [csv|md|ics|json] [filtros] [-o archivo]")
+		return
+	}
+
+	query, err := filters.build()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	result, err := sc.runQuery(query)
+	if err != nil {
+		fmt.Printf("❌ Error leyendo historial: %v\n", err)
+		return
+	}
+	records := result.Sessions
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("gomodoro-%s.%s", time.Now().Format("2006-01-02"), format.Extension())
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("❌ No se pudo crear el archivo: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	if err := stats.Export(file, format, records, time.Local); err != nil {
+		fmt.Printf("❌ Error exportando: %v\n", err)
+		return
+	}
 
-			case "help", "h", "ayuda":
+	fmt.Printf("✅ %d sesiones exportadas a %s\n", len(records), ui.Colorize(path, ui.ColorCyan, true))
+}
+
+// ShowHeatmap muestra la actividad por día de la semana y hora de los últimos días.
+// Argumentos: [días] [zona horaria IANA]
+func (sc *StatsCommands) ShowHeatmap(args []string) {
+	days := 30
+	if len(args) > 0 {
+		value, err := strconv.Atoi(args[0])
+		if err != nil || value < 1 {
+			fmt.Printf("❌ Número de días '%s' no válido\n", args[0])
+			return
+		}
+		days = value
+	}
+
+	loc := time.Local
+	if len(args) > 1 {
+		var err error
+		loc, err = time.LoadLocation(args[1])
+		if err != nil {
+			fmt.Printf("❌ Zona horaria '%s' no válida\n", args[1])
+			return
+		}
+	}
+
+	result, err := sc.runQuery(stats.NewQuery().Between(time.Now().AddDate(0, 0, -days), time.Time{}).WorkOnly())
+	if err != nil {
+		fmt.Printf("❌ Error leyendo historial: %v\n", err)
+		return
+	}
+
+	heatmap := stats.BuildHeatmap(result.CompletedSessions(), loc)
+
+	fmt.Println()
+	fmt.Printf("📅 Últimos %d días\n", days)
+	fmt.Print(ui.HeatmapDisplay(heatmap, ui.ClassicTheme, ui.DefaultStatsConfig()))
+	fmt.Println()
+}
+
+// ShowTaskStats muestra estadísticas agrupadas por tarea, proyecto o tag.
+// Argumentos: tasks [task|project|tag|rule]
+func (sc *StatsCommands) ShowTaskStats(args []string) {
+	if len(args) == 0 || !(strings.EqualFold(args[0], "tasks") || strings.EqualFold(args[0], "tareas")) {
+		fmt.Println("💡 Uso: stats tasks [task|project|tag|rule]")
+		return
+	}
+
+	groupArg := ""
+	if len(args) > 1 {
+		groupArg = args[1]
+	}
+	groupBy, err := stats.ParseGroupBy(groupArg)
+	if err != nil {
+		fmt.Printf("❌ Agrupación '%s' no válida. Usa: task, project, tag o rule\n", groupArg)
+		return
+	}
+
+	result, err := sc.runQuery(stats.NewQuery().WorkOnly())
+	if err != nil {
+		fmt.Printf("❌ Error leyendo historial: %v\n", err)
+		return
+	}
+
+	fmt.Println()
+	fmt.Print(ui.TaskStatsDisplay(stats.AggregateByLabel(result.CompletedSessions(), groupBy), groupBy, ui.DefaultStatsConfig()))
+	fmt.Println()
+}
+
+// ImportHistory fusiona un JSON exportado (estadísticas o historial) con las estadísticas
+// actuales y el historial persistente, sin duplicar sesiones
+func (sc *StatsCommands) ImportHistory(args []string) {
+	if len(args) == 0 {
+		fmt.Println("💡 Uso: import <archivo.json>")
+		return
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Printf("❌ No se pudo leer el archivo: %v\n", err)
+		return
+	}
+
+	result, err := sc.handler.GetEngine().GetStats().MergeJSON(data)
+	if err != nil {
+		fmt.Printf("❌ Error importando: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✅ Importación completada: %s nuevas, %d duplicadas, %s conflictos\n",
+		ui.Colorize(fmt.Sprintf("%d", result.Added), ui.ColorGreen, true),
+		result.Duplicates,
+		ui.Colorize(fmt.Sprintf("%d", len(result.Conflicts)), ui.ColorYellow, true))
+
+	if store := sc.handler.GetHistoryStore(); store != nil {
+		sessions, _ := stats.ParseSessionsJSON(data)
+		stored, err := stats.MergeIntoStore(store, sc.handler.GetHistoryUserID(), sessions)
+		if err != nil {
+			fmt.Printf("❌ Error guardando en el historial: %v\n", err)
+		} else {
+			sc.handler.GetEngine().GetStats().InvalidateDayStreak()
+			fmt.Printf("🗂️  Historial: %d sesiones añadidas\n", stored.Added)
+		}
+	}
+
+	for _, conflict := range result.Conflicts {
+		fmt.Printf("   ⚠️  %s %s: %s\n",
+			ui.SessionLabel(conflict.Imported.Type),
+			conflict.Imported.StartTime.Local().Format("02/01/2006 15:04"),
+			conflict.Reason)
+	}
+}
+
+// ShowHistory muestra una página del historial con filtros y ordenación.
+// Argumentos: [filtros] [-sort start|duration|focus] [-asc] [-page N] [-n tamaño]
+func (sc *StatsCommands) ShowHistory(args []string) {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	filters := registerQueryFlags(flags)
+	sortArg := flags.String("sort", "start", "Orden: start, duration o focus")
+	ascending := flags.Bool("asc", false, "Orden ascendente")
+	page := flags.Int("page", 1, "Página")
+	pageSize := flags.Int("n", 15, "Sesiones por página")
+	if err := flags.Parse(args); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("💡 Uso: history [-from/-to AAAA-MM-DD] [-type work|break] [-status completed|skipped]")
+		fmt.Println("         [-min 10m] [-task X] [-project X] [-tag X] [-sort start|duration|focus] [-asc] [-page N] [-n N]")
+		return
+	}
+
+	query, err := filters.build()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	sortBy, err := stats.ParseSortField(*sortArg)
+	if err != nil {
+		fmt.Printf("❌ Orden '%s' no válido. Usa: start, duration o focus\n", *sortArg)
+		return
+	}
+	if *page < 1 || *pageSize < 1 {
+		fmt.Println("❌ La página y el tamaño de página deben ser mayores que 0")
+		return
+	}
+	if *page-1 > math.MaxInt / *pageSize {
+		fmt.Printf("❌ La página %d está fuera de rango\n", *page)
+		return
+	}
+	offset := (*page - 1) * *pageSize
+
+	result, err := sc.runQuery(query.SortBy(sortBy, !*ascending).Page(offset, *pageSize))
+	if err != nil {
+		fmt.Printf("❌ Error leyendo historial: %v\n", err)
+		return
+	}
+
+	fmt.Println()
+	fmt.Print(ui.HistoryDisplay(result, *page, *pageSize, ui.DefaultStatsConfig()))
+	fmt.Println()
+}
+
+// runQuery ejecuta una consulta sobre el historial persistente o, si está
+// desactivado, sobre las estadísticas en memoria
+func (sc *StatsCommands) runQuery(query *stats.Query) (*stats.QueryResult, error) {
+	if store := sc.handler.GetHistoryStore(); store != nil {
+		return query.Run(store)
+	}
+	return sc.handler.GetEngine().GetStats().Query(query), nil
+}
+
+// queryFlags agrupa los filtros de historial comunes a export e history
+type queryFlags struct {
+	from, to    *string
+	sessionType *string
+	status      *string
+	minDuration *time.Duration
+	task        *string
+	project     *string
+	tag         *string
+}
+
+// registerQueryFlags registra los filtros de historial en un FlagSet
+func registerQueryFlags(flags *flag.FlagSet) *queryFlags {
+	return &queryFlags{
+		from:        flags.String("from", "", "Fecha inicial (AAAA-MM-DD)"),
+		to:          flags.String("to", "", "Fecha final incluida (AAAA-MM-DD)"),
+		sessionType: flags.String("type", "", "Tipo de sesión: work, break o long"),
+		status:      flags.String("status", "", "Estado: completed o skipped"),
+		minDuration: flags.Duration("min", 0, "Duración real mínima (ej: 10m)"),
+		task:        flags.String("task", "", "Tarea"),
+		project:     flags.String("project", "", "Proyecto"),
+		tag:         flags.String("tag", "", "Tags separados por comas"),
+	}
+}
+
+// build construye la consulta a partir de los filtros
+func (qf *queryFlags) build() (*stats.Query, error) {
+	query := stats.NewQuery()
+
+	var from, to time.Time
+	var err error
+	if *qf.from != "" {
+		if from, err = time.ParseInLocation("2006-01-02", *qf.from, time.Local); err != nil {
+			return nil, fmt.Errorf("fecha inicial '%s' no válida (formato AAAA-MM-DD)", *qf.from)
+		}
+	}
+	if *qf.to != "" {
+		if to, err = time.ParseInLocation("2006-01-02", *qf.to, time.Local); err != nil {
+			return nil, fmt.Errorf("fecha final '%s' no válida (formato AAAA-MM-DD)", *qf.to)
+		}
+		to = to.AddDate(0, 0, 1) // incluir el día completo
+	}
+	query.Between(from, to)
+
+	switch strings.ToLower(*qf.sessionType) {
+	case "":
+	case "work", "trabajo", "pomodoro":
+		query.WorkOnly()
+	case "break", "descanso":
+		query.BreaksOnly()
+	case "long", "largo":
+		query.OfType(events.KindLongBreak)
+	default:
+		return nil, fmt.Errorf("tipo '%s' no válido (usa work, break o long)", *qf.sessionType)
+	}
+
+	switch strings.ToLower(*qf.status) {
+	case "":
+	case "completed", "completado", "completados":
+		query.Completed()
+	case "skipped", "saltado", "saltados":
+		query.Skipped()
+	default:
+		return nil, fmt.Errorf("estado '%s' no válido (usa completed o skipped)", *qf.status)
+	}
+
+	return query.MinDuration(*qf.minDuration).
+		WithTask(*qf.task).
+		WithProject(*qf.project).
+		WithTags(*qf.tag), nil
+}
+
+// registerCommands registra los comandos del modo estadísticas
+func (sc *StatsCommands) registerCommands() {
+	// withPrompt ejecuta un comando que no redibuja la pantalla y vuelve a pedir otro
+	withPrompt := func(action func([]string)) func(Args) error {
+		return func(args Args) error {
+			action(args.Rest())
+			fmt.Print("Comando stats > ")
+			return nil
+		}
+	}
+	rest := func(name string) []Arg {
+		return []Arg{{Name: name, Optional: true, Rest: true}}
+	}
+
+	sc.registry.MustRegister(
+		&Command{Name: "detailed", Aliases: []string{"detallado", "full", "completo"},
+			Help: "Vista detallada con gráficos",
+			Run: func(Args) error {
+				sc.ShowDetailedStats()
+				return errLeaveStats
+			}},
+		&Command{Name: "compact", Aliases: []string{"compacto"},
+			Help: "Vista compacta",
+			Run: func(Args) error {
+				sc.ShowCompactStats()
+				return nil
+			}},
+		&Command{Name: "reset", Aliases: []string{"reiniciar"},
+			Help: "Reiniciar estadísticas",
+			Run: func(Args) error {
+				sc.confirmResetStats()
+				return nil
+			}},
+		&Command{Name: "export", Aliases: []string{"exportar"}, Args: rest("csv|md|ics|json filtros"),
+			Help: "Exportar historial (acepta los filtros de history, -o elige archivo)",
+			Run:  withPrompt(sc.ExportHistory)},
+		&Command{Name: "import", Aliases: []string{"importar"}, Args: []Arg{{Name: "archivo"}},
+			Help: "Fusionar un JSON exportado sin duplicar sesiones",
+			Run: func(args Args) error {
+				sc.ImportHistory([]string{args.String("archivo")})
+				fmt.Print("Comando stats > ")
+				return nil
+			}},
+		&Command{Name: "heatmap", Aliases: []string{"mapa"}, Args: rest("días [zona]"),
+			Help: "Mapa de actividad por día de la semana y hora",
+			Run:  withPrompt(sc.ShowHeatmap)},
+		&Command{Name: "tasks", Aliases: []string{"tareas"}, Args: rest("task|project|tag|rule"),
+			Help: "Estadísticas por tarea, proyecto, tag o regla",
+			Run: withPrompt(func(args []string) {
+				sc.ShowTaskStats(append([]string{"tasks"}, args...))
+			})},
+		&Command{Name: "history", Aliases: []string{"historial"}, Args: rest("filtros"),
+			Help: "Historial paginado (-from/-to, -type, -status, -min, -task, -project, -tag, -sort, -asc, -page, -n)",
+			Run:  withPrompt(sc.ShowHistory)},
+		&Command{Name: "notif-stats", Aliases: []string{"notification-stats"},
+			Help: "Estadísticas de notificaciones",
+			Run: withPrompt(func([]string) {
+				sc.handler.GetNotificationCommands().ShowStats()
+			})},
+		&Command{Name: "help", Aliases: []string{"h", "ayuda"},
+			Help: "Esta ayuda",
+			Run: func(Args) error {
 				sc.showStatsHelp()
+				return nil
+			}},
+		&Command{Name: "continue", Aliases: []string{"c", "back", "volver", ""},
+			Help: "Volver al timer",
+			Run: func(Args) error {
+				sc.handler.SetShowingStats(false)
+				ui.ClearScreen()
+				return errLeaveStats
+			}},
+	)
+}
 
-			default:
-				fmt.Printf("❌ Comando '%s' no reconocido en modo stats\n", input)
+// handleStatsCommands maneja el loop interactivo de comandos de estadísticas
+func (sc *StatsCommands) handleStatsCommands() {
+	inputChan := sc.handler.GetInputManager().GetInputChannel()
+
+	for {
+		select {
+		case input := <-inputChan:
+			err := sc.registry.Execute(input)
+			if errors.Is(err, errLeaveStats) {
+				return
+			}
+			if err != nil {
+				printCommandError(err, "help")
 				fmt.Print("Comando stats > ")
 			}
 		default:
@@ -210,13 +616,7 @@ func (sc *StatsCommands) showStatsHelp() {
 	fmt.Println(ui.Colorize("─────────────────────────────", ui.ColorGray, true))
 	fmt.Println()
 	fmt.Println("📊 COMANDOS DISPONIBLES:")
-	fmt.Println("   • detailed/completo  - Vista detallada con gráficos")
-	fmt.Println("   • compact/compacto   - Vista compacta")
-	fmt.Println("   • reset/reiniciar    - Reiniciar estadísticas")
-	fmt.Println("   • export/exportar    - Exportar datos (próximamente)")
-	fmt.Println("   • notif-stats        - Estadísticas de notificaciones")
-	fmt.Println("   • help/ayuda         - Esta ayuda")
-	fmt.Println("   • c/continue/Enter   - Volver al timer")
+	sc.registry.PrintHelp(nil)
 	fmt.Println()
 	fmt.Println("💡 CONSEJOS:")
 	fmt.Println("   • Las estadísticas se actualizan automáticamente")
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// TaskCommands maneja los comandos de tareas, proyectos y tags
+type TaskCommands struct {
+	handler *CLIHandler
+}
+
+// NewTaskCommands crea un nuevo handler de comandos de tareas
+func NewTaskCommands(h *CLIHandler) *TaskCommands {
+	return &TaskCommands{handler: h}
+}
+
+// SetTask asigna la tarea de los próximos pomodoros.
+// Argumentos: <nombre> [@proyecto] [#tag ...] [~estimación] | clear
+func (tc *TaskCommands) SetTask(args []string) {
+	eng := tc.handler.GetEngine()
+
+	if len(args) == 0 {
+		current := eng.GetLabels()
+		if current.IsEmpty() {
+			fmt.Println("🏷️  Sin tarea asignada")
+			fmt.Println("💡 Uso: task <nombre> [@proyecto] [#tag] [~pomodoros estimados]")
+			return
+		}
+		fmt.Printf("🏷️  Tarea actual: %s\n", ui.Colorize(current.Summary(), ui.ColorCyan, true))
+		return
+	}
+
+	if len(args) == 1 && (strings.EqualFold(args[0], "clear") || strings.EqualFold(args[0], "limpiar") || args[0] == "-") {
+		eng.SetLabels(stats.Labels{})
+		fmt.Println("🏷️  Tarea eliminada")
+		return
+	}
+
+	labels, err := ParseLabels(args)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	eng.SetLabels(labels)
+	fmt.Printf("✅ Trabajando en: %s\n", ui.Colorize(labels.Summary(), ui.ColorCyan, true))
+}
+
+// ParseLabels interpreta "<nombre> @proyecto #tag ~3"
+func ParseLabels(args []string) (stats.Labels, error) {
+	var labels stats.Labels
+	var name []string
+	var tags []string
+
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "@") && len(arg) > 1:
+			labels.Project = arg[1:]
+		case strings.HasPrefix(arg, "#") && len(arg) > 1:
+			tags = append(tags, arg[1:])
+		case strings.HasPrefix(arg, "~") && len(arg) > 1:
+			estimate, err := strconv.Atoi(arg[1:])
+			if err != nil || estimate < 1 {
+				return labels, fmt.Errorf("estimación '%s' no válida (usa ~N pomodoros)", arg)
+			}
+			labels.Estimate = estimate
+		default:
+			name = append(name, arg)
+		}
+	}
+
+	labels.Task = strings.Join(name, " ")
+	labels.Tags = stats.ParseTags(strings.Join(tags, ","))
+	return labels, nil
+}
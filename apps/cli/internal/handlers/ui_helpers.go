@@ -68,7 +68,7 @@ func (uh *UIHelpers) DisplayTimerWithStats() {
 	statsData := uh.handler.GetCurrentStatsData()
 
 	// Timer principal con información más clara
-	state := timerData.State
+	state := ui.SessionLabel(timerData.State)
 	status := timerData.Status
 
 	// Mostrar número de sesión actual para mayor claridad
@@ -93,43 +93,25 @@ func (uh *UIHelpers) ShowInlineHelp() {
 	fmt.Println(ui.Colorize("🎮 COMANDOS DISPONIBLES", ui.ColorCyan, true))
 	fmt.Println(ui.Colorize("─────────────────────", ui.ColorGray, true))
 
+	processor := uh.handler.GetCommandProcessor()
+	registry := processor.GetRegistry()
 	if uh.handler.IsFirstSessionStarted() {
-		fmt.Println("⏱️  CONTROL DEL TIMER:")
-		fmt.Println("   • (p)ause    - Pausar timer actual")
-		fmt.Println("   • (r)esume   - Reanudar timer pausado")
-		fmt.Println("   • (s)kip     - Saltar sesión actual")
-		fmt.Println("   • (c)ontinue - Continuar al siguiente")
-		fmt.Println()
-		fmt.Println("📊 ESTADÍSTICAS:")
-		fmt.Println("   • stats      - Ver estadísticas detalladas")
-		fmt.Println("   • compact    - Ver estadísticas compactas")
-		fmt.Println("   • status     - Estado rápido del timer")
-		fmt.Println()
-		fmt.Println("🔔 NOTIFICACIONES:")
-		fmt.Println("   • test-sound - Probar sonidos")
-		fmt.Println("   • notifications - Ver configuración")
-		fmt.Println("   • sound-on/off - Activar/desactivar sonido")
-		fmt.Println("   • notif-stats - Estadísticas de notificaciones")
-		fmt.Println("   • vol+/vol- - Ajustar volumen")
-		fmt.Println()
-		fmt.Println("🎨 EXTRAS:")
-		fmt.Println("   • demo       - Demostración de temas")
-		fmt.Println("   • test       - Prueba de características")
-		fmt.Println("   • (h)elp     - Esta ayuda")
-		fmt.Println("   • (q)uit     - Salir del programa")
+		registry.PrintHelp(nil)
 	} else {
-		fmt.Println("🚀 INICIO:")
-		fmt.Println("   • (c)ontinue - Empezar el primer pomodoro")
-		fmt.Println("   • (h)elp     - Mostrar esta ayuda")
-		fmt.Println("   • (q)uit     - Salir del programa")
-		fmt.Println()
-		fmt.Println("🔊 AUDIO:")
-		fmt.Println("   • test-sound - Probar sistema de sonidos")
-		fmt.Println("   • notifications - Ver configuración de audio")
-		fmt.Println()
-		fmt.Println("🎨 PREVIEW:")
-		fmt.Println("   • demo       - Ver demostración de temas")
-		fmt.Println("   • test       - Probar características")
+		// Antes de empezar solo se muestran los comandos básicos
+		registry.PrintHelp(func(cmd *Command) bool { return cmd.Basic })
+	}
+
+	// Los alias de usuario se pueden usar desde el principio
+	if macros := processor.GetMacros().List(); len(macros) > 0 {
+		names := make([]string, len(macros))
+		for i, macro := range macros {
+			names[i] = macro.Name
+		}
+		fmt.Printf("\n⚡ Tus alias: %s ('alias' para verlos)\n", strings.Join(names, ", "))
+	}
+
+	if !uh.handler.IsFirstSessionStarted() {
 		fmt.Println()
 		fmt.Println("💡 Después de empezar tendrás más comandos disponibles")
 	}
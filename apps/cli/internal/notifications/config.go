@@ -2,7 +2,10 @@ package notifications
 
 import (
 	"fmt"
+	"os"
 	"time"
+
+	coreconfig "github.com/kubaliski/pomodoro-core/config"
 )
 
 // Config contiene la configuración para el sistema de notificaciones
@@ -117,6 +120,42 @@ func DefaultConfig() *Config {
 	}
 }
 
+// DefaultConfigPath retorna la ruta del archivo de notificaciones en el directorio de configuración
+// (notifications.json, .yaml, .yml o .toml, el primero que exista)
+func DefaultConfigPath() string {
+	return coreconfig.FindConfigFile(coreconfig.ConfigDir(), "notifications")
+}
+
+// LoadConfig carga la configuración de notificaciones desde un archivo JSON, YAML o TOML.
+// Los campos ausentes mantienen su valor por defecto.
+func LoadConfig(path string) (*Config, error) {
+	config := DefaultConfig()
+	if err := coreconfig.ReadFile(path, config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notification config: %w", err)
+	}
+	return config, nil
+}
+
+// LoadConfigIfExists carga el archivo de notificaciones si existe; si no, retorna la configuración por defecto
+func LoadConfigIfExists(path string) (*Config, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return DefaultConfig(), nil
+	}
+	return LoadConfig(path)
+}
+
+// SaveToFile guarda la configuración en un archivo JSON, YAML o TOML según su extensión
+func (c *Config) SaveToFile(path string) error {
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("cannot save invalid notification config: %w", err)
+	}
+	return coreconfig.WriteFile(path, c)
+}
+
 // Clone crea una copia profunda de la configuración
 func (c *Config) Clone() *Config {
 	clone := *c
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/kubaliski/pomodoro-core/metrics"
 )
 
 // NotificationType define los tipos de notificaciones disponibles
@@ -73,6 +75,7 @@ type Manager struct {
 	notifiers map[NotificationType]Notifier
 	enabled   bool
 	stats     *NotificationStats
+	metrics   *metrics.Collector
 }
 
 // NotificationStats mantiene estadísticas de notificaciones
@@ -127,6 +130,13 @@ func (m *Manager) RegisterNotifier(notifier Notifier) error {
 	return nil
 }
 
+// SetMetrics establece el collector donde se cuentan las notificaciones fallidas
+func (m *Manager) SetMetrics(collector *metrics.Collector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = collector
+}
+
 // Notify envía una notificación usando los tipos especificados
 func (m *Manager) Notify(request NotificationRequest) []NotificationResponse {
 	m.mu.RLock()
@@ -563,6 +573,7 @@ func (m *Manager) updateStats(event EventType, response NotificationResponse) {
 		m.stats.SuccessCount++
 	} else {
 		m.stats.FailureCount++
+		m.metrics.NotificationFailed(string(response.Type))
 	}
 
 	m.stats.ByType[response.Type]++
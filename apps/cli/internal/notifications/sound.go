@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
@@ -14,6 +15,7 @@ import (
 type SoundNotifier struct {
 	config   map[string]interface{}
 	platform string
+	out      io.Writer // Salida de los mensajes de depuración y del timbre de la terminal
 }
 
 // NewSoundNotifier crea un nuevo notificador de sonido
@@ -21,9 +23,15 @@ func NewSoundNotifier() *SoundNotifier {
 	return &SoundNotifier{
 		config:   make(map[string]interface{}),
 		platform: runtime.GOOS,
+		out:      os.Stdout,
 	}
 }
 
+// SetOutput cambia dónde se escriben los mensajes de depuración y el timbre de la terminal
+func (s *SoundNotifier) SetOutput(w io.Writer) {
+	s.out = w
+}
+
 // GetType retorna el tipo de notificador
 func (s *SoundNotifier) GetType() NotificationType {
 	return TypeSound
@@ -57,7 +65,7 @@ func (s *SoundNotifier) Notify(request NotificationRequest) NotificationResponse
 	soundType := s.getSoundTypeForEvent(request.Event)
 
 	// DEBUG: Agregar logging para debugging
-	fmt.Printf("[DEBUG] Event: %s -> SoundType: %s\n", request.Event, soundType)
+	fmt.Fprintf(s.out, "[DEBUG] Event: %s -> SoundType: %s\n", request.Event, soundType)
 
 	// Obtener configuración de sonido
 	volume := s.getConfigFloat("volume", 0.7)
@@ -69,10 +77,10 @@ func (s *SoundNotifier) Notify(request NotificationRequest) NotificationResponse
 
 	// Intentar reproducir sonido personalizado primero
 	if customSounds {
-		fmt.Printf("[DEBUG] Trying custom sound for type: %s\n", soundType)
+		fmt.Fprintf(s.out, "[DEBUG] Trying custom sound for type: %s\n", soundType)
 		err = s.playCustomSound(soundType, volume)
 		if err == nil {
-			fmt.Printf("[DEBUG] Custom sound successful\n")
+			fmt.Fprintf(s.out, "[DEBUG] Custom sound successful\n")
 			return NotificationResponse{
 				Success:  true,
 				Type:     TypeSound,
@@ -80,17 +88,17 @@ func (s *SoundNotifier) Notify(request NotificationRequest) NotificationResponse
 			}
 		}
 		// Si falla, continuar con sonidos del sistema
-		fmt.Printf("[DEBUG] Custom sound failed: %v, trying system sound\n", err)
+		fmt.Fprintf(s.out, "[DEBUG] Custom sound failed: %v, trying system sound\n", err)
 	}
 
 	// Reproducir sonido del sistema
-	fmt.Printf("[DEBUG] Trying system sound for type: %s (freq: %d, dur: %d)\n", soundType, frequency, duration)
+	fmt.Fprintf(s.out, "[DEBUG] Trying system sound for type: %s (freq: %d, dur: %d)\n", soundType, frequency, duration)
 	err = s.playSystemSound(soundType, volume, duration, frequency)
 
 	if err != nil {
-		fmt.Printf("[DEBUG] System sound failed: %v\n", err)
+		fmt.Fprintf(s.out, "[DEBUG] System sound failed: %v\n", err)
 	} else {
-		fmt.Printf("[DEBUG] System sound successful\n")
+		fmt.Fprintf(s.out, "[DEBUG] System sound successful\n")
 	}
 
 	return NotificationResponse{
@@ -106,7 +114,7 @@ func (s *SoundNotifier) getSoundTypeForEvent(event EventType) string {
 	// Convertir a string para hacer comparación más robusta
 	eventStr := string(event)
 
-	fmt.Printf("[DEBUG] Processing event: '%s'\n", eventStr)
+	fmt.Fprintf(s.out, "[DEBUG] Processing event: '%s'\n", eventStr)
 
 	switch eventStr {
 	case "pomodoro_completed":
@@ -126,7 +134,7 @@ func (s *SoundNotifier) getSoundTypeForEvent(event EventType) string {
 	case "custom_alert":
 		return "default"
 	default:
-		fmt.Printf("[DEBUG] Unknown event type: '%s', using default\n", eventStr)
+		fmt.Fprintf(s.out, "[DEBUG] Unknown event type: '%s', using default\n", eventStr)
 		return "default"
 	}
 }
@@ -491,19 +499,19 @@ func (s *SoundNotifier) playLinuxTerminalBell(soundType string) error {
 	case "urgent":
 		// Múltiples bells
 		for i := 0; i < 3; i++ {
-			fmt.Print("\a") // Terminal bell
+			fmt.Fprint(s.out, "\a") // Terminal bell
 			if i < 2 {
 				time.Sleep(200 * time.Millisecond)
 			}
 		}
 	case "start":
-		fmt.Print("\a") // Terminal bell simple
+		fmt.Fprint(s.out, "\a") // Terminal bell simple
 	case "pause":
-		fmt.Print("\a") // Terminal bell simple
+		fmt.Fprint(s.out, "\a") // Terminal bell simple
 	case "resume":
-		fmt.Print("\a") // Terminal bell simple
+		fmt.Fprint(s.out, "\a") // Terminal bell simple
 	default:
-		fmt.Print("\a") // Terminal bell
+		fmt.Fprint(s.out, "\a") // Terminal bell
 	}
 	return nil
 }
@@ -0,0 +1,196 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// Source es el origen de los eventos de error que publica el gestor de plugins
+const Source = "plugins.Manager"
+
+// Códigos de los eventos de error de los plugins
+const (
+	CodeFailed   = "PLUGIN_FAILED"   // El plugin terminó con error o no completó el handshake
+	CodeDisabled = "PLUGIN_DISABLED" // El plugin se desactivó y no se volverá a lanzar
+	CodeProtocol = "PLUGIN_PROTOCOL" // El plugin envió un mensaje no válido o una acción falló
+)
+
+// Command es un comando que un plugin añade a la CLI
+type Command struct {
+	Plugin *Plugin
+	CommandSpec
+}
+
+// Manager lanza los plugins, les reparte los eventos del engine y ejecuta sus acciones.
+// Cada plugin es un proceso aparte: si falla se reinicia sin afectar a la CLI ni a los demás.
+type Manager struct {
+	engine  engine.EngineInterface
+	notice  func(text string)
+	plugins []*Plugin
+
+	mu       sync.RWMutex
+	eventBus *events.EventBus
+
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// NewManager crea el gestor de los plugins de los manifiestos. notice muestra los mensajes
+// que envían los plugins.
+func NewManager(eng engine.EngineInterface, manifests []*Manifest, notice func(text string)) *Manager {
+	m := &Manager{engine: eng, notice: notice}
+	for _, manifest := range manifests {
+		state := StateStopped
+		if manifest.Disabled {
+			state = StateDisabled
+		}
+		m.plugins = append(m.plugins, &Plugin{manifest: manifest, manager: m, state: state})
+	}
+	return m
+}
+
+// Subscribe reparte los eventos del bus entre los plugins y publica en él sus fallos
+func (m *Manager) Subscribe(eventBus *events.EventBus) {
+	m.mu.Lock()
+	m.eventBus = eventBus
+	m.mu.Unlock()
+
+	eventBus.SubscribeGlobal(m)
+}
+
+// Start lanza los plugins activos y espera a su primer handshake, para que sus comandos
+// se puedan registrar. Los fallos se publican en el bus y el plugin se reinicia según su política.
+func (m *Manager) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	var pending []chan error
+	for _, plugin := range m.plugins {
+		if plugin.manifest.Disabled {
+			continue
+		}
+		ready := make(chan error, 1)
+		pending = append(pending, ready)
+		m.running.Add(1)
+		go func(plugin *Plugin) {
+			defer m.running.Done()
+			plugin.supervise(ctx, ready)
+		}(plugin)
+	}
+
+	for _, ready := range pending {
+		<-ready
+	}
+}
+
+// Stop pide a los plugins que terminen y espera a que lo hagan
+func (m *Manager) Stop() {
+	var wg sync.WaitGroup
+	for _, plugin := range m.plugins {
+		wg.Add(1)
+		go func(plugin *Plugin) {
+			defer wg.Done()
+			plugin.stop()
+		}(plugin)
+	}
+	wg.Wait()
+
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.running.Wait()
+}
+
+// Plugins retorna los plugins en el orden de sus manifiestos
+func (m *Manager) Plugins() []*Plugin {
+	return m.plugins
+}
+
+// Commands retorna los comandos que anunciaron los plugins en su handshake
+func (m *Manager) Commands() []Command {
+	var commands []Command
+	for _, plugin := range m.plugins {
+		for _, spec := range plugin.Status().Commands {
+			commands = append(commands, Command{Plugin: plugin, CommandSpec: spec})
+		}
+	}
+	sort.SliceStable(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	return commands
+}
+
+// HandleEvent implementa events.EventHandler: envía el evento a los plugins interesados
+func (m *Manager) HandleEvent(event events.Event) {
+	for _, plugin := range m.plugins {
+		plugin.deliver(event)
+	}
+}
+
+// perform ejecuta una acción pedida por un plugin
+func (m *Manager) perform(plugin *Plugin, msg Message) error {
+	started := m.engine.GetState() != engine.StateIdle
+	requireStarted := func(action func() error) error {
+		if !started {
+			return fmt.Errorf("no session started yet")
+		}
+		return action()
+	}
+
+	switch msg.Action {
+	case ActionStart:
+		if started {
+			return fmt.Errorf("session already started")
+		}
+		return m.engine.StartFirstSession()
+	case ActionPause:
+		return requireStarted(m.engine.Pause)
+	case ActionResume:
+		return requireStarted(m.engine.Resume)
+	case ActionSkip:
+		return requireStarted(m.engine.Skip)
+	case ActionExtend:
+		d, err := config.ParseConfigDuration(msg.Duration)
+		if err != nil {
+			return err
+		}
+		return requireStarted(func() error { return m.engine.Extend(d) })
+	case ActionTask:
+		var labels stats.Labels
+		if msg.Labels != nil {
+			labels = *msg.Labels
+		}
+		m.engine.SetLabels(labels)
+		return nil
+	case ActionMessage:
+		text := strings.TrimSpace(msg.Text)
+		if text == "" {
+			return fmt.Errorf("message text is required")
+		}
+		m.notice(fmt.Sprintf("🧩 %s: %s", plugin.Name(), text))
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q", msg.Action)
+	}
+}
+
+// report publica el fallo de un plugin en el bus de eventos
+func (m *Manager) report(code, message string) {
+	m.mu.RLock()
+	eventBus := m.eventBus
+	m.mu.RUnlock()
+	if eventBus == nil {
+		return
+	}
+
+	eventBus.Publish(events.ErrorOccurred, events.ErrorEventData{
+		Message: message,
+		Code:    code,
+		Source:  Source,
+	})
+}
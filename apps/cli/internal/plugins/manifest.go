@@ -0,0 +1,162 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-core/config"
+)
+
+// ManifestName es el nombre del manifiesto dentro del directorio de cada plugin
+// (plugin.json, plugin.yaml, plugin.yml o plugin.toml)
+const ManifestName = "plugin"
+
+// DefaultMaxRestarts es el número de reinicios seguidos tras los que se desactiva un plugin
+const DefaultMaxRestarts = 5
+
+// RestartPolicy indica cuándo se reinicia un plugin que termina
+type RestartPolicy string
+
+const (
+	RestartOnFailure RestartPolicy = "on-failure" // Solo si termina con error (por defecto)
+	RestartAlways    RestartPolicy = "always"     // También si termina sin error
+	RestartNever     RestartPolicy = "never"
+)
+
+// Manifest describe un plugin: qué ejecutar y cómo supervisarlo
+type Manifest struct {
+	Name        string        `json:"name"`
+	Version     string        `json:"version,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Command     string        `json:"command"`            // Ejecutable; las rutas relativas parten del directorio del manifiesto
+	Args        []string      `json:"args,omitempty"`     // Argumentos del ejecutable
+	Protocol    int           `json:"protocol,omitempty"` // Versión del protocolo (por defecto la actual)
+	Restart     RestartPolicy `json:"restart,omitempty"`
+	MaxRestarts int           `json:"max_restarts,omitempty"` // 0 = DefaultMaxRestarts
+	Disabled    bool          `json:"disabled,omitempty"`
+
+	Path string `json:"-"` // Archivo del que se leyó
+}
+
+// DefaultDir retorna el directorio de plugins en el directorio de configuración
+func DefaultDir() string {
+	return filepath.Join(config.ConfigDir(), "plugins")
+}
+
+// LoadManifest lee y valida el manifiesto de un plugin
+func LoadManifest(path string) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := config.ReadFile(path, manifest); err != nil {
+		return nil, err
+	}
+	manifest.Path = path
+
+	if manifest.Protocol == 0 {
+		manifest.Protocol = ProtocolVersion
+	}
+	if manifest.Restart == "" {
+		manifest.Restart = RestartOnFailure
+	}
+	if manifest.MaxRestarts == 0 {
+		manifest.MaxRestarts = DefaultMaxRestarts
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// Validate comprueba que el manifiesto se pueda ejecutar con esta versión de la CLI
+func (m *Manifest) Validate() error {
+	if err := ValidateName(m.Name); err != nil {
+		return err
+	}
+	if strings.TrimSpace(m.Command) == "" {
+		return fmt.Errorf("command is required")
+	}
+	if m.Protocol < MinProtocolVersion || m.Protocol > ProtocolVersion {
+		return fmt.Errorf("protocol version %d is not supported (supported: %d-%d)", m.Protocol, MinProtocolVersion, ProtocolVersion)
+	}
+	switch m.Restart {
+	case RestartOnFailure, RestartAlways, RestartNever:
+	default:
+		return fmt.Errorf("invalid restart policy %q (use on-failure, always or never)", m.Restart)
+	}
+	if m.MaxRestarts < 0 {
+		return fmt.Errorf("max_restarts must not be negative")
+	}
+	return nil
+}
+
+// Dir retorna el directorio del manifiesto, donde se ejecuta el plugin
+func (m *Manifest) Dir() string {
+	return filepath.Dir(m.Path)
+}
+
+// CommandPath retorna el ejecutable del plugin. Un nombre sin separadores se busca en el PATH.
+func (m *Manifest) CommandPath() string {
+	if filepath.IsAbs(m.Command) || !strings.ContainsAny(m.Command, `/\`) {
+		return m.Command
+	}
+	return filepath.Join(m.Dir(), m.Command)
+}
+
+// ValidateName comprueba el nombre de un plugin o de uno de sus comandos:
+// minúsculas, dígitos, '-' y '_', empezando por una letra
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-' || c == '_'):
+		default:
+			return fmt.Errorf("invalid name %q (use lowercase letters, digits, '-' and '_', starting with a letter)", name)
+		}
+	}
+	return nil
+}
+
+// Discover lee los manifiestos de los subdirectorios de dir. Si dir no existe no hay plugins.
+// Los manifiestos no válidos o con nombre repetido se omiten y se retornan como error.
+func Discover(dir string) ([]*Manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+
+	var manifests []*Manifest
+	var errs []error
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		// Los subdirectorios pueden ser enlaces; los que no tienen manifiesto se ignoran
+		path := config.FindConfigFile(filepath.Join(dir, entry.Name()), ManifestName)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		manifest, err := LoadManifest(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if previous, ok := seen[manifest.Name]; ok {
+			errs = append(errs, fmt.Errorf("plugin %q in %s already defined in %s", manifest.Name, path, previous))
+			continue
+		}
+		seen[manifest.Name] = path
+		manifests = append(manifests, manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Name < manifests[j].Name })
+	return manifests, errors.Join(errs...)
+}
@@ -0,0 +1,555 @@
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// Tiempos del protocolo
+const (
+	HandshakeTimeout = 5 * time.Second  // Para contestar al "hello" de la CLI
+	InvokeTimeout    = 10 * time.Second // Para contestar a la invocación de un comando
+	ShutdownTimeout  = 2 * time.Second  // Para terminar tras "shutdown" antes de detenerlo
+)
+
+const (
+	outgoingBuffer = 256         // Mensajes pendientes de enviar; si se llena se descartan eventos
+	maxLineSize    = 1 << 20     // Tamaño máximo de un mensaje del plugin
+	maxStderr      = 4 * 1024    // Salida de error que se guarda para informar de un fallo
+	stableAfter    = time.Minute // Tras este tiempo en marcha se reinicia la cuenta de reinicios
+)
+
+// Tiempos que usa el supervisor (variables para que los tests los acorten)
+var (
+	handshakeTimeout = HandshakeTimeout
+	invokeTimeout    = InvokeTimeout
+	minBackoff       = time.Second
+	maxBackoff       = 30 * time.Second
+)
+
+// State es el estado de un plugin
+type State string
+
+const (
+	StateStarting   State = "starting"
+	StateRunning    State = "running"
+	StateRestarting State = "restarting"
+	StateStopped    State = "stopped"  // Terminó y no se reinicia, o se cerró la CLI
+	StateFailed     State = "failed"   // Desactivado por sus fallos
+	StateDisabled   State = "disabled" // Desactivado en el manifiesto
+)
+
+// Status es una instantánea del estado de un plugin
+type Status struct {
+	State    State
+	Version  string // La que anunció en el "hello" o, si no, la del manifiesto
+	Restarts int    // Reinicios seguidos
+	Error    string // Último fallo
+	Commands []CommandSpec
+	Dropped  int // Eventos descartados porque el plugin no los leía a tiempo
+}
+
+// VersionError indica que el plugin habla una versión del protocolo que la CLI no admite.
+// No tiene sentido reiniciarlo.
+type VersionError struct {
+	Version int
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("unsupported protocol version %d (supported: %d-%d)", e.Version, MinProtocolVersion, ProtocolVersion)
+}
+
+// Plugin es un proceso externo supervisado: si termina con error se reinicia con
+// esperas crecientes hasta MaxRestarts veces seguidas
+type Plugin struct {
+	manifest *Manifest
+	manager  *Manager
+
+	mu       sync.Mutex
+	state    State
+	lastErr  string
+	restarts int
+	hello    Message
+	proc     *process // Proceso en marcha tras el handshake
+	nextID   int64
+	dropped  int
+	stopping bool
+}
+
+// process es una ejecución del plugin
+type process struct {
+	cmd      *exec.Cmd
+	out      chan Message
+	hello    chan Message
+	quit     chan struct{} // Cerrar la entrada del plugin
+	readDone chan struct{} // Terminó de leer su salida
+	done     chan struct{} // Terminó el proceso
+	quitOnce sync.Once
+	waitOnce sync.Once
+	waitErr  error
+	stderr   *tailBuffer
+	events   map[events.EventType]bool // Vacío = todos
+	pending  map[int64]chan Message    // Invocaciones esperando su "result" (con Plugin.mu)
+}
+
+// Name retorna el nombre del plugin
+func (p *Plugin) Name() string {
+	return p.manifest.Name
+}
+
+// Manifest retorna el manifiesto del plugin
+func (p *Plugin) Manifest() *Manifest {
+	return p.manifest
+}
+
+// Status retorna el estado actual del plugin
+func (p *Plugin) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	version := p.hello.Version
+	if version == "" {
+		version = p.manifest.Version
+	}
+	return Status{
+		State:    p.state,
+		Version:  version,
+		Restarts: p.restarts,
+		Error:    p.lastErr,
+		Commands: append([]CommandSpec(nil), p.hello.Commands...),
+		Dropped:  p.dropped,
+	}
+}
+
+// setState cambia el estado y el último fallo
+func (p *Plugin) setState(state State, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state = state
+	if err != nil {
+		p.lastErr = err.Error()
+	}
+}
+
+// supervise ejecuta el plugin y lo reinicia según su política hasta que se cancela ctx.
+// ready recibe el resultado del primer handshake.
+func (p *Plugin) supervise(ctx context.Context, ready chan<- error) {
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		err := p.runOnce(ctx, ready)
+		ready = nil
+
+		p.mu.Lock()
+		p.proc = nil
+		stopping := p.stopping
+		p.mu.Unlock()
+
+		if stopping || ctx.Err() != nil {
+			p.setState(StateStopped, nil)
+			return
+		}
+
+		var versionErr *VersionError
+		switch {
+		case errors.As(err, &versionErr):
+			p.setState(StateFailed, err)
+			p.manager.report(CodeDisabled, fmt.Sprintf("plugin %s disabled: %v", p.Name(), err))
+			return
+		case err == nil && p.manifest.Restart != RestartAlways:
+			p.setState(StateStopped, nil)
+			p.manager.notice(fmt.Sprintf("🧩 El plugin %s terminó", p.Name()))
+			return
+		case p.manifest.Restart == RestartNever:
+			p.setState(StateFailed, err)
+			p.manager.report(CodeFailed, fmt.Sprintf("plugin %s failed: %v", p.Name(), err))
+			return
+		}
+
+		p.mu.Lock()
+		if time.Since(started) >= stableAfter {
+			p.restarts = 0
+			backoff = minBackoff
+		}
+		restarts := p.restarts
+		p.mu.Unlock()
+
+		if restarts >= p.manifest.MaxRestarts {
+			p.setState(StateFailed, err)
+			p.manager.report(CodeDisabled, fmt.Sprintf("plugin %s disabled after %d restarts: %v", p.Name(), restarts, err))
+			return
+		}
+
+		p.mu.Lock()
+		p.restarts++
+		p.mu.Unlock()
+		p.setState(StateRestarting, err)
+		reason := "exited"
+		if err != nil {
+			reason = err.Error()
+		}
+		p.manager.report(CodeFailed, fmt.Sprintf("plugin %s %s; restarting in %s (%d/%d)", p.Name(), reason, backoff, restarts+1, p.manifest.MaxRestarts))
+
+		select {
+		case <-ctx.Done():
+			p.setState(StateStopped, nil)
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// runOnce lanza el plugin, hace el handshake y espera a que termine
+func (p *Plugin) runOnce(ctx context.Context, ready chan<- error) error {
+	p.setState(StateStarting, nil)
+
+	proc, err := p.start(ctx)
+	if err == nil {
+		err = p.handshake(proc)
+	}
+	if ready != nil {
+		ready <- err
+	}
+	if err != nil {
+		if proc != nil {
+			proc.cmd.Process.Kill()
+			proc.wait()
+		}
+		return err
+	}
+	return proc.wait()
+}
+
+// start lanza el proceso del plugin y sus goroutines de lectura y escritura
+func (p *Plugin) start(ctx context.Context) (*process, error) {
+	cmd := exec.CommandContext(ctx, p.manifest.CommandPath(), p.manifest.Args...)
+	cmd.Dir = p.manifest.Dir()
+	cmd.Env = append(os.Environ(),
+		"GOMODORO_PLUGIN="+p.Name(),
+		"GOMODORO_PLUGIN_PROTOCOL="+strconv.Itoa(ProtocolVersion),
+	)
+	cmd.WaitDelay = ShutdownTimeout
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	proc := &process{
+		cmd:      cmd,
+		out:      make(chan Message, outgoingBuffer),
+		hello:    make(chan Message, 1),
+		quit:     make(chan struct{}),
+		readDone: make(chan struct{}),
+		done:     make(chan struct{}),
+		stderr:   &tailBuffer{limit: maxStderr},
+		pending:  make(map[int64]chan Message),
+	}
+	cmd.Stderr = proc.stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", p.manifest.CommandPath(), err)
+	}
+
+	go proc.write(stdin)
+	go p.read(proc, stdout)
+	return proc, nil
+}
+
+// handshake envía el "hello" de la CLI y valida la respuesta del plugin
+func (p *Plugin) handshake(proc *process) error {
+	proc.out <- Message{Type: TypeHello, Protocol: ProtocolVersion, Name: "gomodoro"}
+
+	var hello Message
+	select {
+	case hello = <-proc.hello:
+	case <-proc.readDone:
+		if err := proc.wait(); err != nil {
+			return fmt.Errorf("exited before the handshake: %w", err)
+		}
+		return fmt.Errorf("exited before the handshake")
+	case <-time.After(handshakeTimeout):
+		return fmt.Errorf("no hello within %s", handshakeTimeout)
+	}
+
+	if hello.Protocol < MinProtocolVersion || hello.Protocol > ProtocolVersion {
+		return &VersionError{Version: hello.Protocol}
+	}
+	proc.events = make(map[events.EventType]bool, len(hello.Events))
+	for _, eventType := range hello.Events {
+		if !events.IsKnown(eventType) {
+			return fmt.Errorf("unknown event type %q in hello", eventType)
+		}
+		proc.events[eventType] = true
+	}
+	for _, command := range hello.Commands {
+		if err := ValidateName(command.Name); err != nil {
+			return fmt.Errorf("command in hello: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hello = hello
+	p.proc = proc
+	p.state = StateRunning
+	p.lastErr = ""
+	return nil
+}
+
+// read procesa los mensajes del plugin, uno por línea, hasta que cierra su salida
+func (p *Plugin) read(proc *process, stdout io.Reader) {
+	defer close(proc.readDone)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	greeted := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var msg Message
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			p.manager.report(CodeProtocol, fmt.Sprintf("plugin %s sent an invalid message: %v", p.Name(), err))
+			continue
+		}
+		if !greeted {
+			if msg.Type != TypeHello {
+				p.manager.report(CodeProtocol, fmt.Sprintf("plugin %s sent %q before hello", p.Name(), msg.Type))
+				continue
+			}
+			greeted = true
+			proc.hello <- msg
+			continue
+		}
+		p.handleMessage(proc, msg)
+	}
+
+	if err := scanner.Err(); err != nil {
+		// Sin nadie leyendo su salida el plugin se bloquearía: se detiene
+		p.manager.report(CodeProtocol, fmt.Sprintf("plugin %s: %v", p.Name(), err))
+		proc.cmd.Process.Kill()
+	}
+}
+
+// handleMessage atiende un mensaje del plugin tras el handshake
+func (p *Plugin) handleMessage(proc *process, msg Message) {
+	switch msg.Type {
+	case TypeResult:
+		p.mu.Lock()
+		reply, ok := proc.pending[msg.ID]
+		delete(proc.pending, msg.ID)
+		p.mu.Unlock()
+		if ok {
+			reply <- msg
+		}
+
+	case TypeAction:
+		err := p.manager.perform(p, msg)
+		if msg.ID != 0 {
+			result := Message{Type: TypeResult, ID: msg.ID}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			proc.send(result)
+		} else if err != nil {
+			p.manager.report(CodeProtocol, fmt.Sprintf("plugin %s: %s failed: %v", p.Name(), msg.Action, err))
+		}
+
+	case TypeHello:
+		// Un segundo hello no cambia nada
+
+	default:
+		p.manager.report(CodeProtocol, fmt.Sprintf("plugin %s sent unknown message type %q", p.Name(), msg.Type))
+	}
+}
+
+// Invoke ejecuta uno de los comandos del plugin y retorna su salida
+func (p *Plugin) Invoke(command string, args []string) (string, error) {
+	p.mu.Lock()
+	proc := p.proc
+	if proc == nil {
+		state := p.state
+		p.mu.Unlock()
+		return "", fmt.Errorf("plugin %s is not running (%s)", p.Name(), state)
+	}
+	declared := false
+	for _, spec := range p.hello.Commands {
+		declared = declared || spec.Name == command
+	}
+	if !declared {
+		p.mu.Unlock()
+		return "", fmt.Errorf("plugin %s no longer provides command %s", p.Name(), command)
+	}
+	p.nextID++
+	id := p.nextID
+	reply := make(chan Message, 1)
+	proc.pending[id] = reply
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(proc.pending, id)
+		p.mu.Unlock()
+	}()
+
+	timeout := time.NewTimer(invokeTimeout)
+	defer timeout.Stop()
+
+	select {
+	case proc.out <- Message{Type: TypeInvoke, ID: id, Command: command, Args: args}:
+	case <-proc.done:
+		return "", fmt.Errorf("plugin %s exited", p.Name())
+	case <-timeout.C:
+		return "", fmt.Errorf("plugin %s is not reading its input", p.Name())
+	}
+
+	select {
+	case result := <-reply:
+		if result.Error != "" {
+			return result.Output, errors.New(result.Error)
+		}
+		return result.Output, nil
+	case <-proc.done:
+		return "", fmt.Errorf("plugin %s exited while running %s", p.Name(), command)
+	case <-timeout.C:
+		return "", fmt.Errorf("plugin %s did not answer %s within %s", p.Name(), command, invokeTimeout)
+	}
+}
+
+// deliver envía un evento al plugin si le interesa, sin bloquear al engine
+func (p *Plugin) deliver(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proc := p.proc
+	if proc == nil || (len(proc.events) > 0 && !proc.events[event.Type]) {
+		return
+	}
+	select {
+	case proc.out <- Message{Type: TypeEvent, Event: &event}:
+	default:
+		p.dropped++
+	}
+}
+
+// stop pide al plugin que termine y lo detiene si no lo hace a tiempo
+func (p *Plugin) stop() {
+	p.mu.Lock()
+	p.stopping = true
+	proc := p.proc
+	p.mu.Unlock()
+	if proc == nil {
+		return
+	}
+
+	proc.send(Message{Type: TypeShutdown})
+	proc.quitOnce.Do(func() { close(proc.quit) })
+	select {
+	case <-proc.done:
+	case <-time.After(ShutdownTimeout):
+		proc.cmd.Process.Kill()
+		<-proc.done
+	}
+}
+
+// send encola un mensaje sin bloquear; si la cola está llena se descarta
+func (proc *process) send(msg Message) {
+	select {
+	case proc.out <- msg:
+	default:
+	}
+}
+
+// write envía los mensajes encolados al plugin hasta que se pide cerrar su entrada
+func (proc *process) write(stdin io.WriteCloser) {
+	defer stdin.Close()
+
+	encoder := json.NewEncoder(stdin)
+	for {
+		select {
+		case msg := <-proc.out:
+			if err := encoder.Encode(msg); err != nil {
+				return
+			}
+		case <-proc.quit:
+			// Enviar lo pendiente (el "shutdown") antes de cerrar
+			for {
+				select {
+				case msg := <-proc.out:
+					if err := encoder.Encode(msg); err != nil {
+						return
+					}
+				default:
+					return
+				}
+			}
+		case <-proc.done:
+			return
+		}
+	}
+}
+
+// wait espera a que el proceso termine y retorna su error con la salida de error
+func (proc *process) wait() error {
+	proc.waitOnce.Do(func() {
+		<-proc.readDone
+		if err := proc.cmd.Wait(); err != nil {
+			proc.waitErr = proc.exitError(err.Error())
+		}
+		close(proc.done)
+	})
+	return proc.waitErr
+}
+
+// exitError añade al motivo la última línea de la salida de error del plugin
+func (proc *process) exitError(reason string) error {
+	if line := proc.stderr.LastLine(); line != "" {
+		return fmt.Errorf("%s: %s", reason, line)
+	}
+	return errors.New(reason)
+}
+
+// tailBuffer guarda los últimos limit bytes escritos
+type tailBuffer struct {
+	mu    sync.Mutex
+	data  []byte
+	limit int
+}
+
+// Write implementa io.Writer
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+	return len(p), nil
+}
+
+// LastLine retorna la última línea no vacía
+func (b *tailBuffer) LastLine() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := strings.Split(strings.TrimSpace(string(b.data)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
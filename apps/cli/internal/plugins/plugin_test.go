@@ -0,0 +1,342 @@
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// counterDir es el directorio con el plugin de ejemplo compilado y su manifiesto
+var counterDir string
+
+// TestMain compila el plugin de ejemplo. Si la CLI lanza el propio binario de test como
+// plugin (GOMODORO_PLUGIN definido), actúa como un plugin falso según su nombre.
+func TestMain(m *testing.M) {
+	if name := os.Getenv("GOMODORO_PLUGIN"); name != "" {
+		runFakePlugin(name)
+		os.Exit(0)
+	}
+
+	dir, err := os.MkdirTemp("", "gomodoro-plugins")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := func() int {
+		defer os.RemoveAll(dir)
+		if err := buildCounter(dir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		counterDir = dir
+		minBackoff, maxBackoff = 10*time.Millisecond, 20*time.Millisecond
+		return m.Run()
+	}()
+	os.Exit(code)
+}
+
+// buildCounter compila examples/plugins/counter y copia su manifiesto en dir
+func buildCounter(dir string) error {
+	source := filepath.Join("..", "..", "examples", "plugins", "counter")
+	binary := "counter"
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, binary), ".")
+	cmd.Dir = source
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to build counter plugin: %v\n%s", err, output)
+	}
+
+	manifest, err := os.ReadFile(filepath.Join(source, "plugin.yaml"))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "plugin.yaml"), manifest, 0644)
+}
+
+// runFakePlugin implementa los plugins que no se comportan como deberían
+func runFakePlugin(name string) {
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+
+	switch name {
+	case "broken":
+		fmt.Fprintln(os.Stderr, "broken on purpose")
+		os.Exit(3)
+	case "old-protocol":
+		scanner.Scan()
+		encoder.Encode(Message{Type: TypeHello, Protocol: ProtocolVersion + 1})
+	case "silent":
+		scanner.Scan()
+		encoder.Encode(Message{Type: TypeHello, Protocol: ProtocolVersion,
+			Commands: []CommandSpec{{Name: "wait"}}})
+	}
+	// Lee hasta que la CLI cierre su entrada, sin contestar a nada
+	for scanner.Scan() {
+	}
+}
+
+// testManager es un gestor con su engine que guarda los errores publicados y los avisos
+type testManager struct {
+	*Manager
+	mu      sync.Mutex
+	errors  []events.ErrorEventData
+	notices []string
+}
+
+func newTestManager(t *testing.T, manifests ...*Manifest) *testManager {
+	t.Helper()
+	eng := engine.NewEngine(config.DefaultConfig())
+	tm := &testManager{}
+	tm.Manager = NewManager(eng, manifests, func(text string) {
+		tm.mu.Lock()
+		tm.notices = append(tm.notices, text)
+		tm.mu.Unlock()
+	})
+	eng.GetEventBus().SubscribeFunc(events.ErrorOccurred, func(event events.Event) {
+		if data, ok := event.Data.(events.ErrorEventData); ok {
+			tm.mu.Lock()
+			tm.errors = append(tm.errors, data)
+			tm.mu.Unlock()
+		}
+	})
+	tm.Subscribe(eng.GetEventBus())
+	tm.Start(context.Background())
+	t.Cleanup(tm.Stop)
+	return tm
+}
+
+// hasError indica si se publicó un error con el código indicado que contiene el texto
+func (tm *testManager) hasError(code, text string) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	for _, data := range tm.errors {
+		if data.Code == code && strings.Contains(data.Message, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNotice indica si algún aviso contiene el texto indicado
+func (tm *testManager) hasNotice(text string) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	for _, notice := range tm.notices {
+		if strings.Contains(notice, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// counterManifest lee el manifiesto del plugin de ejemplo compilado
+func counterManifest(t *testing.T) *Manifest {
+	t.Helper()
+	manifest, err := LoadManifest(filepath.Join(counterDir, "plugin.yaml"))
+	if err != nil {
+		t.Fatalf("load counter manifest: %v", err)
+	}
+	return manifest
+}
+
+// fakeManifest lanza el binario de test como el plugin falso indicado
+func fakeManifest(t *testing.T, name string) *Manifest {
+	t.Helper()
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Manifest{
+		Name:        name,
+		Command:     executable,
+		Protocol:    ProtocolVersion,
+		Restart:     RestartOnFailure,
+		MaxRestarts: DefaultMaxRestarts,
+		Path:        filepath.Join(t.TempDir(), "plugin.json"),
+	}
+}
+
+// waitFor espera a que se cumpla la condición
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCounterHandshake(t *testing.T) {
+	tm := newTestManager(t, counterManifest(t))
+	plugin := tm.Plugins()[0]
+
+	status := plugin.Status()
+	if status.State != StateRunning {
+		t.Fatalf("expected running after Start, got %s (%s)", status.State, status.Error)
+	}
+	if status.Version != "1.0.0" {
+		t.Errorf("expected version from hello, got %q", status.Version)
+	}
+
+	var names []string
+	for _, command := range tm.Commands() {
+		names = append(names, command.Name)
+	}
+	if got := strings.Join(names, ","); got != "count,crash,remind" {
+		t.Errorf("unexpected commands: %s", got)
+	}
+
+	output, err := plugin.Invoke("count", nil)
+	if err != nil || !strings.Contains(output, "0 completados") {
+		t.Errorf("count: %q, %v", output, err)
+	}
+	if _, err := plugin.Invoke("remind", nil); err == nil {
+		t.Errorf("remind without text should fail")
+	}
+	if _, err := plugin.Invoke("remind", []string{"agua"}); err != nil {
+		t.Errorf("remind: %v", err)
+	}
+	waitFor(t, "remind notice", func() bool { return tm.hasNotice("⏰ agua") })
+
+	if _, err := plugin.Invoke("missing", nil); err == nil {
+		t.Errorf("undeclared command should fail")
+	}
+}
+
+func TestCounterGracefulStop(t *testing.T) {
+	tm := newTestManager(t, counterManifest(t))
+	plugin := tm.Plugins()[0]
+
+	started := time.Now()
+	tm.Stop()
+	if elapsed := time.Since(started); elapsed >= ShutdownTimeout {
+		t.Errorf("plugin did not exit on shutdown (took %s)", elapsed)
+	}
+	if state := plugin.Status().State; state != StateStopped {
+		t.Errorf("expected stopped, got %s", state)
+	}
+	if _, err := plugin.Invoke("count", nil); err == nil {
+		t.Errorf("invoke after stop should fail")
+	}
+}
+
+func TestCounterCrashRestarts(t *testing.T) {
+	tm := newTestManager(t, counterManifest(t))
+	plugin := tm.Plugins()[0]
+
+	if _, err := plugin.Invoke("crash", nil); err == nil || !strings.Contains(err.Error(), "exited") {
+		t.Fatalf("crash should report the plugin exited, got %v", err)
+	}
+
+	waitFor(t, "restart", func() bool {
+		status := plugin.Status()
+		return status.State == StateRunning && status.Restarts == 1
+	})
+	// El aviso del reinicio incluye la última línea de la salida de error
+	waitFor(t, "failure report", func() bool { return tm.hasError(CodeFailed, "crash requested") })
+
+	if _, err := plugin.Invoke("count", nil); err != nil {
+		t.Errorf("count after restart: %v", err)
+	}
+}
+
+func TestCounterMaxRestarts(t *testing.T) {
+	manifest := counterManifest(t)
+	manifest.MaxRestarts = 1
+	tm := newTestManager(t, manifest)
+	plugin := tm.Plugins()[0]
+
+	plugin.Invoke("crash", nil)
+	waitFor(t, "restart", func() bool { return plugin.Status().State == StateRunning })
+	plugin.Invoke("crash", nil)
+
+	waitFor(t, "disabled", func() bool { return plugin.Status().State == StateFailed })
+	waitFor(t, "disabled report", func() bool { return tm.hasError(CodeDisabled, "disabled") })
+	if restarts := plugin.Status().Restarts; restarts != 1 {
+		t.Errorf("expected 1 restart before disabling, got %d", restarts)
+	}
+}
+
+func TestRestartBackoff(t *testing.T) {
+	manifest := fakeManifest(t, "broken")
+	manifest.MaxRestarts = 3
+	started := time.Now()
+	tm := newTestManager(t, manifest)
+	plugin := tm.Plugins()[0]
+
+	waitFor(t, "disabled", func() bool { return plugin.Status().State == StateFailed })
+	status := plugin.Status()
+	if status.Restarts != 3 {
+		t.Errorf("expected 3 restarts, got %d", status.Restarts)
+	}
+	if !strings.Contains(status.Error, "broken on purpose") {
+		t.Errorf("expected the stderr line in the error, got %q", status.Error)
+	}
+	// Esperas de 10ms, 20ms y 20ms (el máximo)
+	if elapsed := time.Since(started); elapsed < 50*time.Millisecond {
+		t.Errorf("restarts did not back off (took %s)", elapsed)
+	}
+}
+
+func TestVersionErrorDisables(t *testing.T) {
+	tm := newTestManager(t, fakeManifest(t, "old-protocol"))
+	plugin := tm.Plugins()[0]
+
+	waitFor(t, "disabled", func() bool { return plugin.Status().State == StateFailed })
+	status := plugin.Status()
+	if !strings.Contains(status.Error, "unsupported protocol version") {
+		t.Errorf("expected a version error, got %q", status.Error)
+	}
+	if status.Restarts != 0 {
+		t.Errorf("a version error must not restart the plugin, got %d restarts", status.Restarts)
+	}
+	waitFor(t, "disabled report", func() bool { return tm.hasError(CodeDisabled, "disabled") })
+}
+
+func TestInvokeTimeout(t *testing.T) {
+	previous := invokeTimeout
+	invokeTimeout = 100 * time.Millisecond
+	defer func() { invokeTimeout = previous }()
+
+	tm := newTestManager(t, fakeManifest(t, "silent"))
+	plugin := tm.Plugins()[0]
+
+	_, err := plugin.Invoke("wait", nil)
+	if err == nil || !strings.Contains(err.Error(), "did not answer") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if state := plugin.Status().State; state != StateRunning {
+		t.Errorf("a slow command must not stop the plugin, got %s", state)
+	}
+}
+
+func TestDisabledManifest(t *testing.T) {
+	manifest := counterManifest(t)
+	manifest.Disabled = true
+	tm := newTestManager(t, manifest)
+
+	if state := tm.Plugins()[0].Status().State; state != StateDisabled {
+		t.Errorf("expected disabled, got %s", state)
+	}
+	if commands := tm.Commands(); len(commands) != 0 {
+		t.Errorf("a disabled plugin must not add commands, got %v", commands)
+	}
+}
@@ -0,0 +1,78 @@
+package plugins
+
+import (
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// El protocolo es JSON delimitado por líneas sobre la entrada y la salida estándar del plugin.
+// Al arrancar, la CLI envía "hello" con la versión del protocolo y el plugin contesta con su
+// propio "hello" (nombre, versión, comandos y eventos que le interesan). A partir de ahí la CLI
+// envía los eventos del engine y las invocaciones de sus comandos, y el plugin puede enviar
+// acciones (pausar, asignar la tarea, mostrar un mensaje...). La salida de error del plugin no
+// forma parte del protocolo y solo se usa para informar de sus fallos.
+
+// ProtocolVersion es la versión del protocolo que habla la CLI
+const ProtocolVersion = 1
+
+// MinProtocolVersion es la versión más antigua que la CLI sigue aceptando
+const MinProtocolVersion = 1
+
+// Tipos de mensaje
+const (
+	TypeHello    = "hello"    // Ambos sentidos: inicio de la conexión
+	TypeEvent    = "event"    // CLI → plugin: evento del engine
+	TypeInvoke   = "invoke"   // CLI → plugin: ejecutar uno de sus comandos
+	TypeShutdown = "shutdown" // CLI → plugin: la CLI se cierra
+	TypeAction   = "action"   // Plugin → CLI: acción sobre el timer o la interfaz
+	TypeResult   = "result"   // Ambos sentidos: respuesta a un "invoke" o a una "action" con ID
+)
+
+// Acciones que puede pedir un plugin
+const (
+	ActionStart   = "start"   // Empezar la primera sesión
+	ActionPause   = "pause"   // Pausar el timer
+	ActionResume  = "resume"  // Reanudar el timer
+	ActionSkip    = "skip"    // Saltar la sesión actual
+	ActionExtend  = "extend"  // Alargar la sesión actual (Duration)
+	ActionTask    = "task"    // Asignar la tarea (Labels; sin Labels se elimina)
+	ActionMessage = "message" // Mostrar un aviso (Text)
+)
+
+// Message es un mensaje del protocolo en cualquiera de los dos sentidos. Solo se rellenan
+// los campos del tipo de mensaje.
+type Message struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id,omitempty"` // Relaciona "invoke" y "action" con su "result"
+
+	// hello
+	Protocol int                `json:"protocol,omitempty"`
+	Name     string             `json:"name,omitempty"`
+	Version  string             `json:"version,omitempty"`
+	Commands []CommandSpec      `json:"commands,omitempty"` // Solo del plugin
+	Events   []events.EventType `json:"events,omitempty"`   // Solo del plugin; vacío = todos
+
+	// event
+	Event *events.Event `json:"event,omitempty"`
+
+	// invoke
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+
+	// action
+	Action   string        `json:"action,omitempty"`
+	Duration string        `json:"duration,omitempty"` // Ej: "5m", "90s"
+	Labels   *stats.Labels `json:"labels,omitempty"`
+	Text     string        `json:"text,omitempty"`
+
+	// result
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CommandSpec es un comando que un plugin añade a la CLI
+type CommandSpec struct {
+	Name  string `json:"name"`
+	Usage string `json:"usage,omitempty"` // Nombre de los argumentos en la ayuda (ej: "minutos")
+	Help  string `json:"help,omitempty"`
+}
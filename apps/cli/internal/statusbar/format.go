@@ -0,0 +1,205 @@
+package statusbar
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kubaliski/pomodoro-cli/internal/daemon"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// View son los datos del estado que reciben los formatos y las plantillas de usuario
+type View struct {
+	Offline   bool   // El daemon no está en marcha
+	Started   bool   // Ya empezó la primera sesión
+	Paused    bool   // El timer está pausado
+	Kind      string // work, short_break o long_break
+	Session   string // Nombre de la sesión (TRABAJO, DESCANSO...)
+	Class     string // idle, paused, work, short_break, long_break u offline
+	Icon      string
+	Remaining string // MM:SS
+	Total     string // MM:SS
+	Seconds   int    // Segundos restantes
+	Percent   int    // Progreso de 0 a 100
+	Pomodoro  int    // Número del pomodoro en curso o del siguiente
+	Completed int
+	Task      string // Tarea, proyecto y tags ("" si no hay)
+	Rule      string // Regla de horario aplicada ("" si ninguna)
+}
+
+// Formatter convierte el estado en la línea que muestra la barra de estado
+type Formatter func(View) (string, error)
+
+// builtins son los formatos incluidos, por nombre
+var builtins = map[string]Formatter{
+	"plain":    formatPlain,
+	"i3blocks": formatPlain,
+	"polybar":  formatPlain,
+	"tmux":     formatTmux,
+	"waybar":   formatWaybar,
+}
+
+// NewView construye los datos del formato a partir del estado del daemon
+func NewView(status daemon.Status) View {
+	view := View{
+		Started:   status.Started,
+		Paused:    status.Paused,
+		Kind:      string(status.Session),
+		Session:   ui.SessionLabel(status.Session),
+		Remaining: ui.FormatDuration(status.Remaining),
+		Total:     ui.FormatDuration(status.Total),
+		Seconds:   int(status.Remaining.Seconds()),
+		Percent:   int(status.Progress * 100),
+		Pomodoro:  status.Pomodoro,
+		Completed: status.Completed,
+		Task:      status.Labels.Summary(),
+		Rule:      status.Rule,
+	}
+
+	switch {
+	case !status.Started || status.State == engine.StateIdle:
+		view.Class, view.Icon = "idle", "⏳"
+	case status.Paused:
+		view.Class, view.Icon = "paused", "⏸️"
+	case status.Session == events.KindWork:
+		view.Class, view.Icon = view.Kind, "🍅"
+	default:
+		view.Class, view.Icon = view.Kind, "☕"
+	}
+	return view
+}
+
+// OfflineView es el estado que se muestra cuando el daemon no responde
+func OfflineView() View {
+	return View{Offline: true, Class: "offline"}
+}
+
+// Builtin retorna un formato incluido por su nombre
+func Builtin(name string) (Formatter, error) {
+	formatter, ok := builtins[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return formatter, nil
+}
+
+// Names retorna los nombres de los formatos incluidos ordenados
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Template crea un formato a partir de una plantilla de Go con los campos de View
+// (ej: "{{.Icon}} {{.Remaining}}{{with .Task}} {{.}}{{end}}")
+func Template(text string) (Formatter, error) {
+	tmpl, err := template.New("status").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return func(view View) (string, error) {
+		var line strings.Builder
+		if err := tmpl.Execute(&line, view); err != nil {
+			return "", fmt.Errorf("failed to render template: %w", err)
+		}
+		return line.String(), nil
+	}, nil
+}
+
+// formatPlain es texto sin códigos de color para i3blocks y polybar
+func formatPlain(view View) (string, error) {
+	if view.Offline {
+		return "", nil
+	}
+	return summary(view), nil
+}
+
+// formatTmux usa los estilos de tmux (#[fg=...]) para colorear la sesión
+func formatTmux(view View) (string, error) {
+	if view.Offline {
+		return "", nil
+	}
+
+	color := "default"
+	switch view.Class {
+	case "paused":
+		color = "yellow"
+	case "work":
+		color = "red"
+	case "short_break":
+		color = "green"
+	case "long_break":
+		color = "blue"
+	}
+
+	line := fmt.Sprintf("#[fg=%s]%s %s#[default]", color, view.Icon, view.Remaining)
+	if view.Task != "" {
+		// "#" inicia un estilo en tmux; los tags se escapan duplicándolo
+		line += " " + strings.ReplaceAll(view.Task, "#", "##")
+	}
+	return line, nil
+}
+
+// waybarOutput es el formato JSON de los módulos personalizados de waybar
+type waybarOutput struct {
+	Text       string `json:"text"`
+	Tooltip    string `json:"tooltip"`
+	Class      string `json:"class"`
+	Percentage int    `json:"percentage"`
+}
+
+// formatWaybar retorna una línea JSON con texto, tooltip, clase CSS y porcentaje
+func formatWaybar(view View) (string, error) {
+	output := waybarOutput{Class: view.Class, Percentage: view.Percent}
+	if view.Offline {
+		output.Tooltip = "gomodoro daemon no está en marcha"
+	} else {
+		output.Text = fmt.Sprintf("%s %s", view.Icon, view.Remaining)
+		output.Tooltip = tooltip(view)
+	}
+
+	line, err := json.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode waybar output: %w", err)
+	}
+	return string(line), nil
+}
+
+// summary es la línea corta común: icono, tiempo restante y tarea
+func summary(view View) string {
+	line := fmt.Sprintf("%s %s", view.Icon, view.Remaining)
+	if view.Task != "" {
+		line += " " + view.Task
+	}
+	return line
+}
+
+// tooltip describe la sesión en varias líneas
+func tooltip(view View) string {
+	lines := make([]string, 0, 4)
+	switch {
+	case !view.Started:
+		lines = append(lines, fmt.Sprintf("Listo para el Pomodoro #%d (%s)", view.Pomodoro, view.Total))
+	case view.Kind == string(events.KindWork):
+		lines = append(lines, fmt.Sprintf("%s · Pomodoro #%d · %s / %s", view.Session, view.Pomodoro, view.Remaining, view.Total))
+	default:
+		lines = append(lines, fmt.Sprintf("%s · %s / %s", view.Session, view.Remaining, view.Total))
+	}
+	if view.Paused {
+		lines = append(lines, "PAUSADO")
+	}
+	if view.Task != "" {
+		lines = append(lines, "Tarea: "+view.Task)
+	}
+	lines = append(lines, fmt.Sprintf("Completados: %d", view.Completed))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,318 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubaliski/pomodoro-cli/internal/notifications"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// maxNotices es el número de notificaciones recientes que se conservan
+const maxNotices = 20
+
+// App es la interfaz a pantalla completa: dibuja el estado del engine y responde a teclas sueltas
+type App struct {
+	engine              engine.EngineInterface
+	notificationManager *notifications.Manager
+	terminal            *Terminal
+
+	mu            sync.Mutex
+	historyStore  stats.Store
+	historyUserID string
+	historyToday  stats.PeriodAggregate // Totales del historial de hoy anteriores a esta ejecución
+	historyDay    time.Time             // Día al que corresponde historyToday
+	theme         int                   // Índice en ui.GetAvailableThemes()
+	started       bool
+	timer         events.TimerEventData
+	rule          string
+	notices       []notice
+	help          bool
+	lastAlert     int // Último minuto restante avisado en la sesión (-1 = ninguno)
+
+	redraw chan struct{}
+}
+
+// NewApp crea la interfaz a pantalla completa para el engine
+func NewApp(eng engine.EngineInterface) *App {
+	app := &App{
+		engine:    eng,
+		lastAlert: -1,
+		redraw:    make(chan struct{}, 1),
+	}
+
+	// Las notificaciones visuales van al panel de notificaciones; el notificador de sonido
+	// no escribe en la pantalla salvo el timbre
+	app.notificationManager = notifications.NewManager(notifications.DefaultConfig())
+	app.notificationManager.RegisterNotifier(noticeNotifier{app: app})
+	soundNotifier := notifications.NewSoundNotifier()
+	soundNotifier.SetOutput(bellWriter{app: app})
+	if err := app.notificationManager.RegisterNotifier(soundNotifier); err != nil {
+		app.Notice(fmt.Sprintf("Sonido no disponible: %v", err))
+	}
+
+	return app
+}
+
+// GetNotificationManager retorna el gestor de notificaciones de la interfaz
+func (a *App) GetNotificationManager() *notifications.Manager {
+	return a.notificationManager
+}
+
+// SetHistoryStore establece el historial persistente con el que se calculan los totales de hoy
+func (a *App) SetHistoryStore(store stats.Store, userID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.historyStore = store
+	a.historyUserID = userID
+}
+
+// Notice añade un mensaje al panel de notificaciones (seguro desde cualquier goroutine)
+func (a *App) Notice(text string) {
+	a.mu.Lock()
+	a.notices = append([]notice{{at: time.Now(), text: strings.TrimSpace(text)}}, a.notices...)
+	if len(a.notices) > maxNotices {
+		a.notices = a.notices[:maxNotices]
+	}
+	a.mu.Unlock()
+	a.requestRedraw()
+}
+
+// Run abre la pantalla completa, inicia el engine y procesa teclas hasta que el usuario sale
+func (a *App) Run(ctx context.Context) error {
+	terminal, err := OpenTerminal()
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.terminal = terminal
+	a.mu.Unlock()
+	defer terminal.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	a.loadHistoryToday()
+	a.subscribe(a.engine.GetEventBus())
+	if err := a.engine.Start(ctx); err != nil {
+		return fmt.Errorf("error starting engine: %w", err)
+	}
+	defer a.engine.Stop()
+
+	keys := make(chan Key, 16)
+	go readKeys(terminal.in, keys)
+
+	resized := make(chan struct{}, 1)
+	go watchResize(ctx, resized)
+
+	a.draw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			if quit := a.handleKey(key); quit {
+				return nil
+			}
+			a.draw()
+		case <-resized:
+			terminal.Clear()
+			a.draw()
+		case <-a.redraw:
+			a.draw()
+		}
+	}
+}
+
+// handleKey ejecuta la acción de una tecla; retorna true si hay que salir
+func (a *App) handleKey(key Key) bool {
+	a.mu.Lock()
+	started := a.started
+	paused := strings.EqualFold(a.timer.Status, "PAUSED")
+	a.mu.Unlock()
+
+	var err error
+	switch key {
+	case 'q', KeyCtrlC:
+		return true
+	case 'c', KeyEnter:
+		if !started && a.engine.GetState() == engine.StateIdle {
+			err = a.engine.StartFirstSession()
+		}
+	case 'p', ' ':
+		switch {
+		case !started:
+			err = fmt.Errorf("aún no hay sesión iniciada, pulsa c para empezar")
+		case paused:
+			err = a.engine.Resume()
+		default:
+			err = a.engine.Pause()
+		}
+	case 'r':
+		if started {
+			err = a.engine.Resume()
+		}
+	case 's':
+		if started {
+			err = a.engine.Skip()
+		}
+	case 't':
+		a.mu.Lock()
+		a.theme = (a.theme + 1) % len(ui.GetAvailableThemes())
+		a.mu.Unlock()
+	case 'm':
+		config := a.notificationManager.GetConfig()
+		config.SoundEnabled = !config.SoundEnabled
+		err = a.notificationManager.UpdateConfig(config)
+		if err == nil && config.SoundEnabled {
+			a.Notice("🔊 Sonido activado")
+		} else if err == nil {
+			a.Notice("🔇 Sonido desactivado")
+		}
+	case 'h', '?':
+		a.mu.Lock()
+		a.help = !a.help
+		a.mu.Unlock()
+	case KeyEscape:
+		a.mu.Lock()
+		a.help = false
+		a.mu.Unlock()
+	}
+
+	if err != nil {
+		a.Notice("❌ " + err.Error())
+	}
+	return false
+}
+
+// draw dibuja la pantalla con el estado actual
+func (a *App) draw() {
+	a.mu.Lock()
+	terminal := a.terminal
+	if terminal == nil {
+		a.mu.Unlock()
+		return
+	}
+
+	width, height := terminal.Size()
+	snapshot := a.engine.GetStats().GetSnapshot()
+	idle, _ := a.engine.GetConfig().Resolve(time.Now())
+
+	v := view{
+		width:   width,
+		height:  height,
+		theme:   ui.GetAvailableThemes()[a.theme],
+		started: a.started,
+		idle:    idle.WorkDuration,
+		timer:   a.timer,
+		number:  a.engine.GetPomodoroCount() + 1,
+		labels:  a.engine.GetLabels(),
+		rule:    a.rule,
+		today:   a.todayLocked(snapshot),
+		notices: append([]notice(nil), a.notices...),
+		muted:   !a.notificationManager.GetConfig().SoundEnabled,
+		help:    a.help,
+	}
+	a.mu.Unlock()
+
+	terminal.Draw(render(v))
+}
+
+// requestRedraw pide un nuevo dibujo sin bloquear (los eventos llegan desde otras goroutines)
+func (a *App) requestRedraw() {
+	select {
+	case a.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// loadHistoryToday guarda los totales de hoy del historial anteriores a esta ejecución.
+// Las sesiones nuevas se suman desde el engine para no depender de cuándo las guarda el historial.
+func (a *App) loadHistoryToday() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	a.historyDay, _ = stats.PeriodBounds(stats.PeriodDay, now, time.Local)
+	if a.historyStore == nil {
+		return
+	}
+	report, err := stats.GenerateReport(a.historyStore, a.historyUserID, stats.PeriodDay, now, time.Local)
+	if err != nil {
+		a.notices = append(a.notices, notice{at: now, text: fmt.Sprintf("⚠️ No se pudo leer el historial: %v", err)})
+		return
+	}
+	a.historyToday = report.Total
+}
+
+// todayLocked combina el historial de hoy con las sesiones de esta ejecución (requiere a.mu)
+func (a *App) todayLocked(snapshot stats.StatsSnapshot) todayStats {
+	now := time.Now()
+	day, _ := stats.PeriodBounds(stats.PeriodDay, now, time.Local)
+
+	session := stats.BuildReport(a.engine.GetStats().GetCompletedSessions(), stats.PeriodDay, now, time.Local).Total
+	history := a.historyToday
+	if !day.Equal(a.historyDay) {
+		history = stats.PeriodAggregate{} // Pasó la medianoche desde que se abrió
+	}
+
+	today := todayStats{
+		Completed: history.PomodorosCompleted + session.PomodorosCompleted,
+		Skipped:   history.PomodorosSkipped + session.PomodorosSkipped,
+		Goal:      snapshot.DayStreak.MinPomodoros,
+		FocusTime: history.FocusTime + session.FocusTime,
+		DayStreak: snapshot.DayStreak.Current,
+	}
+
+	// Eficiencia media ponderada por el número de pomodoros de cada parte
+	historyCount := history.PomodorosCompleted + history.PomodorosSkipped
+	sessionCount := session.PomodorosCompleted + session.PomodorosSkipped
+	if total := historyCount + sessionCount; total > 0 {
+		today.Efficiency = (history.Efficiency*float64(historyCount) + session.Efficiency*float64(sessionCount)) / float64(total)
+	}
+	return today
+}
+
+// noticeNotifier muestra las notificaciones visuales en el panel de notificaciones
+type noticeNotifier struct {
+	app *App
+}
+
+func (n noticeNotifier) Notify(request notifications.NotificationRequest) notifications.NotificationResponse {
+	n.app.Notice(request.Title + " · " + request.Message)
+	return notifications.NotificationResponse{Success: true, Type: notifications.TypeVisual}
+}
+
+func (n noticeNotifier) IsAvailable() bool                             { return true }
+func (n noticeNotifier) GetType() notifications.NotificationType       { return notifications.TypeVisual }
+func (n noticeNotifier) Configure(config map[string]interface{}) error { return nil }
+
+// bellWriter recibe la salida del notificador de sonido: descarta los mensajes de
+// depuración (romperían el dibujo de la pantalla) y deja pasar el timbre de la terminal
+type bellWriter struct {
+	app *App
+}
+
+func (w bellWriter) Write(p []byte) (int, error) {
+	if bytes.IndexByte(p, '\a') < 0 {
+		return len(p), nil
+	}
+
+	w.app.mu.Lock()
+	terminal := w.app.terminal
+	w.app.mu.Unlock()
+	if terminal != nil {
+		terminal.Bell()
+	}
+	return len(p), nil
+}
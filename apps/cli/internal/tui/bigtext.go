@@ -0,0 +1,53 @@
+package tui
+
+import "strings"
+
+// bigGlyphHeight es el alto en filas de los dígitos grandes
+const bigGlyphHeight = 5
+
+// bigGlyphs son los caracteres del reloj grande, de 5 filas de alto
+var bigGlyphs = map[rune][bigGlyphHeight]string{
+	'0': {"█████", "█   █", "█   █", "█   █", "█████"},
+	'1': {"   █ ", "  ██ ", "   █ ", "   █ ", "  ███"},
+	'2': {"█████", "    █", "█████", "█    ", "█████"},
+	'3': {"█████", "    █", " ████", "    █", "█████"},
+	'4': {"█   █", "█   █", "█████", "    █", "    █"},
+	'5': {"█████", "█    ", "█████", "    █", "█████"},
+	'6': {"█████", "█    ", "█████", "█   █", "█████"},
+	'7': {"█████", "    █", "   █ ", "  █  ", "  █  "},
+	'8': {"█████", "█   █", "█████", "█   █", "█████"},
+	'9': {"█████", "█   █", "█████", "    █", "█████"},
+	':': {"   ", " █ ", "   ", " █ ", "   "},
+}
+
+// bigText convierte un texto como "24:59" en las filas del reloj grande
+func bigText(text string) []string {
+	rows := make([]string, bigGlyphHeight)
+	for i, r := range text {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row] += " "
+			}
+			rows[row] += glyph[row]
+		}
+	}
+	return rows
+}
+
+// bigTextWidth retorna el ancho en columnas del texto grande
+func bigTextWidth(text string) int {
+	rows := bigText(text)
+	return len([]rune(rows[0]))
+}
+
+// center añade espacios a la izquierda para centrar un texto de visible columnas en width
+func center(text string, visible, width int) string {
+	if visible >= width {
+		return text
+	}
+	return strings.Repeat(" ", (width-visible)/2) + text
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// subscribe actualiza la pantalla y lanza las notificaciones con los eventos del engine
+func (a *App) subscribe(eventBus *events.EventBus) {
+	eventBus.SubscribeFunc(events.TimerStarted, a.handleTimerStarted)
+	eventBus.SubscribeFunc(events.TimerTick, a.handleTimerTick)
+	eventBus.SubscribeFunc(events.TimerPaused, a.handleTimerPaused)
+	eventBus.SubscribeFunc(events.TimerResumed, a.handleTimerResumed)
+	eventBus.SubscribeFunc(events.TimerExtended, a.handleTimerExtended)
+
+	eventBus.SubscribeFunc(events.PomodoroStarted, a.handlePomodoroStarted)
+	eventBus.SubscribeFunc(events.PomodoroCompleted, a.handlePomodoroCompleted)
+	eventBus.SubscribeFunc(events.PomodoroSkipped, a.handlePomodoroSkipped)
+	eventBus.SubscribeFunc(events.BreakStarted, a.handleBreakStarted)
+	eventBus.SubscribeFunc(events.BreakCompleted, a.handleBreakCompleted)
+	eventBus.SubscribeFunc(events.BreakSkipped, a.handleBreakSkipped)
+
+	eventBus.SubscribeFunc(events.StatsUpdated, func(events.Event) { a.requestRedraw() })
+}
+
+// setTimer guarda los datos del timer y pide un nuevo dibujo
+func (a *App) setTimer(data events.TimerEventData) {
+	a.mu.Lock()
+	a.timer = data
+	a.started = true
+	a.mu.Unlock()
+	a.requestRedraw()
+}
+
+func (a *App) handleTimerStarted(event events.Event) {
+	data, ok := event.Data.(events.TimerEventData)
+	if !ok {
+		return
+	}
+	a.mu.Lock()
+	a.lastAlert = -1
+	a.mu.Unlock()
+	a.setTimer(data)
+	a.notificationManager.NotifySessionStarted(ui.SessionLabel(data.State), data.Total)
+}
+
+func (a *App) handleTimerTick(event events.Event) {
+	data, ok := event.Data.(events.TimerEventData)
+	if !ok {
+		return
+	}
+	a.setTimer(data)
+
+	// Avisos al bajar de 5 y de 1 minuto, una vez por sesión (solo si la sesión dura más)
+	for _, minutes := range []int{1, 5} {
+		threshold := time.Duration(minutes) * time.Minute
+		if data.Total <= threshold || data.Remaining > threshold {
+			continue
+		}
+		a.mu.Lock()
+		alert := a.lastAlert == -1 || a.lastAlert > minutes
+		if alert {
+			a.lastAlert = minutes
+		}
+		a.mu.Unlock()
+		if alert {
+			a.notificationManager.NotifyTimeAlert(data.Remaining, ui.SessionLabel(data.State))
+		}
+		return
+	}
+}
+
+func (a *App) handleTimerPaused(event events.Event) {
+	if data, ok := event.Data.(events.TimerEventData); ok {
+		a.setTimer(data)
+	}
+	a.notificationManager.NotifyTimerPaused()
+}
+
+func (a *App) handleTimerResumed(event events.Event) {
+	if data, ok := event.Data.(events.TimerEventData); ok {
+		a.setTimer(data)
+		a.notificationManager.NotifyTimerResumed(data.Remaining)
+	}
+}
+
+func (a *App) handleTimerExtended(event events.Event) {
+	data, ok := event.Data.(events.TimerEventData)
+	if !ok {
+		return
+	}
+	// Los avisos que quedan por delante tras la ampliación se vuelven a lanzar
+	a.mu.Lock()
+	switch {
+	case data.Remaining > 5*time.Minute:
+		a.lastAlert = -1
+	case data.Remaining > time.Minute && a.lastAlert == 1:
+		a.lastAlert = 5
+	}
+	a.mu.Unlock()
+	a.setTimer(data)
+}
+
+func (a *App) handlePomodoroStarted(event events.Event) {
+	if data, ok := event.Data.(events.PomodoroEventData); ok {
+		a.setRule(data.Rule)
+	}
+}
+
+func (a *App) handleBreakStarted(event events.Event) {
+	if data, ok := event.Data.(events.BreakEventData); ok {
+		a.setRule(data.Rule)
+	}
+}
+
+func (a *App) handlePomodoroCompleted(event events.Event) {
+	if data, ok := event.Data.(events.PomodoroEventData); ok {
+		a.notificationManager.NotifyPomodoroCompleted(data.Number, data.NextDuration)
+	}
+}
+
+func (a *App) handlePomodoroSkipped(event events.Event) {
+	if data, ok := event.Data.(events.PomodoroEventData); ok {
+		a.Notice(fmt.Sprintf("⏭️ Pomodoro #%d saltado tras %s", data.Number, ui.FormatDuration(data.ActualTime)))
+	}
+}
+
+func (a *App) handleBreakCompleted(event events.Event) {
+	if data, ok := event.Data.(events.BreakEventData); ok {
+		a.notificationManager.NotifyBreakCompleted(ui.SessionLabel(data.Type), a.engine.GetPomodoroCount()+1)
+	}
+}
+
+func (a *App) handleBreakSkipped(event events.Event) {
+	if data, ok := event.Data.(events.BreakEventData); ok {
+		a.Notice(fmt.Sprintf("⏭️ %s saltado", ui.SessionLabel(data.Type)))
+	}
+}
+
+// setRule guarda la regla de horario de la sesión que empieza
+func (a *App) setRule(rule string) {
+	a.mu.Lock()
+	a.rule = rule
+	a.mu.Unlock()
+	a.requestRedraw()
+}
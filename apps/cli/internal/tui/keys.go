@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"bufio"
+	"io"
+	"unicode"
+)
+
+// Key es una tecla pulsada en modo raw
+type Key rune
+
+// Teclas especiales (fuera del rango de caracteres imprimibles)
+const (
+	KeyCtrlC  Key = 3
+	KeyEnter  Key = '\r'
+	KeyEscape Key = 27
+)
+
+// readKeys lee pulsaciones de in y las envía por el canal hasta que la lectura falla.
+// Las secuencias de escape (flechas, teclas de función) se descartan.
+func readKeys(in io.Reader, keys chan<- Key) {
+	reader := bufio.NewReader(in)
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			close(keys)
+			return
+		}
+
+		if r == rune(KeyEscape) {
+			if reader.Buffered() == 0 {
+				keys <- KeyEscape // Esc pulsado solo
+				continue
+			}
+			skipEscapeSequence(reader)
+			continue
+		}
+		if r == '\n' {
+			r = rune(KeyEnter)
+		}
+
+		keys <- Key(unicode.ToLower(r))
+	}
+}
+
+// skipEscapeSequence descarta el resto de una secuencia CSI o SS3 (ej: "\033[A")
+func skipEscapeSequence(reader *bufio.Reader) {
+	next, _, err := reader.ReadRune()
+	if err != nil || (next != '[' && next != 'O') {
+		return
+	}
+	for reader.Buffered() > 0 {
+		b, err := reader.ReadByte()
+		if err != nil || (b >= 0x40 && b <= 0x7e) {
+			return // Byte final de la secuencia
+		}
+	}
+}
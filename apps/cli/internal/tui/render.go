@@ -0,0 +1,333 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// notice es una entrada del panel de notificaciones recientes
+type notice struct {
+	at   time.Time
+	text string
+}
+
+// todayStats son los totales del día que muestra el panel "Hoy"
+type todayStats struct {
+	Completed  int
+	Skipped    int
+	Goal       int
+	FocusTime  time.Duration
+	DayStreak  int
+	Efficiency float64
+}
+
+// view es todo lo que se necesita para dibujar una pantalla
+type view struct {
+	width   int
+	height  int
+	theme   ui.Theme
+	started bool // Ya empezó la primera sesión
+	idle    time.Duration
+	timer   events.TimerEventData
+	number  int // Número del pomodoro en curso o del siguiente
+	labels  stats.Labels
+	rule    string
+	today   todayStats
+	notices []notice // La más reciente primero
+	muted   bool
+	help    bool
+}
+
+// Tamaño mínimo con el que se dibuja el reloj grande
+const (
+	bigTimerMinHeight = 22
+	minWidth          = 40
+	minHeight         = 12
+)
+
+// render construye las líneas de la pantalla para el tamaño de la vista
+func render(v view) []string {
+	if v.width < minWidth || v.height < minHeight {
+		return renderTooSmall(v)
+	}
+
+	lines := make([]string, 0, v.height)
+	lines = append(lines, titleBar(v), "")
+
+	// Sesión y reloj
+	state := sessionState(v)
+	stateColor := sessionColor(v)
+	lines = append(lines, center(ui.Colorize(state, stateColor, true), textWidth(state), v.width), "")
+
+	clock := ui.FormatDuration(v.timer.Remaining)
+	if !v.started {
+		clock = ui.FormatDuration(v.idle)
+	}
+	if v.height >= bigTimerMinHeight && v.width >= bigTextWidth(clock)+4 {
+		for _, row := range bigText(clock) {
+			lines = append(lines, center(ui.Colorize(row, stateColor, true), utf8.RuneCountInString(row), v.width))
+		}
+	} else {
+		lines = append(lines, center(ui.Colorize(clock, stateColor, true), len(clock), v.width))
+	}
+	lines = append(lines, "")
+
+	// Progreso
+	barWidth := v.width - 14
+	if barWidth > 60 {
+		barWidth = 60
+	}
+	progress := v.timer.Progress
+	if !v.started {
+		progress = 0
+	}
+	style := ui.ProgressBarStyle{
+		FilledChar:  "█",
+		EmptyChar:   "░",
+		FilledColor: stateColor,
+		EmptyColor:  ui.ColorGray,
+		BorderColor: v.theme.Secondary,
+	}
+	bar := ui.CreateStyledProgressBar(progress, barWidth, style, true) + fmt.Sprintf(" %5.1f%%", progress*100)
+	lines = append(lines, center(bar, barWidth+2+7, v.width))
+
+	if !v.started {
+		hint := "Pulsa c o Enter para empezar"
+		lines = append(lines, "", center(ui.Colorize(hint, v.theme.Warning, true), len(hint), v.width))
+	}
+	lines = append(lines, "")
+
+	// Paneles inferiores
+	lines = append(lines, sectionTitle("Hoy", v))
+	lines = append(lines, "  "+todayLine(v))
+	lines = append(lines, sectionTitle("Tarea", v))
+	lines = append(lines, "  "+fit(taskLine(v), v.width-2))
+
+	footer := footerLine(v)
+	remaining := v.height - len(lines) - 2 // Título del panel y pie
+	if remaining > 0 {
+		lines = append(lines, sectionTitle("Notificaciones", v))
+		lines = append(lines, noticeLines(v, remaining)...)
+	}
+
+	// El pie va siempre en la última fila
+	for len(lines) < v.height-1 {
+		lines = append(lines, "")
+	}
+	if len(lines) > v.height-1 {
+		lines = lines[:v.height-1]
+	}
+	return append(lines, footer)
+}
+
+// renderTooSmall dibuja una versión mínima cuando la terminal es demasiado pequeña
+func renderTooSmall(v view) []string {
+	clock := ui.FormatDuration(v.timer.Remaining)
+	if !v.started {
+		clock = ui.FormatDuration(v.idle)
+	}
+	lines := []string{
+		ui.Colorize(fit(sessionState(v)+" "+clock, v.width), sessionColor(v), true),
+		fit(fmt.Sprintf("Terminal pequeña (%dx%d)", v.width, v.height), v.width),
+	}
+	if len(lines) > v.height {
+		lines = lines[:v.height]
+	}
+	return lines
+}
+
+// titleBar es la barra superior con el nombre de la aplicación y el tema
+func titleBar(v view) string {
+	left := " GOMODORO"
+	right := "tema: " + v.theme.Name + " "
+	if v.muted {
+		right = "sin sonido · " + right
+	}
+	padding := v.width - textWidth(left) - textWidth(right)
+	if padding < 1 {
+		padding = 1
+	}
+	return ui.Colorize(left, v.theme.Primary+ui.ColorBold, true) + strings.Repeat(" ", padding) + ui.Colorize(right, ui.ColorGray, true)
+}
+
+// sessionState describe la sesión en curso (ej: "TRABAJO · Pomodoro #3 · PAUSADO")
+func sessionState(v view) string {
+	if !v.started {
+		return fmt.Sprintf("LISTO · Pomodoro #%d", v.number)
+	}
+
+	state := ui.SessionLabel(v.timer.State)
+	if v.timer.State == events.KindWork {
+		state += fmt.Sprintf(" · Pomodoro #%d", v.number)
+	}
+	if strings.EqualFold(v.timer.Status, "PAUSED") {
+		state += " · PAUSADO"
+	}
+	return state
+}
+
+// sessionColor retorna el color del tema para la sesión en curso
+func sessionColor(v view) ui.Color {
+	switch {
+	case !v.started:
+		return ui.ColorGray
+	case strings.EqualFold(v.timer.Status, "PAUSED"):
+		return v.theme.Warning
+	case v.timer.State == events.KindWork:
+		return v.theme.Primary
+	case v.timer.State == events.KindLongBreak:
+		return v.theme.Secondary
+	default:
+		return v.theme.Info
+	}
+}
+
+// sectionTitle es el título de un panel, con una línea hasta el borde derecho
+func sectionTitle(title string, v view) string {
+	rule := v.width - textWidth(title) - 4
+	if rule < 0 {
+		rule = 0
+	}
+	return ui.Colorize("─ ", ui.ColorGray, true) + ui.Colorize(title, v.theme.Info, true) + ui.Colorize(" "+strings.Repeat("─", rule), ui.ColorGray, true)
+}
+
+// todayLine resume los totales del día
+func todayLine(v view) string {
+	t := v.today
+	goal := fmt.Sprintf("%d", t.Completed)
+	if t.Goal > 0 {
+		goal = fmt.Sprintf("%d/%d", t.Completed, t.Goal)
+	}
+	goalColor := v.theme.Text
+	if t.Goal > 0 && t.Completed >= t.Goal {
+		goalColor = v.theme.Success
+	}
+
+	parts := []string{
+		"Pomodoros " + ui.Colorize(goal, goalColor, true),
+		"Saltados " + fmt.Sprintf("%d", t.Skipped),
+		"Enfoque " + ui.Colorize(stats.FormatDuration(t.FocusTime), v.theme.Info, true),
+		"Racha " + ui.Colorize(fmt.Sprintf("%d días", t.DayStreak), ui.GetStreakColor(t.DayStreak), true),
+	}
+	if t.Completed+t.Skipped > 0 {
+		parts = append(parts, "Eficiencia "+ui.Colorize(fmt.Sprintf("%.0f%%", t.Efficiency), ui.GetEfficiencyColor(t.Efficiency), true))
+	}
+
+	// Se quitan datos por la derecha si no caben
+	separator := ui.Colorize("  ·  ", ui.ColorGray, true)
+	for len(parts) > 1 && textWidth(strings.Join(parts, "  ·  ")) > v.width-2 {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, separator)
+}
+
+// taskLine describe la tarea actual y la regla de horario aplicada
+func taskLine(v view) string {
+	text := "(sin tarea)"
+	if !v.labels.IsEmpty() {
+		text = v.labels.Summary()
+	}
+	if v.rule != "" {
+		text += " · regla " + v.rule
+	}
+	return text
+}
+
+// noticeLines retorna como mucho max líneas del panel de notificaciones
+func noticeLines(v view, max int) []string {
+	if len(v.notices) == 0 {
+		return []string{ui.Colorize("  Sin notificaciones todavía", ui.ColorGray, true)}
+	}
+
+	lines := make([]string, 0, max)
+	for _, n := range v.notices {
+		if len(lines) == max {
+			break
+		}
+		stamp := n.at.Format("15:04")
+		lines = append(lines, "  "+ui.Colorize(stamp, ui.ColorGray, true)+" "+fit(n.text, v.width-3-len(stamp)))
+	}
+	return lines
+}
+
+// footerLine muestra las teclas disponibles (o la ayuda completa con h)
+func footerLine(v view) string {
+	keys := [][2]string{{"p", "pausa"}, {"s", "saltar"}, {"q", "salir"}, {"h", "ayuda"}}
+	if !v.started {
+		keys = append([][2]string{{"c", "empezar"}}, keys...)
+	}
+	if v.help {
+		keys = [][2]string{{"p/espacio", "pausa/reanudar"}, {"s", "saltar"}, {"t", "tema"}, {"m", "sonido"}, {"q/Ctrl+C", "salir"}, {"h", "cerrar ayuda"}}
+	}
+
+	parts := make([]string, 0, len(keys))
+	plain := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, ui.Colorize("["+key[0]+"]", v.theme.Secondary, true)+" "+key[1])
+		plain = append(plain, "["+key[0]+"] "+key[1])
+	}
+	for len(parts) > 1 && textWidth(" "+strings.Join(plain, "  ")) > v.width {
+		parts = parts[:len(parts)-1]
+		plain = plain[:len(plain)-1]
+	}
+	return " " + strings.Join(parts, "  ")
+}
+
+// fit recorta un texto sin colores para que ocupe como mucho width columnas
+func fit(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if textWidth(text) <= width {
+		return text
+	}
+
+	var result strings.Builder
+	used := 0
+	for _, r := range text {
+		w := runeWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		result.WriteRune(r)
+		used += w
+	}
+	return result.String() + "…"
+}
+
+// textWidth retorna las columnas que ocupa un texto, sin contar los códigos de color
+func textWidth(text string) int {
+	width := 0
+	inEscape := false
+	for _, r := range text {
+		switch {
+		case inEscape:
+			if r >= '@' && r <= '~' && r != '[' {
+				inEscape = false
+			}
+		case r == '\033':
+			inEscape = true
+		default:
+			width += runeWidth(r)
+		}
+	}
+	return width
+}
+
+// runeWidth aproxima las columnas de un carácter: los emoji ocupan dos y los selectores de variante ninguna
+func runeWidth(r rune) int {
+	switch {
+	case r == 0xFE0F || r == 0x200D:
+		return 0
+	case r >= 0x1F300:
+		return 2
+	default:
+		return 1
+	}
+}
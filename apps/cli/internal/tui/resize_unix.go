@@ -0,0 +1,29 @@
+//go:build !windows
+
+package tui
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize avisa por el canal cada vez que cambia el tamaño de la terminal (SIGWINCH)
+func watchResize(ctx context.Context, resized chan<- struct{}) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			select {
+			case resized <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
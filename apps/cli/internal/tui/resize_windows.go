@@ -0,0 +1,35 @@
+//go:build windows
+
+package tui
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// watchResize comprueba periódicamente el tamaño de la consola (Windows no tiene SIGWINCH)
+func watchResize(ctx context.Context, resized chan<- struct{}) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	width, height, _ := term.GetSize(int(os.Stdout.Fd()))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w, h, err := term.GetSize(int(os.Stdout.Fd()))
+			if err != nil || (w == width && h == height) {
+				continue
+			}
+			width, height = w, h
+			select {
+			case resized <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// Secuencias de control de la terminal
+const (
+	enterAltScreen = "\033[?1049h"
+	leaveAltScreen = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	clearScreen    = "\033[2J"
+	cursorHome     = "\033[H"
+	clearLine      = "\033[K"
+	clearBelow     = "\033[J"
+)
+
+// Terminal controla la terminal en modo raw con el buffer de pantalla alternativo
+type Terminal struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+	mu    sync.Mutex // Serializa las escrituras en la salida
+}
+
+// IsSupported indica si la entrada y la salida estándar son una terminal interactiva
+func IsSupported() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// OpenTerminal pone la terminal en modo raw y cambia al buffer de pantalla alternativo
+func OpenTerminal() (*Terminal, error) {
+	if !IsSupported() {
+		return nil, fmt.Errorf("stdin and stdout must be a terminal")
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable raw mode: %w", err)
+	}
+
+	t := &Terminal{in: os.Stdin, out: os.Stdout, state: state}
+	t.write(enterAltScreen + hideCursor + clearScreen)
+	return t, nil
+}
+
+// Close restaura la pantalla y el modo de la terminal
+func (t *Terminal) Close() error {
+	t.write(showCursor + leaveAltScreen)
+	return term.Restore(int(t.in.Fd()), t.state)
+}
+
+// Size retorna el ancho y alto de la terminal (80x24 si no se puede obtener)
+func (t *Terminal) Size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// Draw dibuja las líneas desde la esquina superior izquierda en una sola escritura.
+// Cada línea borra el resto de la fila, así no hace falta limpiar la pantalla y no hay parpadeo.
+func (t *Terminal) Draw(lines []string) {
+	var frame bytes.Buffer
+	frame.WriteString(cursorHome)
+	for i, line := range lines {
+		if i > 0 {
+			frame.WriteString("\r\n")
+		}
+		frame.WriteString(line)
+		frame.WriteString(clearLine)
+	}
+	frame.WriteString(clearBelow)
+	t.write(frame.String())
+}
+
+// Clear borra toda la pantalla (tras un cambio de tamaño)
+func (t *Terminal) Clear() {
+	t.write(clearScreen)
+}
+
+// Bell hace sonar el timbre de la terminal
+func (t *Terminal) Bell() {
+	t.write("\a")
+}
+
+// write escribe en la salida sin mezclarse con otras escrituras
+func (t *Terminal) write(s string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.out.WriteString(s)
+}
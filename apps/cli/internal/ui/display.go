@@ -7,6 +7,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
 )
 
 // Variables de estado del display
@@ -15,6 +17,20 @@ var (
 	lastDisplayContent = ""
 )
 
+// SessionLabel retorna el texto que muestra la CLI para cada tipo de sesión
+func SessionLabel(kind events.SessionKind) string {
+	switch kind {
+	case events.KindWork:
+		return "TRABAJO"
+	case events.KindShortBreak:
+		return "DESCANSO"
+	case events.KindLongBreak:
+		return "DESCANSO LARGO"
+	default:
+		return strings.ToUpper(string(kind))
+	}
+}
+
 // ClearScreen limpia la pantalla de la terminal
 func ClearScreen() {
 	var cmd *exec.Cmd
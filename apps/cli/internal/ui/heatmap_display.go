@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// Orden de las filas del heatmap (la semana empieza en lunes)
+var heatmapWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+	time.Friday, time.Saturday, time.Sunday,
+}
+
+// HeatmapDisplay genera un heatmap día de la semana × hora coloreado con el tema indicado
+func HeatmapDisplay(heatmap *stats.Heatmap, theme Theme, config StatsDisplayConfig) string {
+	var result strings.Builder
+
+	result.WriteString(theme.ApplyTheme("🗓️  ¿CUÁNDO TE CONCENTRAS?\n", "info", config.UseColors))
+	result.WriteString(Colorize(fmt.Sprintf("   Pomodoros por día y hora de inicio (%s)\n\n", heatmap.Location.String()), ColorGray, config.UseColors))
+
+	first, last, ok := heatmap.HourRange()
+	if !ok {
+		result.WriteString(Colorize("Sin pomodoros registrados en este período\n", ColorGray, config.UseColors))
+		return result.String()
+	}
+
+	// Cabecera con las horas
+	result.WriteString("    ")
+	for hour := first; hour <= last; hour++ {
+		result.WriteString(Colorize(fmt.Sprintf("%-3d", hour), ColorGray, config.UseColors))
+	}
+	result.WriteString("\n")
+
+	for _, day := range heatmapWeekdays {
+		result.WriteString(fmt.Sprintf("%-4s", weekdayNames[day]))
+		for hour := first; hour <= last; hour++ {
+			result.WriteString(heatmapCell(heatmap, day, hour, theme, config.UseColors))
+			result.WriteString(" ")
+		}
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	result.WriteString(fmt.Sprintf("%s sin actividad  %s poca  %s media  %s alta  %s máxima  %s solo saltados\n",
+		Colorize("··", ColorGray, config.UseColors),
+		Colorize("░░", theme.Info, config.UseColors),
+		Colorize("▒▒", theme.Warning, config.UseColors),
+		Colorize("▓▓", theme.Secondary, config.UseColors),
+		Colorize("██", theme.Primary, config.UseColors),
+		Colorize("××", theme.Error, config.UseColors)))
+
+	result.WriteString(fmt.Sprintf("🍅 %s completados | ⏭️  %s saltados\n",
+		theme.ApplyTheme(fmt.Sprintf("%d", heatmap.Completed), "success", config.UseColors),
+		theme.ApplyTheme(fmt.Sprintf("%d", heatmap.Skipped), "error", config.UseColors)))
+
+	if day, hour, ok := heatmap.Peak(); ok {
+		cell := heatmap.Cells[day][hour]
+		result.WriteString(fmt.Sprintf("🏆 Mejor franja: %s %02d:00-%02d:00 (%d pomodoros)\n",
+			weekdayNames[day], hour, (hour+1)%24, cell.Completed))
+	}
+
+	return result.String()
+}
+
+// heatmapCell retorna el bloque coloreado de una celda según su intensidad
+func heatmapCell(heatmap *stats.Heatmap, day time.Weekday, hour int, theme Theme, useColors bool) string {
+	cell := heatmap.Cells[day][hour]
+
+	switch intensity := heatmap.Intensity(day, hour); {
+	case cell.Completed == 0 && cell.Skipped > 0:
+		return Colorize("××", theme.Error, useColors)
+	case cell.Completed == 0:
+		return Colorize("··", ColorGray, useColors)
+	case intensity >= 1:
+		return Colorize("██", theme.Primary, useColors)
+	case intensity >= 0.66:
+		return Colorize("▓▓", theme.Secondary, useColors)
+	case intensity >= 0.33:
+		return Colorize("▒▒", theme.Warning, useColors)
+	default:
+		return Colorize("░░", theme.Info, useColors)
+	}
+}
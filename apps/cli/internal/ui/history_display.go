@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// HistoryDisplay genera la vista de una página de resultados del historial
+func HistoryDisplay(history *stats.QueryResult, page, pageSize int, config StatsDisplayConfig) string {
+	var result strings.Builder
+
+	pages := (history.Total + pageSize - 1) / pageSize
+	if pages == 0 {
+		pages = 1
+	}
+
+	result.WriteString(Colorize(fmt.Sprintf("🗂️  HISTORIAL (%d sesiones, página %d/%d)\n", history.Total, page, pages), ColorCyan, config.UseColors))
+	result.WriteString(Colorize("─────────────────────────\n", ColorGray, config.UseColors))
+
+	if len(history.Sessions) == 0 {
+		result.WriteString(Colorize("Sin sesiones que coincidan con los filtros\n", ColorGray, config.UseColors))
+		return result.String()
+	}
+
+	for _, record := range history.Sessions {
+		icon, color := "✅", ColorGreen
+		if !record.Completed {
+			icon, color = "⏭️ ", ColorRed
+		}
+
+		line := fmt.Sprintf("%s %s  %s %s",
+			icon,
+			record.StartTime.Local().Format("02/01/2006 15:04"),
+			Colorize(fmt.Sprintf("%-14s", SessionLabel(record.Type)), color, config.UseColors),
+			formatDurationDetailed(record.ActualTime))
+		if record.Type.IsWork() {
+			line += fmt.Sprintf("  🎯 %.0f%%", record.FocusScore())
+		}
+		if summary := record.Labels.Summary(); summary != "" {
+			line += "  " + Colorize(summary, ColorYellow, config.UseColors)
+		}
+		result.WriteString(line + "\n")
+	}
+
+	totals := history.Totals
+	result.WriteString(Colorize("─────────────────────────\n", ColorGray, config.UseColors))
+	result.WriteString(fmt.Sprintf("🍅 %s completados, %d saltados | ⏱️ Enfoque: %s | 🧘 Descanso: %s\n",
+		Colorize(fmt.Sprintf("%d", totals.PomodorosCompleted), ColorGreen, config.UseColors),
+		totals.PomodorosSkipped,
+		formatDurationDetailed(totals.FocusTime),
+		formatDurationDetailed(totals.BreakTime)))
+	result.WriteString(fmt.Sprintf("📈 Eficiencia: %s | 🎯 Enfoque: %.1f%%\n",
+		Colorize(fmt.Sprintf("%.1f%%", totals.Efficiency), GetEfficiencyColor(totals.Efficiency), config.UseColors),
+		totals.FocusScore))
+
+	if page < pages {
+		result.WriteString(Colorize(fmt.Sprintf("💡 Siguiente página: -page %d\n", page+1), ColorGray, config.UseColors))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// Nombres cortos de los días de la semana (time.Weekday empieza en domingo)
+var weekdayNames = []string{"Dom", "Lun", "Mar", "Mié", "Jue", "Vie", "Sáb"}
+
+// ReportDisplay genera la vista de un reporte agregado del historial
+func ReportDisplay(report *stats.Report, config StatsDisplayConfig) string {
+	var result strings.Builder
+
+	result.WriteString(Colorize(fmt.Sprintf("📅 REPORTE %s\n", periodTitle(report.Period)), ColorCyan, config.UseColors))
+	result.WriteString(Colorize(fmt.Sprintf("   %s → %s (%s)\n",
+		report.Start.Format("02/01/2006"),
+		report.End.AddDate(0, 0, -1).Format("02/01/2006"),
+		report.Location.String()), ColorGray, config.UseColors))
+	result.WriteString("\n")
+
+	total := report.Total
+	result.WriteString(Colorize("🍅 RESUMEN\n", ColorYellow, config.UseColors))
+	result.WriteString(Colorize("─────────\n", ColorGray, config.UseColors))
+	result.WriteString(fmt.Sprintf("🍅 Pomodoros: %s | ⏭️  Saltados: %s\n",
+		Colorize(fmt.Sprintf("%d", total.PomodorosCompleted), ColorGreen, config.UseColors),
+		Colorize(fmt.Sprintf("%d", total.PomodorosSkipped), ColorRed, config.UseColors)))
+	result.WriteString(fmt.Sprintf("🧘 Descansos: %d completados, %d saltados\n",
+		total.BreaksCompleted, total.BreaksSkipped))
+	result.WriteString(fmt.Sprintf("⏱️  Enfoque: %s | Descanso: %s\n",
+		Colorize(formatDurationDetailed(total.FocusTime), ColorBlue, config.UseColors),
+		Colorize(formatDurationDetailed(total.BreakTime), ColorCyan, config.UseColors)))
+	result.WriteString(fmt.Sprintf("📈 Eficiencia: %s %s\n",
+		createProgressBar(total.Efficiency/100.0, 20, config.UseColors),
+		Colorize(fmt.Sprintf("%.1f%%", total.Efficiency), GetEfficiencyColor(total.Efficiency), config.UseColors)))
+	result.WriteString(fmt.Sprintf("🎯 Enfoque: %.1f%% | ⏸️  Pausas: %d\n", total.FocusScore, total.Pauses))
+	result.WriteString(fmt.Sprintf("🔥 Racha más larga: %s\n",
+		Colorize(fmt.Sprintf("%d", total.LongestStreak), GetStreakColor(total.LongestStreak), config.UseColors)))
+
+	if report.BestDay != nil {
+		result.WriteString(fmt.Sprintf("🏆 Mejor día: %s (%d pomodoros)\n",
+			dayLabel(*report.BestDay), report.BestDay.PomodorosCompleted))
+	}
+	if report.WorstDay != nil && report.WorstDay != report.BestDay {
+		result.WriteString(fmt.Sprintf("🐢 Peor día: %s (%d pomodoros)\n",
+			dayLabel(*report.WorstDay), report.WorstDay.PomodorosCompleted))
+	}
+
+	if len(report.Days) == 0 {
+		result.WriteString("\n")
+		result.WriteString(Colorize("Sin sesiones registradas en este período\n", ColorGray, config.UseColors))
+		return result.String()
+	}
+
+	// Desglose diario con barra proporcional al mejor día
+	maxCompleted := 1
+	if report.BestDay != nil && report.BestDay.PomodorosCompleted > 0 {
+		maxCompleted = report.BestDay.PomodorosCompleted
+	}
+
+	result.WriteString("\n")
+	result.WriteString(Colorize("📊 POR DÍA\n", ColorYellow, config.UseColors))
+	result.WriteString(Colorize("─────────\n", ColorGray, config.UseColors))
+	for _, day := range report.Days {
+		width := day.PomodorosCompleted * 20 / maxCompleted
+		bar := Colorize(strings.Repeat("█", width), ColorGreen, config.UseColors) +
+			Colorize(strings.Repeat("░", 20-width), ColorGray, config.UseColors)
+		result.WriteString(fmt.Sprintf("%-10s [%s] 🍅 %2d ⏭️  %2d ⏱️ %s\n",
+			dayLabel(day), bar, day.PomodorosCompleted, day.PomodorosSkipped,
+			formatDurationDetailed(day.FocusTime)))
+	}
+
+	return result.String()
+}
+
+// periodTitle traduce el período del reporte
+func periodTitle(period stats.ReportPeriod) string {
+	switch period {
+	case stats.PeriodDay:
+		return "DIARIO"
+	case stats.PeriodMonth:
+		return "MENSUAL"
+	default:
+		return "SEMANAL"
+	}
+}
+
+// dayLabel formatea un día como "Lun 02/01"
+func dayLabel(day stats.PeriodAggregate) string {
+	return fmt.Sprintf("%s %s", weekdayNames[day.Start.Weekday()], day.Start.Format("02/01"))
+}
@@ -105,6 +105,7 @@ func buildSummarySection(snapshot stats.StatsSnapshot, config StatsDisplayConfig
 		ColorEnd(config.UseColors))
 
 	result.WriteString(fmt.Sprintf("%-30s %s\n", col3, col4))
+	result.WriteString(buildDayStreakLine(snapshot.DayStreak, config))
 
 	// Tiempo total con formato amigable
 	workTime := formatDurationDetailed(snapshot.TotalWorkTime)
@@ -133,6 +134,13 @@ func buildPerformanceSection(snapshot stats.StatsSnapshot, config StatsDisplayCo
 	efficiencyBar := createProgressBar(efficiency/100.0, 20, config.UseColors)
 	result.WriteString(fmt.Sprintf("💪 Eficiencia trabajo: %s %.1f%%\n", efficiencyBar, efficiency))
 
+	// Calidad de enfoque (tiempo activo vs pausas)
+	focusBar := createProgressBar(snapshot.FocusScore/100.0, 20, config.UseColors)
+	result.WriteString(fmt.Sprintf("🎯 Enfoque: %s %s\n", focusBar,
+		Colorize(fmt.Sprintf("%.1f%%", snapshot.FocusScore), GetEfficiencyColor(snapshot.FocusScore), config.UseColors)))
+	result.WriteString(fmt.Sprintf("⏸️  Pausas: %d (%s en pausa) | ✅ Completados: %.1f%%\n",
+		snapshot.TotalPauses, formatDurationDetailed(snapshot.TotalPausedTime), snapshot.CompletionRate))
+
 	// Ratio descansos
 	totalBreaks := snapshot.BreaksCompleted + snapshot.BreaksSkipped
 	var breakEfficiency float64
@@ -246,13 +254,27 @@ func buildTipsSection(snapshot stats.StatsSnapshot, config StatsDisplayConfig) s
 // Helper functions
 
 func compactStatsDisplay(snapshot stats.StatsSnapshot) string {
-	return fmt.Sprintf("🍅 %d | 🔥 %d | ⏱️ %s | 📈 %.1f%%",
+	return fmt.Sprintf("🍅 %d | 🔥 %d | 📆 %d días | ⏱️ %s | 📈 %.1f%%",
 		snapshot.PomodorosCompleted,
 		snapshot.CurrentStreak,
+		snapshot.DayStreak.Current,
 		formatDurationDetailed(snapshot.TotalWorkTime),
 		snapshot.WorkEfficiency)
 }
 
+// buildDayStreakLine construye la línea de racha de días cumpliendo el objetivo diario
+func buildDayStreakLine(streak stats.DayStreak, config StatsDisplayConfig) string {
+	today := Colorize(fmt.Sprintf("%d/%d", streak.TodayCompleted, streak.MinPomodoros), ColorYellow, config.UseColors)
+	if streak.GoalMetToday {
+		today = Colorize(fmt.Sprintf("%d/%d ✅", streak.TodayCompleted, streak.MinPomodoros), ColorGreen, config.UseColors)
+	}
+
+	return fmt.Sprintf("📆 Racha diaria: %s días (mejor %d) | Hoy: %s\n",
+		Colorize(fmt.Sprintf("%d", streak.Current), GetStreakColor(streak.Current), config.UseColors),
+		streak.Best,
+		today)
+}
+
 func createProgressBar(progress float64, width int, useColors bool) string {
 	filled := int(progress * float64(width))
 	empty := width - filled
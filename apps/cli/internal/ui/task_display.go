@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// TaskStatsDisplay genera la tabla de estadísticas por tarea, proyecto, tag o regla de horario
+func TaskStatsDisplay(groups []stats.LabelStats, groupBy stats.GroupBy, config StatsDisplayConfig) string {
+	var result strings.Builder
+
+	result.WriteString(Colorize(fmt.Sprintf("🏷️  ESTADÍSTICAS POR %s\n", groupTitle(groupBy)), ColorCyan, config.UseColors))
+	result.WriteString(Colorize("─────────────────────────\n", ColorGray, config.UseColors))
+
+	if len(groups) == 0 {
+		result.WriteString(Colorize("Sin pomodoros registrados todavía\n", ColorGray, config.UseColors))
+		return result.String()
+	}
+
+	for _, group := range groups {
+		name := group.Key
+		if name == "" {
+			name = "(sin etiqueta)"
+			if groupBy == stats.GroupByRule {
+				name = "(configuración base)"
+			}
+		}
+		if len([]rune(name)) > 28 {
+			name = string([]rune(name)[:27]) + "…"
+		}
+
+		result.WriteString(fmt.Sprintf("%s\n", Colorize(name, ColorYellow, config.UseColors)))
+		result.WriteString(fmt.Sprintf("   🍅 %s  ⏭️  %d (%s)  ⏱️ %s\n",
+			Colorize(fmt.Sprintf("%d", group.PomodorosCompleted), ColorGreen, config.UseColors),
+			group.PomodorosSkipped,
+			Colorize(fmt.Sprintf("%.0f%%", group.SkipRate), GetEfficiencyColor(100-group.SkipRate), config.UseColors),
+			formatDurationDetailed(group.FocusTime)))
+		result.WriteString(Colorize(fmt.Sprintf("   📅 %s → %s\n",
+			group.FirstWorked.Local().Format("02/01/2006 15:04"),
+			group.LastWorked.Local().Format("02/01/2006 15:04")), ColorGray, config.UseColors))
+
+		if group.HasEstimate() {
+			progress := group.EstimateProgress()
+			color := ColorGreen
+			if progress > 100 {
+				color = ColorRed // Se excedió la estimación
+			}
+			result.WriteString(fmt.Sprintf("   🎯 Estimado: %d/%d %s %s\n",
+				group.PomodorosCompleted, group.Estimate,
+				createProgressBar(progress/100.0, 15, config.UseColors),
+				Colorize(fmt.Sprintf("%.0f%%", progress), color, config.UseColors)))
+		}
+	}
+
+	return result.String()
+}
+
+// groupTitle traduce el criterio de agrupación
+func groupTitle(groupBy stats.GroupBy) string {
+	switch groupBy {
+	case stats.GroupByProject:
+		return "PROYECTO"
+	case stats.GroupByTag:
+		return "TAG"
+	case stats.GroupByRule:
+		return "REGLA DE HORARIO"
+	default:
+		return "TAREA"
+	}
+}
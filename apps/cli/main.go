@@ -4,45 +4,228 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
+	"os/user"
 	"time"
 
+	"github.com/kubaliski/pomodoro-cli/internal/daemon"
 	"github.com/kubaliski/pomodoro-cli/internal/handlers"
+	"github.com/kubaliski/pomodoro-cli/internal/notifications"
+	"github.com/kubaliski/pomodoro-cli/internal/plugins"
+	"github.com/kubaliski/pomodoro-cli/internal/tui"
 	"github.com/kubaliski/pomodoro-core/config"
 	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/hooks"
+	"github.com/kubaliski/pomodoro-core/metrics"
+	"github.com/kubaliski/pomodoro-core/stats"
 )
 
+// frontend es la interfaz con el usuario: el modo de líneas o la pantalla completa
+type frontend interface {
+	Run(ctx context.Context) error
+	GetNotificationManager() *notifications.Manager
+	SetHistoryStore(store stats.Store, userID string)
+	Notice(text string)
+}
+
+// aliasFrontend es un frontend con comandos escritos que admite los alias del archivo de configuración
+type aliasFrontend interface {
+	SetAliases(aliases map[string]string) error
+}
+
+// pluginFrontend es un frontend con comandos escritos que admite los comandos de los plugins
+type pluginFrontend interface {
+	SetPlugins(manager *plugins.Manager) error
+}
+
 func main() {
-	// Configuración desde flags
+	os.Exit(run())
+}
+
+// run inicia la CLI y retorna el código de salida. Los errores se retornan en lugar de
+// terminar el proceso para que se ejecuten los defer (historial, plugins, hooks y métricas).
+func run() int {
+	// Subcomandos que no inician el timer
+	args := os.Args[1:]
+	daemonMode := false
+	if len(args) > 0 {
+		switch command := args[0]; {
+		case command == "maintenance":
+			return runMaintenance(args[1:])
+		case command == "config":
+			return runConfig(args[1:])
+		case command == "presets":
+			return runPresets(args[1:])
+		case command == "status":
+			return runStatus(args[1:])
+		case controlCommands[command]:
+			return runControl(command, args[1:])
+		case command == "daemon":
+			// El daemon acepta los mismos flags que el modo interactivo
+			daemonMode = true
+			args = args[1:]
+		}
+	}
+
+	// Configuración por capas: valores por defecto, archivo, entorno (GOMODORO_*) y flags
+	loader := config.NewLoader()
+	loader.RegisterFlags(flag.CommandLine)
+
 	var (
-		workDuration      = flag.Duration("work", 25*time.Minute, "Duración de la sesión de trabajo")
-		shortBreak        = flag.Duration("break", 5*time.Minute, "Duración del descanso corto")
-		longBreak         = flag.Duration("long", 15*time.Minute, "Duración del descanso largo")
-		longBreakInterval = flag.Int("interval", 4, "Número de pomodoros antes del descanso largo")
+		historyPath       = flag.String("history", stats.DefaultHistoryPath(), "Archivo del historial de sesiones (vacío para desactivar)")
+		dailyGoal         = flag.Int("daily-goal", 4, "Pomodoros al día para mantener la racha diaria")
+		restDays          = flag.String("rest-days", "", "Días que no rompen la racha diaria (ej: sat,sun)")
+		retentionDays     = flag.Int("retention-days", 0, "Días con sesiones individuales en el historial; las anteriores se compactan (0 = sin límite)")
+		notificationsPath = flag.String("notifications", notifications.DefaultConfigPath(), "Archivo de configuración de notificaciones (.json, .yaml o .toml)")
+		metricsAddr       = flag.String("metrics-addr", "", "Dirección donde servir métricas de Prometheus en /metrics (ej: :9090, vacío para desactivar)")
+		watchConfig       = flag.Bool("watch-config", false, "Recargar la configuración y las notificaciones al editar sus archivos")
+		tuiMode           = flag.Bool("tui", false, "Interfaz a pantalla completa con teclas sueltas (si la terminal no la admite se usa el modo de líneas)")
+		socketPath        = flag.String("socket", daemon.DefaultSocketPath(), "Socket de control en modo daemon")
+		pluginsDir        = flag.String("plugins", plugins.DefaultDir(), "Directorio de plugins, uno por subdirectorio con su plugin.yaml (vacío para desactivar)")
 	)
-	flag.Parse()
+	flag.CommandLine.Parse(args)
 
-	// Crear configuración
-	cfg := &config.Config{
-		WorkDuration:      *workDuration,
-		ShortBreak:        *shortBreak,
-		LongBreak:         *longBreak,
-		LongBreakInterval: *longBreakInterval,
+	// Combinar y validar la configuración
+	resolved, err := loader.Load()
+	if err != nil {
+		log.Printf("Error en configuración: %v", err)
+		return 1
 	}
+	if err := config.SetBounds(resolved.Bounds); err != nil {
+		log.Printf("Error en configuración: %v", err)
+		return 1
+	}
+	cfg := resolved.Config
 
-	// Validar configuración
-	if err := cfg.Validate(); err != nil {
-		log.Fatalf("Error en configuración: %v", err)
+	// Configuración de la racha diaria
+	streakConfig := stats.DefaultDayStreakConfig()
+	streakConfig.MinPomodoros = *dailyGoal
+	days, err := config.ParseWeekdays(*restDays)
+	if err != nil {
+		log.Printf("Error en configuración: %v", err)
+		return 1
 	}
+	streakConfig.RestDays = days
 
 	// Crear engine del core
 	pomodoroEngine := engine.NewEngine(cfg)
+	pomodoroEngine.GetStats().ConfigureDayStreak(nil, "", streakConfig)
+
+	// Crear la interfaz que conecta el core con el usuario
+	var app frontend
+	if daemonMode {
+		app = daemon.NewServer(pomodoroEngine, *socketPath)
+	} else if *tuiMode && tui.IsSupported() {
+		app = tui.NewApp(pomodoroEngine)
+	} else {
+		if *tuiMode {
+			log.Printf("⚠️ La entrada o la salida no es una terminal, se usa el modo de líneas")
+		}
+		cli := handlers.NewCLIHandler(pomodoroEngine)
+		cli.SetPresets(resolved.Presets)
+		app = cli
+	}
 
-	// Crear handler CLI que conecta el core con la UI
-	cliHandler := handlers.NewCLIHandler(pomodoroEngine)
+	// Hooks: comandos de shell que se ejecutan con los eventos del engine
+	hookRunner := hooks.NewRunner(resolved.Hooks)
+	hookRunner.Subscribe(pomodoroEngine.GetEventBus())
+	defer hookRunner.Wait()
+
+	// Los errores que publica el core (hooks, historial) se muestran como avisos
+	pomodoroEngine.GetEventBus().SubscribeFunc(events.ErrorOccurred, func(event events.Event) {
+		if data, ok := event.Data.(events.ErrorEventData); ok {
+			app.Notice("⚠️  " + data.Message)
+		}
+	})
 
-	// Ejecutar
 	ctx := context.Background()
-	if err := cliHandler.Run(ctx); err != nil {
-		log.Fatalf("Error ejecutando CLI: %v", err)
+
+	// Plugins: procesos externos que reciben los eventos y pueden controlar el timer
+	if *pluginsDir != "" {
+		manifests, err := plugins.Discover(*pluginsDir)
+		if err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+		pluginManager := plugins.NewManager(pomodoroEngine, manifests, app.Notice)
+		pluginManager.Subscribe(pomodoroEngine.GetEventBus())
+		pluginManager.Start(ctx)
+		defer pluginManager.Stop()
+		if withPlugins, ok := app.(pluginFrontend); ok {
+			if err := withPlugins.SetPlugins(pluginManager); err != nil {
+				log.Printf("⚠️ %v", err)
+			}
+		}
+	}
+
+	// Alias del archivo de configuración (después de los plugins para no ocultar sus comandos)
+	if withAliases, ok := app.(aliasFrontend); ok {
+		if err := withAliases.SetAliases(resolved.Aliases); err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+	}
+
+	// Configuración de notificaciones desde archivo
+	if notifConfig, err := notifications.LoadConfigIfExists(*notificationsPath); err != nil {
+		log.Printf("⚠️ Configuración de notificaciones no válida, usando valores por defecto: %v", err)
+	} else if err := app.GetNotificationManager().UpdateConfig(notifConfig); err != nil {
+		log.Printf("⚠️ No se pudo aplicar la configuración de notificaciones: %v", err)
+	}
+
+	// Métricas de Prometheus
+	if *metricsAddr != "" {
+		collector := metrics.NewCollector()
+		server, err := collector.Registry().ListenAndServe(*metricsAddr)
+		if err != nil {
+			log.Printf("⚠️ Métricas no disponibles: %v", err)
+		} else {
+			defer server.Close()
+			collector.Subscribe(pomodoroEngine.GetEventBus())
+			app.GetNotificationManager().SetMetrics(collector)
+			log.Printf("📈 Métricas en http://%s/metrics", server.Addr)
+		}
+	}
+
+	// Historial persistente de sesiones
+	if *historyPath != "" {
+		historyStore, err := stats.OpenFileStore(*historyPath)
+		if err != nil {
+			log.Printf("⚠️ Historial no disponible: %v", err)
+		} else {
+			defer historyStore.Close()
+			policy := stats.RetentionPolicy{RawDays: *retentionDays}
+			if policy.Enabled() {
+				if result, err := historyStore.Compact(policy, time.Now()); err != nil {
+					log.Printf("⚠️ No se pudo compactar el historial: %v", err)
+				} else if result.Compacted > 0 {
+					log.Printf("🗜️ Historial compactado: %d sesiones en %d días", result.Compacted, result.Days)
+				}
+			}
+			userID := localUserID()
+			stats.NewRecorder(historyStore, userID).Subscribe(pomodoroEngine.GetEventBus())
+			app.SetHistoryStore(historyStore, userID)
+			pomodoroEngine.GetStats().ConfigureDayStreak(historyStore, userID, streakConfig)
+		}
+	}
+
+	// Recarga de la configuración al editar los archivos
+	if *watchConfig {
+		stopWatchers := startConfigWatchers(ctx, loader, *notificationsPath, pomodoroEngine, hookRunner, app)
+		defer stopWatchers()
+	}
+
+	// Ejecutar
+	if err := app.Run(ctx); err != nil {
+		log.Printf("Error ejecutando CLI: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// localUserID retorna el identificador del usuario local para el historial
+func localUserID() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
 	}
+	return "local"
 }
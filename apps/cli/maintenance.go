@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// runMaintenance ejecuta el subcomando de mantenimiento del historial y retorna el código de salida.
+// Uso: maintenance <verify|repair|compact> [-history archivo] [-keep días]
+func runMaintenance(args []string) int {
+	if len(args) == 0 {
+		printMaintenanceUsage()
+		return 2
+	}
+
+	action := args[0]
+	flags := flag.NewFlagSet("maintenance "+action, flag.ContinueOnError)
+	historyPath := flags.String("history", stats.DefaultHistoryPath(), "Archivo del historial de sesiones")
+	keepDays := flags.Int("keep", 90, "Días con sesiones individuales al compactar")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	switch action {
+	case "verify", "verificar":
+		check, err := stats.VerifyHistoryFile(*historyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		printHistoryCheck(check)
+		if !check.Healthy() {
+			fmt.Println("💡 Ejecuta 'maintenance repair' para corregirlo (con la aplicación cerrada)")
+			return 1
+		}
+		return 0
+
+	case "repair", "reparar":
+		check, err := stats.RepairHistoryFile(*historyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		printHistoryCheck(check)
+		if check.Healthy() {
+			fmt.Println("✅ El historial no necesitaba reparación")
+		} else {
+			fmt.Printf("✅ Historial reparado (copia del original en %s.bak)\n", *historyPath)
+		}
+		return 0
+
+	case "compact", "compactar":
+		if *keepDays < 1 {
+			fmt.Fprintln(os.Stderr, "❌ -keep debe ser al menos 1 día")
+			return 2
+		}
+		store, err := stats.OpenFileStore(*historyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		defer store.Close()
+
+		result, err := store.Compact(stats.RetentionPolicy{RawDays: *keepDays}, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		fmt.Printf("🗜️  %d sesiones anteriores al %s compactadas en %d días\n",
+			result.Compacted, result.Cutoff.Format("02/01/2006"), result.Days)
+		return 0
+
+	default:
+		printMaintenanceUsage()
+		return 2
+	}
+}
+
+// printHistoryCheck muestra el resultado de la verificación del historial
+func printHistoryCheck(check *stats.HistoryCheck) {
+	fmt.Printf("🗂️  %s\n", check.Path)
+	fmt.Printf("   Esquema: v%d (actual v%d)\n", check.SchemaVersion, stats.SchemaVersion)
+	fmt.Printf("   Líneas: %d | Sesiones válidas: %d | Días compactados: %d\n",
+		check.Lines, check.Sessions, check.DailyAggregates)
+
+	if len(check.CorruptLines) > 0 {
+		fmt.Printf("   ⚠️  Líneas ilegibles: %v\n", check.CorruptLines)
+	}
+	if len(check.InvalidLines) > 0 {
+		fmt.Printf("   ⚠️  Sesiones con datos no válidos: %v\n", check.InvalidLines)
+	}
+	if check.Duplicates > 0 {
+		fmt.Printf("   ⚠️  Duplicados: %d\n", check.Duplicates)
+	}
+	if check.Unsorted {
+		fmt.Println("   ℹ️  Sesiones fuera de orden (se ordenan al leerlas)")
+	}
+	if check.TruncatedTail {
+		fmt.Println("   ⚠️  Última línea incompleta")
+	}
+	if check.NeedsMigration() {
+		fmt.Println("   ⚠️  Esquema antiguo, necesita migración")
+	}
+	if check.Healthy() {
+		fmt.Println("   ✅ Sin problemas")
+	}
+}
+
+// printMaintenanceUsage muestra la ayuda del subcomando de mantenimiento
+func printMaintenanceUsage() {
+	fmt.Println("Uso: pomodoro maintenance <acción> [-history archivo]")
+	fmt.Println()
+	fmt.Println("Acciones:")
+	fmt.Println("   verify            Comprobar el historial sin modificarlo")
+	fmt.Println("   repair            Reescribir el historial sin líneas corruptas ni duplicados")
+	fmt.Println("   compact [-keep N] Compactar en agregados diarios las sesiones de más de N días")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kubaliski/pomodoro-core/config"
+)
+
+// runPresets ejecuta el subcomando de presets y retorna el código de salida.
+// Uso: presets [list] [-config archivo] | presets show [-config archivo] <nombre>
+func runPresets(args []string) int {
+	action := "list"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		action, args = args[0], args[1:]
+	}
+
+	loader := config.NewLoader()
+	flags := flag.NewFlagSet("presets", flag.ContinueOnError)
+	flags.Func("config", fmt.Sprintf("Archivo de configuración (por defecto %s)", loader.Path), func(value string) error {
+		loader.SetPath(value)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	registry, err := loader.Presets()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	switch action {
+	case "list", "listar":
+		printPresetList(registry)
+		return 0
+	case "show", "mostrar":
+		if flags.NArg() != 1 {
+			printPresetsUsage()
+			return 2
+		}
+		preset, err := registry.Get(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		printPreset(preset)
+		return 0
+	default:
+		printPresetsUsage()
+		return 2
+	}
+}
+
+// printPresetList muestra los presets disponibles en una línea cada uno
+func printPresetList(registry *config.PresetRegistry) {
+	fmt.Println("⏱️  Presets disponibles")
+	fmt.Println()
+	for _, preset := range registry.List() {
+		origin := "incluido"
+		if !preset.Builtin {
+			origin = "usuario"
+		}
+		fmt.Printf("   %-12s %-18s %-9s %s\n", preset.Name, preset.Summary(), origin, preset.Description)
+	}
+	fmt.Println()
+	fmt.Println("💡 Usa -preset nombre al iniciar o 'presets show nombre' para ver el detalle")
+}
+
+// printPreset muestra el detalle de un preset
+func printPreset(preset config.Preset) {
+	fmt.Printf("⏱️  Preset %s\n", preset.Name)
+	if preset.Description != "" {
+		fmt.Printf("   %s\n", preset.Description)
+	}
+	fmt.Println()
+	fmt.Printf("   • Trabajo: %s\n", config.FormatConfigDuration(preset.Config.WorkDuration))
+	fmt.Printf("   • Descanso corto: %s\n", config.FormatConfigDuration(preset.Config.ShortBreak))
+	fmt.Printf("   • Descanso largo: %s\n", config.FormatConfigDuration(preset.Config.LongBreak))
+	fmt.Printf("   • Descanso largo cada: %d pomodoros\n", preset.Config.LongBreakInterval)
+	if preset.Builtin {
+		fmt.Println("   • Origen: incluido en gomodoro")
+	} else {
+		fmt.Println("   • Origen: archivo de configuración")
+	}
+}
+
+// printPresetsUsage muestra la ayuda del subcomando de presets
+func printPresetsUsage() {
+	fmt.Println("Uso: pomodoro presets [list] [-config archivo]")
+	fmt.Println("     pomodoro presets show [-config archivo] <nombre>")
+	fmt.Println()
+	fmt.Println("Lista los presets de tiempos incluidos y los definidos en la clave 'presets'")
+	fmt.Println("del archivo de configuración, o muestra el detalle de uno.")
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kubaliski/pomodoro-cli/internal/daemon"
+	"github.com/kubaliski/pomodoro-cli/internal/statusbar"
+)
+
+// statusReconnectInterval es la espera entre intentos de reconexión en modo -watch
+const statusReconnectInterval = 5 * time.Second
+
+// runStatus ejecuta el subcomando status y retorna el código de salida (3 si el daemon no está en marcha).
+// Uso: status [-socket ruta] [-json | -format nombre | -template plantilla] [-watch]
+func runStatus(args []string) int {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	socketPath := flags.String("socket", daemon.DefaultSocketPath(), "Socket de control del daemon")
+	rawJSON := flags.Bool("json", false, "Mostrar la respuesta del daemon en JSON")
+	format := flags.String("format", "", fmt.Sprintf("Formato para barras de estado (%s)", strings.Join(statusbar.Names(), ", ")))
+	templateText := flags.String("template", "", "Plantilla de Go con los campos del estado (ej: '{{.Icon}} {{.Remaining}}')")
+	watch := flags.Bool("watch", false, "Escribir una línea nueva cada vez que cambia el estado")
+	flags.Usage = printStatusUsage
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		printStatusUsage()
+		return 2
+	}
+
+	formatter, err := statusFormatter(*format, *templateText, *rawJSON, *watch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	if *watch {
+		watchStatus(*socketPath, formatter)
+		return 0
+	}
+
+	client, err := daemon.Dial(*socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		fmt.Fprintln(os.Stderr, "💡 Inícialo con 'pomodoro daemon'")
+		return 3
+	}
+	defer client.Close()
+
+	status, err := client.Status()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	switch {
+	case *rawJSON:
+		line, _ := json.Marshal(status)
+		fmt.Println(string(line))
+	case formatter != nil:
+		line, err := formatter(statusbar.NewView(status))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		fmt.Println(line)
+	default:
+		printRemoteStatus(status)
+	}
+	return 0
+}
+
+// statusFormatter elige el formato de línea según los flags (nil para la salida normal)
+func statusFormatter(format, templateText string, rawJSON, watch bool) (statusbar.Formatter, error) {
+	selected := 0
+	for _, set := range []bool{format != "", templateText != "", rawJSON} {
+		if set {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return nil, fmt.Errorf("-json, -format y -template no se pueden combinar")
+	}
+
+	switch {
+	case templateText != "":
+		return statusbar.Template(templateText)
+	case format != "":
+		return statusbar.Builtin(format)
+	case watch && !rawJSON:
+		// En modo -watch cada cambio es una línea: sin formato se usa el de texto plano
+		return statusbar.Builtin("plain")
+	default:
+		return nil, nil
+	}
+}
+
+// watchStatus escribe una línea por cada cambio de estado, guiado por los eventos del daemon.
+// Si el daemon no responde escribe la línea "offline" y vuelve a intentarlo periódicamente.
+func watchStatus(socketPath string, formatter statusbar.Formatter) {
+	last := ""
+	emit := func(view statusbar.View, status *daemon.Status) {
+		var line string
+		var err error
+		switch {
+		case formatter != nil:
+			line, err = formatter(view)
+		case status == nil:
+			line = `{"offline":true}`
+		default:
+			data, _ := json.Marshal(status)
+			line = string(data)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return
+		}
+		if line != last {
+			fmt.Println(line)
+			last = line
+		}
+	}
+
+	lastErr := ""
+	for {
+		err := followStatus(socketPath, func(status daemon.Status) {
+			lastErr = ""
+			emit(statusbar.NewView(status), &status)
+		})
+		// El mismo error se informa una sola vez mientras el daemon siga sin responder
+		if err != nil && err.Error() != lastErr {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+			lastErr = err.Error()
+		}
+		emit(statusbar.OfflineView(), nil)
+		time.Sleep(statusReconnectInterval)
+	}
+}
+
+// followStatus llama a update con el estado inicial y tras cada evento del engine hasta que
+// se pierde la conexión. Usa dos conexiones: una suscrita a los eventos y otra para consultar
+// el estado completo, que incluye datos (como la tarea) que los eventos no llevan.
+func followStatus(socketPath string, update func(daemon.Status)) error {
+	events, err := daemon.Dial(socketPath)
+	if err != nil {
+		return err
+	}
+	defer events.Close()
+
+	queries, err := daemon.Dial(socketPath)
+	if err != nil {
+		return err
+	}
+	defer queries.Close()
+
+	var queryErr error
+	err = events.Subscribe(nil, update, func(daemon.RemoteEvent) {
+		if queryErr != nil {
+			return
+		}
+		status, err := queries.Status()
+		if err != nil {
+			queryErr = err
+			events.Close() // Termina la suscripción para reconectar
+			return
+		}
+		update(status)
+	})
+	if queryErr != nil {
+		return queryErr
+	}
+	return err
+}
+
+// printStatusUsage muestra la ayuda del subcomando status
+func printStatusUsage() {
+	fmt.Println("Uso: pomodoro status [-socket ruta] [-json | -format nombre | -template plantilla] [-watch]")
+	fmt.Println()
+	fmt.Printf("Formatos: %s\n", strings.Join(statusbar.Names(), ", "))
+	fmt.Println("Campos de plantilla: .Icon .Session .Kind .Class .Remaining .Total .Seconds .Percent")
+	fmt.Println("                     .Pomodoro .Completed .Task .Rule .Started .Paused .Offline")
+	fmt.Println()
+	fmt.Println("Ejemplos:")
+	fmt.Println("   pomodoro status -format tmux")
+	fmt.Println("   pomodoro status -format waybar -watch")
+	fmt.Println("   pomodoro status -template '{{.Icon}} {{.Remaining}}{{with .Task}} · {{.}}{{end}}'")
+}
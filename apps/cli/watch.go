@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubaliski/pomodoro-cli/internal/notifications"
+	"github.com/kubaliski/pomodoro-cli/internal/ui"
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/engine"
+	"github.com/kubaliski/pomodoro-core/hooks"
+)
+
+// startConfigWatchers vigila el archivo de configuración y el de notificaciones y aplica
+// los cambios (tiempos, alias y hooks) sin reiniciar la sesión. Si un archivo no es válido
+// se mantiene la configuración anterior. Retorna una función que detiene los watchers.
+func startConfigWatchers(ctx context.Context, loader *config.Loader, notificationsPath string, eng engine.EngineInterface, hookRunner *hooks.Runner, app frontend) func() {
+	reportReloadError := func(path string, err error) {
+		app.Notice(fmt.Sprintf("⚠️  No se aplicaron los cambios de %s, se mantiene la configuración anterior: %v", path, err))
+	}
+
+	watchers := make([]*config.Watcher, 0, 2)
+
+	if loader.Path != "" {
+		watcher := loader.Watch(config.DefaultWatchInterval,
+			func(resolved *config.Resolved) {
+				// El engine valida con los límites globales: se aplican los nuevos y, si
+				// rechaza la configuración, se restauran los anteriores
+				previousBounds := config.CurrentBounds()
+				if err := config.SetBounds(resolved.Bounds); err != nil {
+					reportReloadError(loader.Path, err)
+					return
+				}
+				if err := eng.UpdateConfig(resolved.Config); err != nil {
+					config.SetBounds(previousBounds)
+					reportReloadError(loader.Path, err)
+					return
+				}
+				hookRunner.Update(resolved.Hooks)
+				if aliases, ok := app.(aliasFrontend); ok {
+					if err := aliases.SetAliases(resolved.Aliases); err != nil {
+						app.Notice(fmt.Sprintf("⚠️  %v", err))
+					}
+				}
+				cfg := resolved.Config
+				app.Notice(fmt.Sprintf("🔄 Configuración recargada: trabajo %s · descanso %s · largo %s cada %d (desde la próxima sesión)",
+					ui.FormatDuration(cfg.WorkDuration), ui.FormatDuration(cfg.ShortBreak),
+					ui.FormatDuration(cfg.LongBreak), cfg.LongBreakInterval))
+			},
+			func(err error) { reportReloadError(loader.Path, err) },
+		)
+		watchers = append(watchers, watcher)
+	}
+
+	if notificationsPath != "" {
+		watcher := config.NewWatcher(notificationsPath, config.DefaultWatchInterval, func() {
+			notifConfig, err := notifications.LoadConfig(notificationsPath)
+			if err == nil {
+				err = app.GetNotificationManager().UpdateConfig(notifConfig)
+			}
+			if err != nil {
+				reportReloadError(notificationsPath, err)
+				return
+			}
+			app.Notice(fmt.Sprintf("🔄 Notificaciones recargadas desde %s", notificationsPath))
+		})
+		watchers = append(watchers, watcher)
+	}
+
+	for _, watcher := range watchers {
+		watcher.Start(ctx)
+	}
+
+	return func() {
+		for _, watcher := range watchers {
+			watcher.Stop()
+		}
+	}
+}
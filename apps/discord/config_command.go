@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/config"
+)
+
+// runConfig ejecuta el subcomando de configuración y retorna el código de salida.
+// Uso: config show [-config archivo] [-work 30m ...]
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "show" {
+		printConfigUsage()
+		return 2
+	}
+
+	loader := config.NewLoader()
+	flags := flag.NewFlagSet("config show", flag.ContinueOnError)
+	loader.RegisterFlags(flags)
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	resolved, err := loader.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	fmt.Println("⚙️  Effective default session config")
+	switch {
+	case resolved.Path != "":
+		fmt.Printf("   File: %s\n", resolved.Path)
+	case loader.Path != "":
+		fmt.Printf("   File: %s (not found)\n", loader.Path)
+	default:
+		fmt.Println("   File: none")
+	}
+	fmt.Println()
+
+	for _, entry := range resolved.Entries() {
+		fmt.Printf("   %-20s %-8s ← %s\n", entry.Key, entry.Value, entry.Origin)
+	}
+
+	if len(resolved.Config.Schedule) > 0 {
+		fmt.Println()
+		fmt.Println("   Schedule rules (first match wins):")
+		for _, rule := range resolved.Config.Schedule {
+			fmt.Printf("   %-20s %-24s %s\n", rule.Name, rule.Summary(), rule.Changes())
+		}
+		if _, name := resolved.Config.Resolve(time.Now()); name != "" {
+			fmt.Printf("   Active now: %s\n", name)
+		}
+	}
+
+	bounds := resolved.Bounds
+	fmt.Println()
+	fmt.Println("   Bounds (also used for slash command option limits):")
+	for _, r := range []struct {
+		key   string
+		value config.DurationRange
+	}{
+		{"work_duration", bounds.WorkDuration},
+		{"short_break", bounds.ShortBreak},
+		{"long_break", bounds.LongBreak},
+	} {
+		fmt.Printf("   %-20s %s – %s\n", r.key, config.FormatConfigDuration(r.value.Min), config.FormatConfigDuration(r.value.Max))
+	}
+	fmt.Printf("   %-20s %d – %d\n", "long_break_interval", bounds.LongBreakInterval.Min, bounds.LongBreakInterval.Max)
+	return 0
+}
+
+// printConfigUsage muestra la ayuda del subcomando de configuración
+func printConfigUsage() {
+	fmt.Println("Usage: bot config show [-config file] [-preset name] [config flags]")
+	fmt.Println()
+	fmt.Println("Shows the effective default session config and where each value comes from:")
+	fmt.Println("defaults, preset, config file, GOMODORO_* environment variables or flags.")
+}
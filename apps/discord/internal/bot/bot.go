@@ -8,6 +8,8 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kubaliski/gomodoro/apps/discord/internal/manager"
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/metrics"
 )
 
 // Bot representa el bot de Discord con arquitectura modular
@@ -30,7 +32,7 @@ func NewBot(token string, sessionManager *manager.SessionManager) (*Bot, error)
 	// Crear componentes modulares
 	notifier := NewNotificationManager(session)
 	eventHandler := NewEventHandler(sessionManager)
-	registry := NewCommandRegistry()
+	registry := NewCommandRegistry(config.CurrentBounds())
 
 	bot := &Bot{
 		session:        session,
@@ -46,6 +48,11 @@ func NewBot(token string, sessionManager *manager.SessionManager) (*Bot, error)
 	return bot, nil
 }
 
+// SetMetrics establece el collector de métricas de las notificaciones del bot
+func (b *Bot) SetMetrics(collector *metrics.Collector) {
+	b.notifier.SetMetrics(collector)
+}
+
 // Start inicia el bot y todos sus componentes
 func (b *Bot) Start(ctx context.Context) error {
 	if err := b.session.Open(); err != nil {
@@ -108,6 +115,14 @@ func (b *Bot) handleReady(s *discordgo.Session, r *discordgo.Ready) {
 
 // handleSlashCommand maneja todos los comandos slash
 func (b *Bot) handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		b.handleAutocomplete(s, i)
+		return
+	}
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
 	commandName := i.ApplicationCommandData().Name
 	log.Printf("📝 Received command: /%s from user %s", commandName, b.getInteractionUserID(i))
 
@@ -126,6 +141,16 @@ func (b *Bot) handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionC
 		b.handleStatusPomodoro(s, i)
 	case "pomodoro-stats":
 		b.handleStatsPomodoro(s, i)
+	case "pomodoro-report":
+		b.handleReportPomodoro(s, i)
+	case "pomodoro-export":
+		b.handleExportPomodoro(s, i)
+	case "pomodoro-task":
+		b.handleTaskPomodoro(s, i)
+	case "pomodoro-heatmap":
+		b.handleHeatmapPomodoro(s, i)
+	case "pomodoro-presets":
+		b.handlePresetsPomodoro(s, i)
 	default:
 		log.Printf("⚠️ Unknown command: %s", commandName)
 		respondWithError(s, i, "Comando no reconocido")
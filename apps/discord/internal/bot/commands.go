@@ -7,6 +7,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/events"
 	"github.com/kubaliski/pomodoro-core/stats"
 )
 
@@ -20,10 +21,24 @@ func (b *Bot) handleStartPomodoro(s *discordgo.Session, i *discordgo.Interaction
 
 	channelID := i.ChannelID
 
-	// Parsear opciones personalizadas
-	cfg := config.DefaultConfig()
+	// Parsear opciones personalizadas: el preset sustituye a la configuración por defecto
+	// (y a sus reglas de horario) y las duraciones indicadas lo ajustan
+	cfg := b.sessionManager.GetDefaultConfig()
 	options := i.ApplicationCommandData().Options
 
+	presetName := ""
+	for _, option := range options {
+		if option.Name == "preset" {
+			preset, err := b.sessionManager.GetPresets().Get(option.StringValue())
+			if err != nil {
+				respondWithError(s, i, fmt.Sprintf("El preset **%s** no existe. Usa `/pomodoro-presets` para ver los disponibles.", option.StringValue()))
+				return
+			}
+			cfg = preset.Config
+			presetName = preset.Name
+		}
+	}
+
 	for _, option := range options {
 		switch option.Name {
 		case "work":
@@ -32,7 +47,11 @@ func (b *Bot) handleStartPomodoro(s *discordgo.Session, i *discordgo.Interaction
 			cfg.ShortBreak = time.Duration(option.IntValue()) * time.Minute
 		case "long_break":
 			cfg.LongBreak = time.Duration(option.IntValue()) * time.Minute
+		default:
+			continue
 		}
+		// Los tiempos pedidos explícitamente no se cambian según el horario
+		cfg.Schedule = nil
 	}
 
 	// Validar configuración
@@ -48,19 +67,20 @@ func (b *Bot) handleStartPomodoro(s *discordgo.Session, i *discordgo.Interaction
 		return
 	}
 
-	// Crear respuesta pública en el canal
+	// Crear respuesta pública en el canal con los tiempos que aplican ahora
+	current, rule := session.Config.Resolve(time.Now())
 	embed := &discordgo.MessageEmbed{
 		Title: "🍅 ¡Pomodoro Iniciado!",
 		Description: fmt.Sprintf("Tu sesión comenzó con períodos de trabajo de %s.\n\n📱 **Las notificaciones se envían a tus mensajes privados**",
-			config.FormatDuration(session.Config.WorkDuration)),
+			config.FormatDuration(current.WorkDuration)),
 		Color: 0x00ff00,
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name: "⚙️ Configuración",
 				Value: fmt.Sprintf("**Trabajo:** %s\n**Descanso Corto:** %s\n**Descanso Largo:** %s",
-					config.FormatDuration(session.Config.WorkDuration),
-					config.FormatDuration(session.Config.ShortBreak),
-					config.FormatDuration(session.Config.LongBreak)),
+					config.FormatDuration(current.WorkDuration),
+					config.FormatDuration(current.ShortBreak),
+					config.FormatDuration(current.LongBreak)),
 				Inline: false,
 			},
 			{
@@ -74,6 +94,20 @@ func (b *Bot) handleStartPomodoro(s *discordgo.Session, i *discordgo.Interaction
 			Text: "Asegúrate de tener los DMs habilitados para la mejor experiencia",
 		},
 	}
+	if len(session.Config.Schedule) > 0 {
+		value := "Ninguna ahora (configuración base)"
+		if rule != "" {
+			value = rule
+		}
+		embed.Fields = append([]*discordgo.MessageEmbedField{
+			{Name: "🕒 Regla de horario", Value: value + fmt.Sprintf("\n%d reglas: los tiempos se ajustan al inicio de cada sesión", len(session.Config.Schedule)), Inline: false},
+		}, embed.Fields...)
+	}
+	if presetName != "" {
+		embed.Fields = append([]*discordgo.MessageEmbedField{
+			{Name: "⏱️ Preset", Value: presetName, Inline: false},
+		}, embed.Fields...)
+	}
 
 	// Responder en el canal público
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -228,19 +262,16 @@ func (b *Bot) handleStatusPomodoro(s *discordgo.Session, i *discordgo.Interactio
 	statusTitle := "Sesión de Trabajo"
 	statusColor := 0xff6b6b
 
-	// Convertir SessionType a string para comparar
-	sessionTypeStr := string(currentSession)
-
-	switch sessionTypeStr {
-	case "work":
+	switch currentSession {
+	case events.KindWork:
 		statusEmoji = "🍅"
 		statusTitle = "Sesión de Trabajo"
 		statusColor = 0xff6b6b
-	case "short_break":
+	case events.KindShortBreak:
 		statusEmoji = "☕"
 		statusTitle = "Descanso Corto"
 		statusColor = 0x4ecdc4
-	case "long_break":
+	case events.KindLongBreak:
 		statusEmoji = "🏖️"
 		statusTitle = "Descanso Largo"
 		statusColor = 0x45b7d1
@@ -287,6 +318,14 @@ func (b *Bot) handleStatsPomodoro(s *discordgo.Session, i *discordgo.Interaction
 		return
 	}
 
+	// Estadísticas agrupadas por proyecto, tarea o tag
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "group_by" {
+			b.handleGroupedStats(s, i, userID, option.StringValue())
+			return
+		}
+	}
+
 	session, err := b.sessionManager.GetSession(userID)
 	if err != nil {
 		respondWithError(s, i, "No tienes una sesión de pomodoro activa. Usa `/pomodoro` para iniciar una.")
@@ -318,6 +357,16 @@ func (b *Bot) handleStatsPomodoro(s *discordgo.Session, i *discordgo.Interaction
 				Value:  fmt.Sprintf("**Actual:** %d\n**Mejor:** %d", statsData.CurrentStreak, statsData.BestStreak),
 				Inline: true,
 			},
+			{
+				Name: "📆 Racha Diaria",
+				Value: fmt.Sprintf("**Actual:** %d días\n**Mejor:** %d días\n**Hoy:** %d/%d%s",
+					statsData.DayStreak.Current,
+					statsData.DayStreak.Best,
+					statsData.DayStreak.TodayCompleted,
+					statsData.DayStreak.MinPomodoros,
+					map[bool]string{true: " ✅", false: ""}[statsData.DayStreak.GoalMetToday]),
+				Inline: true,
+			},
 			{
 				Name: "⏱️ Tiempo Dedicado",
 				Value: fmt.Sprintf("**Trabajo:** %s\n**Descansos:** %s\n**Total:** %s",
@@ -327,8 +376,10 @@ func (b *Bot) handleStatsPomodoro(s *discordgo.Session, i *discordgo.Interaction
 				Inline: true,
 			},
 			{
-				Name:   "📈 Eficiencia",
-				Value:  fmt.Sprintf("**%.1f%%**\n`[%s]`", statsData.WorkEfficiency, efficiencyBar),
+				Name: "📈 Eficiencia",
+				Value: fmt.Sprintf("**%.1f%%**\n`[%s]`\n**Enfoque:** %.1f%%\n**Pausas:** %d (%s)",
+					statsData.WorkEfficiency, efficiencyBar,
+					statsData.FocusScore, statsData.TotalPauses, stats.FormatDuration(statsData.TotalPausedTime)),
 				Inline: true,
 			},
 			{
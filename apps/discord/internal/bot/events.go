@@ -121,6 +121,9 @@ func (eh *EventHandler) createPomodoroStartedHandler(notifier *NotificationManag
 				Text: "Elimina las distracciones y concéntrate",
 			},
 		}
+		if data.Rule != "" {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "🕒 Regla de horario", Value: data.Rule, Inline: true})
+		}
 
 		if err := notifier.SendNotification(userID, channelID, embed, ""); err != nil {
 			log.Printf("❌ Error sending pomodoro started notification: %v", err)
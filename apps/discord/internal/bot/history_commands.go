@@ -0,0 +1,370 @@
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// Nombres cortos de los días de la semana (time.Weekday empieza en domingo)
+var weekdayNames = []string{"Dom", "Lun", "Mar", "Mié", "Jue", "Vie", "Sáb"}
+
+// handleReportPomodoro maneja el comando de reporte del historial
+func (b *Bot) handleReportPomodoro(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userID, err := getUserID(i)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	store := b.sessionManager.GetHistoryStore()
+	if store == nil {
+		respondWithError(s, i, "El historial de sesiones no está disponible en este bot.")
+		return
+	}
+
+	period := stats.PeriodWeek
+	loc := time.Local
+
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "period":
+			period, err = stats.ParseReportPeriod(option.StringValue())
+			if err != nil {
+				respondWithError(s, i, "Período no válido. Usa día, semana o mes.")
+				return
+			}
+		case "timezone":
+			loc, err = time.LoadLocation(option.StringValue())
+			if err != nil {
+				respondWithError(s, i, fmt.Sprintf("Zona horaria `%s` no válida. Usa un nombre IANA como `Europe/Madrid`.", option.StringValue()))
+				return
+			}
+		}
+	}
+
+	report, err := stats.GenerateReport(store, userID, period, time.Now(), loc)
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Error al generar el reporte: %v", err))
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{buildReportEmbed(report)},
+		},
+	})
+}
+
+// handleExportPomodoro maneja el comando de exportación del historial como archivo adjunto
+func (b *Bot) handleExportPomodoro(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userID, err := getUserID(i)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	store := b.sessionManager.GetHistoryStore()
+	if store == nil {
+		respondWithError(s, i, "El historial de sesiones no está disponible en este bot.")
+		return
+	}
+
+	format := stats.FormatCSV
+	days := 30
+	loc := time.Local
+	query := stats.NewQuery().ForUser(userID)
+
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "type":
+			if option.StringValue() == "work" {
+				query.WorkOnly()
+			} else {
+				query.BreaksOnly()
+			}
+		case "project":
+			query.WithProject(option.StringValue())
+		case "tag":
+			query.WithTags(option.StringValue())
+		case "format":
+			format, err = stats.ParseExportFormat(option.StringValue())
+			if err != nil {
+				respondWithError(s, i, "Formato no válido. Usa CSV, Markdown, iCalendar o JSON.")
+				return
+			}
+		case "days":
+			days = int(option.IntValue())
+		case "timezone":
+			loc, err = time.LoadLocation(option.StringValue())
+			if err != nil {
+				respondWithError(s, i, fmt.Sprintf("Zona horaria `%s` no válida. Usa un nombre IANA como `Europe/Madrid`.", option.StringValue()))
+				return
+			}
+		}
+	}
+
+	result, err := query.Between(time.Now().AddDate(0, 0, -days), time.Time{}).Run(store)
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Error al leer el historial: %v", err))
+		return
+	}
+	records := result.Sessions
+
+	var buf bytes.Buffer
+	if err := stats.Export(&buf, format, records, loc); err != nil {
+		respondWithError(s, i, fmt.Sprintf("Error al exportar: %v", err))
+		return
+	}
+
+	fileName := fmt.Sprintf("gomodoro-%s.%s", time.Now().In(loc).Format("2006-01-02"), format.Extension())
+
+	// El archivo se envía de forma privada, solo lo ve quien ejecutó el comando
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("📦 Tu historial de los últimos %d días (%d sesiones)", days, len(records)),
+			Files: []*discordgo.File{
+				{
+					Name:        fileName,
+					ContentType: format.ContentType(),
+					Reader:      &buf,
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleHeatmapPomodoro maneja el comando del mapa de actividad por día y hora
+func (b *Bot) handleHeatmapPomodoro(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userID, err := getUserID(i)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	store := b.sessionManager.GetHistoryStore()
+	if store == nil {
+		respondWithError(s, i, "El historial de sesiones no está disponible en este bot.")
+		return
+	}
+
+	days := 30
+	loc := time.Local
+
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "days":
+			days = int(option.IntValue())
+		case "timezone":
+			loc, err = time.LoadLocation(option.StringValue())
+			if err != nil {
+				respondWithError(s, i, fmt.Sprintf("Zona horaria `%s` no válida. Usa un nombre IANA como `Europe/Madrid`.", option.StringValue()))
+				return
+			}
+		}
+	}
+
+	heatmap, err := stats.GenerateHeatmap(store, userID, time.Now().AddDate(0, 0, -days), time.Time{}, loc)
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Error al generar el mapa: %v", err))
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{buildHeatmapEmbed(heatmap, days)},
+		},
+	})
+}
+
+// buildHeatmapEmbed construye el embed con la cuadrícula día × hora en monoespaciado
+func buildHeatmapEmbed(heatmap *stats.Heatmap, days int) *discordgo.MessageEmbed {
+	description := fmt.Sprintf("Pomodoros por hora de inicio en los últimos %d días (%s)", days, heatmap.Location.String())
+
+	first, last, ok := heatmap.HourRange()
+	if !ok {
+		description += "\n\nNo hay pomodoros registrados en este período."
+		return &discordgo.MessageEmbed{
+			Title:       "🗓️ Mapa de actividad",
+			Description: description,
+			Color:       0x9b59b6,
+		}
+	}
+
+	// Cabecera con una etiqueta cada 3 horas
+	width := last - first + 1
+	header := []byte(strings.Repeat(" ", width+2))
+	for hour := first; hour <= last; hour++ {
+		if hour%3 == 0 {
+			copy(header[hour-first:], fmt.Sprintf("%d", hour))
+		}
+	}
+
+	var grid strings.Builder
+	grid.WriteString("    " + strings.TrimRight(string(header), " ") + "\n")
+	for _, day := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		grid.WriteString(fmt.Sprintf("%-4s", weekdayNames[day]))
+		for hour := first; hour <= last; hour++ {
+			grid.WriteString(heatmapGlyph(heatmap, day, hour))
+		}
+		grid.WriteString("\n")
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   "📊 Día × hora",
+			Value:  "```\n" + grid.String() + "```",
+			Inline: false,
+		},
+		{
+			Name:   "🍅 Pomodoros",
+			Value:  fmt.Sprintf("**Completados:** %d\n**Saltados:** %d", heatmap.Completed, heatmap.Skipped),
+			Inline: true,
+		},
+	}
+
+	if day, hour, ok := heatmap.Peak(); ok {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "🏆 Mejor franja",
+			Value:  fmt.Sprintf("%s %02d:00-%02d:00\n%d pomodoros", weekdayNames[day], hour, (hour+1)%24, heatmap.Cells[day][hour].Completed),
+			Inline: true,
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "🗓️ Mapa de actividad",
+		Description: description,
+		Color:       0x9b59b6,
+		Fields:      fields,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "· sin actividad  ░▒▓█ de menos a más  x solo saltados",
+		},
+	}
+}
+
+// heatmapGlyph retorna el carácter de una celda según su intensidad
+func heatmapGlyph(heatmap *stats.Heatmap, day time.Weekday, hour int) string {
+	cell := heatmap.Cells[day][hour]
+
+	switch intensity := heatmap.Intensity(day, hour); {
+	case cell.Completed == 0 && cell.Skipped > 0:
+		return "x"
+	case cell.Completed == 0:
+		return "·"
+	case intensity >= 1:
+		return "█"
+	case intensity >= 0.66:
+		return "▓"
+	case intensity >= 0.33:
+		return "▒"
+	default:
+		return "░"
+	}
+}
+
+// buildReportEmbed construye el embed de un reporte del historial
+func buildReportEmbed(report *stats.Report) *discordgo.MessageEmbed {
+	total := report.Total
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   "🍅 Pomodoros",
+			Value:  fmt.Sprintf("**Completados:** %d\n**Saltados:** %d", total.PomodorosCompleted, total.PomodorosSkipped),
+			Inline: true,
+		},
+		{
+			Name:   "☕ Descansos",
+			Value:  fmt.Sprintf("**Completados:** %d\n**Saltados:** %d", total.BreaksCompleted, total.BreaksSkipped),
+			Inline: true,
+		},
+		{
+			Name: "⏱️ Tiempo",
+			Value: fmt.Sprintf("**Enfoque:** %s\n**Descanso:** %s",
+				stats.FormatDuration(total.FocusTime),
+				stats.FormatDuration(total.BreakTime)),
+			Inline: true,
+		},
+		{
+			Name:   "📈 Eficiencia",
+			Value:  fmt.Sprintf("**%.1f%%**\n`[%s]`\n**Enfoque:** %.1f%% (%d pausas)", total.Efficiency, createProgressBar(total.Efficiency, 20), total.FocusScore, total.Pauses),
+			Inline: true,
+		},
+		{
+			Name:   "🔥 Racha más larga",
+			Value:  fmt.Sprintf("%d pomodoros", total.LongestStreak),
+			Inline: true,
+		},
+	}
+
+	if report.BestDay != nil {
+		value := fmt.Sprintf("**Mejor:** %s (%d 🍅)", reportDayLabel(*report.BestDay), report.BestDay.PomodorosCompleted)
+		if report.WorstDay != nil && report.WorstDay != report.BestDay {
+			value += fmt.Sprintf("\n**Peor:** %s (%d 🍅)", reportDayLabel(*report.WorstDay), report.WorstDay.PomodorosCompleted)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "🏆 Días", Value: value, Inline: true})
+	}
+
+	if len(report.Days) > 0 {
+		var lines strings.Builder
+		for _, day := range report.Days {
+			// Discord limita los campos a 1024 caracteres
+			if lines.Len() > 900 {
+				lines.WriteString("…\n")
+				break
+			}
+			lines.WriteString(fmt.Sprintf("%-9s 🍅 %2d ⏭️ %2d  %s\n",
+				reportDayLabel(day), day.PomodorosCompleted, day.PomodorosSkipped,
+				stats.FormatDuration(day.FocusTime)))
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "📊 Por día",
+			Value:  "```\n" + lines.String() + "```",
+			Inline: false,
+		})
+	}
+
+	description := fmt.Sprintf("Del %s al %s (%s)",
+		report.Start.Format("02/01/2006"),
+		report.End.AddDate(0, 0, -1).Format("02/01/2006"),
+		report.Location.String())
+	if len(report.Days) == 0 {
+		description += "\n\nNo hay sesiones registradas en este período."
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📅 Reporte %s", translateReportPeriod(report.Period)),
+		Description: description,
+		Color:       0x9b59b6,
+		Fields:      fields,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Basado en tu historial de sesiones",
+		},
+	}
+}
+
+// reportDayLabel formatea un día como "Lun 02/01"
+func reportDayLabel(day stats.PeriodAggregate) string {
+	return fmt.Sprintf("%s %s", weekdayNames[day.Start.Weekday()], day.Start.Format("02/01"))
+}
+
+// translateReportPeriod traduce el período del reporte
+func translateReportPeriod(period stats.ReportPeriod) string {
+	switch period {
+	case stats.PeriodDay:
+		return "Diario"
+	case stats.PeriodMonth:
+		return "Mensual"
+	default:
+		return "Semanal"
+	}
+}
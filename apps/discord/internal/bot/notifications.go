@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kubaliski/pomodoro-core/metrics"
 )
 
 // NotificationManager maneja el envío de notificaciones con lógica DM/fallback
@@ -16,6 +17,7 @@ type NotificationManager struct {
 	cacheMutex     sync.RWMutex
 	welcomeSent    map[string]bool // userID -> sent
 	welcomeMutex   sync.RWMutex
+	metrics        *metrics.Collector
 }
 
 // NewNotificationManager crea una nueva instancia del notification manager
@@ -27,6 +29,11 @@ func NewNotificationManager(session *discordgo.Session) *NotificationManager {
 	}
 }
 
+// SetMetrics establece el collector donde se cuentan fallbacks y notificaciones fallidas
+func (nm *NotificationManager) SetMetrics(collector *metrics.Collector) {
+	nm.metrics = collector
+}
+
 // SendNotification envía notificación a DM con fallback a canal
 // Esta es la función principal para todas las notificaciones automáticas
 func (nm *NotificationManager) SendNotification(userID, channelID string, embed *discordgo.MessageEmbed, mention string) error {
@@ -95,14 +102,14 @@ func (nm *NotificationManager) sendToDM(userID, channelID string, embed *discord
 	dmChannelID, err := nm.getOrCreateDMChannel(userID)
 	if err != nil {
 		log.Printf("📢 DM unavailable for user %s, using channel fallback: %v", userID, err)
-		return nm.sendToChannelFallback(channelID, embed, mention)
+		return nm.sendToChannelFallback(metrics.ReasonDMUnavailable, channelID, embed, mention)
 	}
 
 	// 2. Intentar enviar embed a DM
 	_, err = nm.session.ChannelMessageSendEmbed(dmChannelID, embed)
 	if err != nil {
 		log.Printf("📢 DM failed for user %s, using channel fallback: %v", userID, err)
-		return nm.sendToChannelFallback(channelID, embed, mention)
+		return nm.sendToChannelFallback(metrics.ReasonDMFailed, channelID, embed, mention)
 	}
 
 	// 3. Enviar mention por separado si es necesario
@@ -118,11 +125,15 @@ func (nm *NotificationManager) sendToDM(userID, channelID string, embed *discord
 	return nil
 }
 
-// sendToChannelFallback envía notificación al canal público como fallback
-func (nm *NotificationManager) sendToChannelFallback(channelID string, embed *discordgo.MessageEmbed, mention string) error {
+// sendToChannelFallback envía notificación al canal público como fallback.
+// reason indica por qué no se pudo usar el DM.
+func (nm *NotificationManager) sendToChannelFallback(reason, channelID string, embed *discordgo.MessageEmbed, mention string) error {
+	nm.metrics.DMFallback(reason)
+
 	// Enviar embed
 	_, err := nm.session.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil {
+		nm.metrics.NotificationFailed("discord")
 		return fmt.Errorf("failed to send fallback embed to channel %s: %w", channelID, err)
 	}
 
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kubaliski/pomodoro-core/config"
+)
+
+// maxAutocompleteChoices es el máximo de opciones que Discord acepta en un autocompletado
+const maxAutocompleteChoices = 25
+
+// handleAutocomplete responde a las sugerencias de las opciones con autocompletado
+func (b *Bot) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Focused {
+			focused = option
+			break
+		}
+	}
+	if focused == nil {
+		return
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0)
+	switch focused.Name {
+	case "preset", "name":
+		choices = b.presetChoices(focused.StringValue())
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Printf("❌ Error responding to autocomplete for /%s: %v", i.ApplicationCommandData().Name, err)
+	}
+}
+
+// presetChoices retorna los presets que coinciden con lo escrito por el usuario
+func (b *Bot) presetChoices(typed string) []*discordgo.ApplicationCommandOptionChoice {
+	matches := b.sessionManager.GetPresets().Match(typed)
+	if len(matches) > maxAutocompleteChoices {
+		matches = matches[:maxAutocompleteChoices]
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(matches))
+	for _, preset := range matches {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s (%s)", preset.Name, preset.Summary()),
+			Value: preset.Name,
+		})
+	}
+	return choices
+}
+
+// handlePresetsPomodoro lista los presets disponibles o muestra el detalle de uno
+func (b *Bot) handlePresetsPomodoro(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	presets := b.sessionManager.GetPresets()
+
+	var name string
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "name" {
+			name = strings.TrimSpace(option.StringValue())
+		}
+	}
+
+	var embed *discordgo.MessageEmbed
+	if name == "" {
+		embed = presetListEmbed(presets.List())
+	} else {
+		preset, err := presets.Get(name)
+		if err != nil {
+			respondWithError(s, i, fmt.Sprintf("El preset **%s** no existe. Usa `/pomodoro-presets` para ver los disponibles.", name))
+			return
+		}
+		embed = presetEmbed(preset)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// presetListEmbed crea el embed con todos los presets disponibles
+func presetListEmbed(presets []config.Preset) *discordgo.MessageEmbed {
+	lines := make([]string, 0, len(presets))
+	for _, preset := range presets {
+		line := fmt.Sprintf("**%s** · `%s`", preset.Name, preset.Summary())
+		if preset.Description != "" {
+			line += " — " + preset.Description
+		}
+		lines = append(lines, line)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "⏱️ Presets de tiempos",
+		Description: strings.Join(lines, "\n"),
+		Color:       0x3498db,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Usa /pomodoro preset:<nombre> para iniciar con uno",
+		},
+	}
+}
+
+// presetEmbed crea el embed con el detalle de un preset
+func presetEmbed(preset config.Preset) *discordgo.MessageEmbed {
+	origin := "Incluido"
+	if !preset.Builtin {
+		origin = "Configuración del bot"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("⏱️ Preset %s", preset.Name),
+		Description: preset.Description,
+		Color:       0x3498db,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Trabajo", Value: config.FormatDuration(preset.Config.WorkDuration), Inline: true},
+			{Name: "Descanso Corto", Value: config.FormatDuration(preset.Config.ShortBreak), Inline: true},
+			{Name: "Descanso Largo", Value: config.FormatDuration(preset.Config.LongBreak), Inline: true},
+			{Name: "Descanso Largo Cada", Value: fmt.Sprintf("%d pomodoros", preset.Config.LongBreakInterval), Inline: true},
+			{Name: "Origen", Value: origin, Inline: true},
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Usa /pomodoro preset:%s para iniciar con este preset", preset.Name),
+		},
+	}
+}
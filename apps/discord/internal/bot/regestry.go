@@ -3,8 +3,10 @@ package bot
 import (
 	"fmt"
 	"log"
+	"math"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kubaliski/pomodoro-core/config"
 )
 
 // CommandRegistry maneja el registro de comandos slash
@@ -12,8 +14,9 @@ type CommandRegistry struct {
 	commands []*discordgo.ApplicationCommand
 }
 
-// NewCommandRegistry crea una nueva instancia del registry
-func NewCommandRegistry() *CommandRegistry {
+// NewCommandRegistry crea una nueva instancia del registry; los límites de las opciones
+// de duración se generan a partir de los límites de validación de la configuración
+func NewCommandRegistry(bounds config.Bounds) *CommandRegistry {
 	return &CommandRegistry{
 		commands: []*discordgo.ApplicationCommand{
 			{
@@ -25,24 +28,44 @@ func NewCommandRegistry() *CommandRegistry {
 						Name:        "work",
 						Description: "Duración del trabajo en minutos (por defecto: 25)",
 						Required:    false,
-						MinValue:    func() *float64 { v := 1.0; return &v }(),
-						MaxValue:    120,
+						MinValue:    minMinutes(bounds.WorkDuration),
+						MaxValue:    maxMinutes(bounds.WorkDuration),
 					},
 					{
 						Type:        discordgo.ApplicationCommandOptionInteger,
 						Name:        "short_break",
 						Description: "Duración del descanso corto en minutos (por defecto: 5)",
 						Required:    false,
-						MinValue:    func() *float64 { v := 1.0; return &v }(),
-						MaxValue:    30,
+						MinValue:    minMinutes(bounds.ShortBreak),
+						MaxValue:    maxMinutes(bounds.ShortBreak),
 					},
 					{
 						Type:        discordgo.ApplicationCommandOptionInteger,
 						Name:        "long_break",
 						Description: "Duración del descanso largo en minutos (por defecto: 15)",
 						Required:    false,
-						MinValue:    func() *float64 { v := 5.0; return &v }(),
-						MaxValue:    60,
+						MinValue:    minMinutes(bounds.LongBreak),
+						MaxValue:    maxMinutes(bounds.LongBreak),
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "preset",
+						Description:  "Preset de tiempos (classic, 52-17, desk-time, short...); las duraciones indicadas lo ajustan",
+						Required:     false,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Name:        "pomodoro-presets",
+				Description: "Ver los presets de tiempos disponibles o el detalle de uno",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "name",
+						Description:  "Preset a describir (vacío para listarlos todos)",
+						Required:     false,
+						Autocomplete: true,
 					},
 				},
 			},
@@ -69,11 +92,165 @@ func NewCommandRegistry() *CommandRegistry {
 			{
 				Name:        "pomodoro-stats",
 				Description: "Ver tus estadísticas de pomodoro",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "group_by",
+						Description: "Agrupar tu historial por proyecto, tarea, tag o regla de horario",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Proyecto", Value: "project"},
+							{Name: "Tarea", Value: "task"},
+							{Name: "Tag", Value: "tag"},
+							{Name: "Regla de horario", Value: "rule"},
+						},
+					},
+				},
+			},
+			{
+				Name:        "pomodoro-task",
+				Description: "Asignar la tarea en la que estás trabajando",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "task",
+						Description: "Nombre de la tarea (vacío para quitarla)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "project",
+						Description: "Proyecto al que pertenece",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "tags",
+						Description: "Tags separados por comas",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "estimate",
+						Description: "Pomodoros estimados para la tarea",
+						Required:    false,
+						MinValue:    func() *float64 { v := 1.0; return &v }(),
+						MaxValue:    100,
+					},
+				},
+			},
+			{
+				Name:        "pomodoro-report",
+				Description: "Ver el reporte de tu historial de pomodoros",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "period",
+						Description: "Período del reporte (por defecto: semana)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Día", Value: "day"},
+							{Name: "Semana", Value: "week"},
+							{Name: "Mes", Value: "month"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "timezone",
+						Description: "Tu zona horaria IANA, por ejemplo Europe/Madrid (por defecto: la del servidor)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "pomodoro-export",
+				Description: "Exportar tu historial de pomodoros como archivo",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "format",
+						Description: "Formato del archivo (por defecto: CSV)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "CSV", Value: "csv"},
+							{Name: "Markdown", Value: "md"},
+							{Name: "iCalendar", Value: "ics"},
+							{Name: "JSON", Value: "json"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "days",
+						Description: "Número de días hacia atrás a incluir (por defecto: 30)",
+						Required:    false,
+						MinValue:    func() *float64 { v := 1.0; return &v }(),
+						MaxValue:    365,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "type",
+						Description: "Tipo de sesión a incluir (por defecto: todas)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Pomodoros", Value: "work"},
+							{Name: "Descansos", Value: "break"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "project",
+						Description: "Incluir solo un proyecto",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "tag",
+						Description: "Incluir solo sesiones con estos tags (separados por comas)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "timezone",
+						Description: "Tu zona horaria IANA para las fechas del archivo (por defecto: la del servidor)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "pomodoro-heatmap",
+				Description: "Ver en qué días y horas te concentras más",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "days",
+						Description: "Número de días hacia atrás a analizar (por defecto: 30)",
+						Required:    false,
+						MinValue:    func() *float64 { v := 1.0; return &v }(),
+						MaxValue:    365,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "timezone",
+						Description: "Tu zona horaria IANA, por ejemplo Europe/Madrid (por defecto: la del servidor)",
+						Required:    false,
+					},
+				},
 			},
 		},
 	}
 }
 
+// minMinutes retorna el mínimo de un rango en minutos enteros, redondeando hacia arriba
+func minMinutes(r config.DurationRange) *float64 {
+	v := math.Ceil(r.Min.Minutes())
+	return &v
+}
+
+// maxMinutes retorna el máximo de un rango en minutos enteros, redondeando hacia abajo
+func maxMinutes(r config.DurationRange) float64 {
+	return math.Floor(r.Max.Minutes())
+}
+
 // RegisterCommands registra todos los comandos slash con Discord
 func (cr *CommandRegistry) RegisterCommands(session *discordgo.Session) error {
 	log.Printf("📝 Registering %d slash commands...", len(cr.commands))
@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kubaliski/pomodoro-core/stats"
+)
+
+// handleTaskPomodoro maneja el comando para asignar la tarea actual
+func (b *Bot) handleTaskPomodoro(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userID, err := getUserID(i)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	var labels stats.Labels
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "task":
+			labels.Task = strings.TrimSpace(option.StringValue())
+		case "project":
+			labels.Project = strings.TrimSpace(option.StringValue())
+		case "tags":
+			labels.Tags = stats.ParseTags(option.StringValue())
+		case "estimate":
+			labels.Estimate = int(option.IntValue())
+		}
+	}
+
+	if err := b.sessionManager.SetSessionLabels(userID, labels); err != nil {
+		respondWithError(s, i, "No tienes una sesión de pomodoro activa. Usa `/pomodoro` para iniciar una.")
+		return
+	}
+
+	description := "Tarea eliminada. Los próximos pomodoros no tendrán etiqueta."
+	if !labels.IsEmpty() {
+		description = fmt.Sprintf("Trabajando en **%s**", labels.Summary())
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "🏷️ Tarea actualizada",
+					Description: description,
+					Color:       0x3498db,
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleGroupedStats muestra las estadísticas del usuario agrupadas por proyecto, tarea o tag
+func (b *Bot) handleGroupedStats(s *discordgo.Session, i *discordgo.InteractionCreate, userID, group string) {
+	groupBy, err := stats.ParseGroupBy(group)
+	if err != nil {
+		respondWithError(s, i, "Agrupación no válida. Usa proyecto, tarea, tag o regla.")
+		return
+	}
+
+	// Se usa el historial completo si existe; si no, solo la sesión activa
+	var result *stats.QueryResult
+	query := stats.NewQuery().ForUser(userID).WorkOnly()
+	if store := b.sessionManager.GetHistoryStore(); store != nil {
+		result, err = query.Run(store)
+		if err != nil {
+			respondWithError(s, i, fmt.Sprintf("Error al leer el historial: %v", err))
+			return
+		}
+	} else if session, err := b.sessionManager.GetSession(userID); err == nil {
+		result = session.Engine.GetStats().Query(query.ForUser(""))
+	} else {
+		respondWithError(s, i, "No tienes una sesión de pomodoro activa. Usa `/pomodoro` para iniciar una.")
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{buildGroupedStatsEmbed(stats.AggregateByLabel(result.CompletedSessions(), groupBy), groupBy)},
+		},
+	})
+}
+
+// buildGroupedStatsEmbed construye el embed con un campo por grupo
+func buildGroupedStatsEmbed(groups []stats.LabelStats, groupBy stats.GroupBy) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:     fmt.Sprintf("🏷️ Estadísticas por %s", translateGroupBy(groupBy)),
+		Color:     0x9b59b6,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	if len(groups) == 0 {
+		embed.Description = "No hay pomodoros registrados todavía."
+		return embed
+	}
+
+	// Discord permite como máximo 25 campos por embed
+	for index, group := range groups {
+		if index == 24 {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Y %d más…", len(groups)-index),
+			}
+			break
+		}
+
+		name := group.Key
+		if name == "" {
+			name = "(sin etiqueta)"
+			if groupBy == stats.GroupByRule {
+				name = "(configuración base)"
+			}
+		}
+
+		value := fmt.Sprintf("🍅 **%d** · ⏭️ %d (%.0f%%)\n⏱️ %s\n📅 %s → %s",
+			group.PomodorosCompleted, group.PomodorosSkipped, group.SkipRate,
+			stats.FormatDuration(group.FocusTime),
+			group.FirstWorked.Format("02/01"), group.LastWorked.Format("02/01"))
+		if group.HasEstimate() {
+			value += fmt.Sprintf("\n🎯 %d/%d `[%s]`", group.PomodorosCompleted, group.Estimate,
+				createProgressBar(group.EstimateProgress(), 10))
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   name,
+			Value:  value,
+			Inline: true,
+		})
+	}
+
+	return embed
+}
+
+// translateGroupBy traduce el criterio de agrupación
+func translateGroupBy(groupBy stats.GroupBy) string {
+	switch groupBy {
+	case stats.GroupByProject:
+		return "proyecto"
+	case stats.GroupByTag:
+		return "tag"
+	case stats.GroupByRule:
+		return "regla de horario"
+	default:
+		return "tarea"
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kubaliski/pomodoro-core/events"
 )
 
 // getUserID obtiene el ID del usuario de forma segura (funciona en canal y DM)
@@ -86,13 +87,13 @@ func translateState(state string) string {
 }
 
 // translateBreakType traduce el tipo de descanso
-func translateBreakType(breakType string) string {
+func translateBreakType(breakType events.SessionKind) string {
 	switch breakType {
-	case "DESCANSO":
+	case events.KindShortBreak:
 		return "Descanso Corto"
-	case "DESCANSO LARGO":
+	case events.KindLongBreak:
 		return "Descanso Largo"
 	default:
-		return breakType
+		return string(breakType)
 	}
 }
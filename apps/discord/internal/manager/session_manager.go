@@ -10,6 +10,8 @@ import (
 	"github.com/kubaliski/pomodoro-core/config"
 	"github.com/kubaliski/pomodoro-core/engine"
 	"github.com/kubaliski/pomodoro-core/events"
+	"github.com/kubaliski/pomodoro-core/metrics"
+	"github.com/kubaliski/pomodoro-core/stats"
 )
 
 // UserSession representa una sesión de pomodoro para un usuario específico
@@ -28,7 +30,11 @@ type SessionManager struct {
 	mu            sync.RWMutex
 	sessions      map[string]*UserSession // userID -> session
 	defaultConfig *config.Config
+	presets       *config.PresetRegistry
 	eventHandlers map[string]EventHandlerFunc
+	historyStore  stats.Store
+	streakConfig  stats.DayStreakConfig
+	metrics       *metrics.Collector
 }
 
 // EventHandlerFunc maneja eventos de Discord
@@ -39,7 +45,9 @@ func NewSessionManager(defaultConfig *config.Config) *SessionManager {
 	return &SessionManager{
 		sessions:      make(map[string]*UserSession),
 		defaultConfig: defaultConfig.Clone(),
+		presets:       config.NewPresetRegistry(),
 		eventHandlers: make(map[string]EventHandlerFunc),
+		streakConfig:  stats.DefaultDayStreakConfig(),
 	}
 }
 
@@ -191,6 +199,79 @@ func (sm *SessionManager) SkipSession(userID string) error {
 	return session.Engine.Skip()
 }
 
+// SetSessionLabels asigna la tarea, proyecto y tags de los pomodoros de un usuario
+func (sm *SessionManager) SetSessionLabels(userID string, labels stats.Labels) error {
+	session, err := sm.GetSession(userID)
+	if err != nil {
+		return err
+	}
+	log.Printf("🏷️ Setting task for user %s: %s", userID, labels.Summary())
+	session.Engine.SetLabels(labels)
+	return nil
+}
+
+// SetHistoryStore establece el historial persistente donde se guardan las sesiones de todos los usuarios
+func (sm *SessionManager) SetHistoryStore(store stats.Store) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.historyStore = store
+}
+
+// GetHistoryStore retorna el historial persistente (nil si no está configurado)
+func (sm *SessionManager) GetHistoryStore() stats.Store {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.historyStore
+}
+
+// SetDefaultConfig reemplaza la configuración de las nuevas sesiones sin configuración propia.
+// Las sesiones en curso conservan la suya.
+func (sm *SessionManager) SetDefaultConfig(cfg *config.Config) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.defaultConfig = cfg.Clone()
+	return nil
+}
+
+// GetDefaultConfig retorna una copia de la configuración de las sesiones sin configuración propia
+func (sm *SessionManager) GetDefaultConfig() *config.Config {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.defaultConfig.Clone()
+}
+
+// SetPresets establece los presets de tiempos disponibles para /pomodoro
+func (sm *SessionManager) SetPresets(presets *config.PresetRegistry) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.presets = presets
+}
+
+// GetPresets retorna los presets de tiempos disponibles
+func (sm *SessionManager) GetPresets() *config.PresetRegistry {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.presets
+}
+
+// SetDayStreakConfig establece el objetivo diario y los días de descanso de la racha diaria
+func (sm *SessionManager) SetDayStreakConfig(streakConfig stats.DayStreakConfig) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.streakConfig = streakConfig
+}
+
+// SetMetrics establece el collector de métricas al que se suscriben las nuevas sesiones
+func (sm *SessionManager) SetMetrics(collector *metrics.Collector) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.metrics = collector
+}
+
 // RegisterEventHandler registra un handler para eventos de Discord
 func (sm *SessionManager) RegisterEventHandler(eventType string, handler EventHandlerFunc) {
 	log.Printf("📝 Registering event handler for: %s", eventType)
@@ -203,6 +284,13 @@ func (sm *SessionManager) setupSessionEventHandlers(session *UserSession) {
 
 	log.Printf("🔧 Setting up event handlers for user %s", session.UserID)
 
+	// Guardar cada sesión terminada en el historial persistente
+	if sm.historyStore != nil {
+		stats.NewRecorder(sm.historyStore, session.UserID).Subscribe(eventBus)
+	}
+	session.Engine.GetStats().ConfigureDayStreak(sm.historyStore, session.UserID, sm.streakConfig)
+	sm.metrics.Subscribe(eventBus)
+
 	// Handler para eventos de pomodoro completado
 	eventBus.SubscribeFunc(events.PomodoroCompleted, func(event events.Event) {
 		log.Printf("🍅 PomodoroCompleted event received for user %s", session.UserID)
@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kubaliski/gomodoro/apps/discord/internal/bot"
 	"github.com/kubaliski/gomodoro/apps/discord/internal/manager"
 	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/metrics"
+	"github.com/kubaliski/pomodoro-core/stats"
 )
 
 func main() {
@@ -19,17 +24,78 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
+	// Subcomandos que no inician el bot
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+
+	// Configuración por defecto de las sesiones: valores por defecto, archivo, entorno (GOMODORO_*) y flags
+	loader := config.NewLoader()
+	loader.RegisterFlags(flag.CommandLine)
+
+	metricsAddr := flag.String("metrics-addr", os.Getenv("POMODORO_METRICS_ADDR"),
+		"Address to serve Prometheus metrics on /metrics (e.g. :9090, empty to disable)")
+	watchConfig := flag.Bool("watch-config", os.Getenv("POMODORO_WATCH_CONFIG") == "true",
+		"Reload the default session config when the config file changes")
+	flag.Parse()
+
 	// Cargar token del bot desde variable de entorno
 	token := os.Getenv("DISCORD_BOT_TOKEN")
 	if token == "" {
 		log.Fatal("DISCORD_BOT_TOKEN environment variable is required")
 	}
 
-	// Configuración por defecto del pomodoro
-	pomodoroConfig := config.DefaultConfig()
+	resolved, err := loader.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	// Límites de validación (también los de las opciones de los comandos slash)
+	if err := config.SetBounds(resolved.Bounds); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	pomodoroConfig := resolved.Config
+	log.Printf("⚙️ Default session config: %s", pomodoroConfig.String())
 
 	// Crear el manager de sesiones
 	sessionManager := manager.NewSessionManager(pomodoroConfig)
+	sessionManager.SetPresets(resolved.Presets)
+
+	// Historial persistente de sesiones de todos los usuarios
+	historyPath := os.Getenv("POMODORO_HISTORY_FILE")
+	if historyPath == "" {
+		historyPath = stats.DefaultHistoryPath()
+	}
+	historyStore, err := stats.OpenFileStore(historyPath)
+	if err != nil {
+		log.Printf("⚠️ Session history disabled: %v", err)
+	} else {
+		defer historyStore.Close()
+
+		// Retención: las sesiones de más de N días se compactan en agregados diarios
+		if days, err := strconv.Atoi(os.Getenv("POMODORO_RETENTION_DAYS")); err == nil && days > 0 {
+			result, err := historyStore.Compact(stats.RetentionPolicy{RawDays: days}, time.Now())
+			if err != nil {
+				log.Printf("⚠️ History compaction failed: %v", err)
+			} else if result.Compacted > 0 {
+				log.Printf("🗜️ Compacted %d sessions into %d daily aggregates", result.Compacted, result.Days)
+			}
+		}
+
+		sessionManager.SetHistoryStore(historyStore)
+		log.Printf("💾 Session history stored at %s", historyPath)
+	}
+
+	// Objetivo diario y días de descanso de la racha diaria
+	streakConfig := stats.DefaultDayStreakConfig()
+	if goal, err := strconv.Atoi(os.Getenv("POMODORO_DAILY_GOAL")); err == nil && goal > 0 {
+		streakConfig.MinPomodoros = goal
+	}
+	restDays, err := config.ParseWeekdays(os.Getenv("POMODORO_REST_DAYS"))
+	if err != nil {
+		log.Printf("⚠️ Invalid POMODORO_REST_DAYS: %v", err)
+	}
+	streakConfig.RestDays = restDays
+	sessionManager.SetDayStreakConfig(streakConfig)
 
 	// Crear y configurar el bot
 	discordBot, err := bot.NewBot(token, sessionManager)
@@ -37,10 +103,47 @@ func main() {
 		log.Fatalf("Failed to create bot: %v", err)
 	}
 
+	// Métricas de Prometheus
+	if *metricsAddr != "" {
+		collector := metrics.NewCollector()
+		server, err := collector.Registry().ListenAndServe(*metricsAddr)
+		if err != nil {
+			log.Printf("⚠️ Metrics disabled: %v", err)
+		} else {
+			defer server.Close()
+			sessionManager.SetMetrics(collector)
+			discordBot.SetMetrics(collector)
+			log.Printf("📈 Metrics available at http://%s/metrics", server.Addr)
+		}
+	}
+
 	// Crear contexto con cancelación
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Recarga de la configuración por defecto al editar el archivo
+	if *watchConfig && loader.Path != "" {
+		watcher := loader.Watch(config.DefaultWatchInterval,
+			func(resolved *config.Resolved) {
+				if resolved.Bounds != config.CurrentBounds() {
+					log.Printf("⚠️ Config bounds changed; restart the bot to apply them to validation and slash commands")
+				}
+				if err := sessionManager.SetDefaultConfig(resolved.Config); err != nil {
+					log.Printf("⚠️ Config reload rejected, keeping previous config: %v", err)
+					return
+				}
+				sessionManager.SetPresets(resolved.Presets)
+				log.Printf("🔄 Default session config reloaded: %s", resolved.Config.String())
+			},
+			func(err error) {
+				log.Printf("⚠️ Config reload failed, keeping previous config: %v", err)
+			},
+		)
+		watcher.Start(ctx)
+		defer watcher.Stop()
+		log.Printf("👀 Watching %s for changes", loader.Path)
+	}
+
 	// Iniciar el bot
 	if err := discordBot.Start(ctx); err != nil {
 		log.Fatalf("Failed to start bot: %v", err)
@@ -134,7 +134,7 @@ check_setup() {
 
     if [ "$bot_exists" = false ]; then
         echo "❌ Bot executable not found. Building it now..."
-        go build -o pomodoro-bot main.go
+        go build -o pomodoro-bot .
         if [ $? -ne 0 ]; then
             echo "❌ Failed to build bot. Make sure you're in the correct directory and Go is installed."
             return 1
@@ -226,7 +226,7 @@ start_bot() {
     # Compilar el bot si no existe
     if [ ! -f "./pomodoro-bot" ] && [ ! -f "./pomodoro-bot.exe" ]; then
         echo "🔨 Building bot..."
-        go build -o pomodoro-bot main.go
+        go build -o pomodoro-bot .
         if [ $? -ne 0 ]; then
             echo "❌ Failed to build bot"
             return 1
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// aliasesKey es la clave del archivo de configuración con los alias de comandos de la CLI
+const aliasesKey = "aliases"
+
+// aliasName son los nombres válidos de alias
+var aliasName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateAlias comprueba el nombre y el contenido de un alias de comandos
+// (ej: deep = "preset 90/20; task \"deep work\"; c")
+func ValidateAlias(name, commands string) error {
+	if !aliasName.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q (use lowercase letters, digits, '-' or '_')", name)
+	}
+	if strings.TrimSpace(strings.ReplaceAll(commands, ";", "")) == "" {
+		return fmt.Errorf("alias %s has no commands", name)
+	}
+	return nil
+}
+
+// parseAliases decodifica los alias definidos en el archivo de configuración (nombre -> comandos)
+func parseAliases(data json.RawMessage) (map[string]string, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("aliases must be a map of name to commands: %w", err)
+	}
+
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliases := make(map[string]string, len(raw))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if err := ValidateAlias(key, raw[name]); err != nil {
+			return nil, err
+		}
+		if _, exists := aliases[key]; exists {
+			return nil, fmt.Errorf("alias %s is defined twice", key)
+		}
+		aliases[key] = strings.TrimSpace(raw[name])
+	}
+	return aliases, nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DurationRange es un rango de duraciones permitido, con ambos extremos incluidos
+type DurationRange struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// IntRange es un rango de enteros permitido, con ambos extremos incluidos
+type IntRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Bounds son los límites que debe cumplir una configuración válida
+type Bounds struct {
+	WorkDuration      DurationRange `json:"work_duration"`
+	ShortBreak        DurationRange `json:"short_break"`
+	LongBreak         DurationRange `json:"long_break"`
+	LongBreakInterval IntRange      `json:"long_break_interval"`
+}
+
+// DefaultBounds retorna los límites por defecto
+func DefaultBounds() Bounds {
+	return Bounds{
+		WorkDuration:      DurationRange{Min: 1 * time.Minute, Max: 120 * time.Minute},
+		ShortBreak:        DurationRange{Min: 1 * time.Minute, Max: 30 * time.Minute},
+		LongBreak:         DurationRange{Min: 5 * time.Minute, Max: 60 * time.Minute},
+		LongBreakInterval: IntRange{Min: 2, Max: 10},
+	}
+}
+
+// Validate comprueba que cada rango tenga sentido (mínimo positivo y no mayor que el máximo)
+func (b Bounds) Validate() error {
+	var errs ValidationErrors
+
+	checkDuration := func(key string, r DurationRange) {
+		if r.Min <= 0 {
+			errs = append(errs, ValidationError{Field: "bounds." + key + ".min", Message: "must be positive"})
+		}
+		if r.Max < r.Min {
+			errs = append(errs, ValidationError{Field: "bounds." + key + ".max", Message: "must not be less than min"})
+		}
+	}
+	checkDuration("work_duration", b.WorkDuration)
+	checkDuration("short_break", b.ShortBreak)
+	checkDuration("long_break", b.LongBreak)
+
+	if b.LongBreakInterval.Min < 1 {
+		errs = append(errs, ValidationError{Field: "bounds.long_break_interval.min", Message: "must be at least 1"})
+	}
+	if b.LongBreakInterval.Max < b.LongBreakInterval.Min {
+		errs = append(errs, ValidationError{Field: "bounds.long_break_interval.max", Message: "must not be less than min"})
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// MarshalJSON serializa el rango con duraciones legibles
+func (r DurationRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Min jsonDuration `json:"min"`
+		Max jsonDuration `json:"max"`
+	}{jsonDuration(r.Min), jsonDuration(r.Max)})
+}
+
+// UnmarshalJSON lee el rango aceptando duraciones como texto o en nanosegundos.
+// Un extremo ausente conserva su valor actual.
+func (r *DurationRange) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		Min *jsonDuration `json:"min"`
+		Max *jsonDuration `json:"max"`
+	}{(*jsonDuration)(&r.Min), (*jsonDuration)(&r.Max)}
+	return json.Unmarshal(data, &aux)
+}
+
+var (
+	boundsMu      sync.RWMutex
+	currentBounds = DefaultBounds()
+)
+
+// CurrentBounds retorna los límites con los que Validate comprueba las configuraciones
+func CurrentBounds() Bounds {
+	boundsMu.RLock()
+	defer boundsMu.RUnlock()
+	return currentBounds
+}
+
+// SetBounds reemplaza los límites que usa Validate en todo el proceso.
+// Las aplicaciones lo llaman al arrancar con los límites de su configuración.
+func SetBounds(bounds Bounds) error {
+	if err := bounds.Validate(); err != nil {
+		return fmt.Errorf("invalid bounds: %w", err)
+	}
+
+	boundsMu.Lock()
+	defer boundsMu.Unlock()
+	currentBounds = bounds
+	return nil
+}
@@ -1,9 +1,8 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 )
 
@@ -13,11 +12,14 @@ type Config struct {
 	ShortBreak        time.Duration `json:"short_break"`
 	LongBreak         time.Duration `json:"long_break"`
 	LongBreakInterval int           `json:"long_break_interval"`
+
+	// Reglas por franja horaria y día de la semana; se resuelven al empezar cada sesión
+	Schedule []ScheduleRule `json:"schedule,omitempty"`
 }
 
 // ValidationError representa un error de validación de configuración
 type ValidationError struct {
-	Field   string
+	Field   string // Ruta del campo con las claves del archivo (ej: "work_duration", "presets.deep.short_break")
 	Message string
 }
 
@@ -25,6 +27,47 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("validation error in %s: %s", e.Field, e.Message)
 }
 
+// ValidationErrors son todos los errores de validación de una configuración
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = fmt.Sprintf("%s: %s", err.Field, err.Message)
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(errs), strings.Join(messages, "; "))
+}
+
+// Unwrap permite usar errors.As con un ValidationError concreto
+func (errs ValidationErrors) Unwrap() []error {
+	wrapped := make([]error, len(errs))
+	for i, err := range errs {
+		wrapped[i] = err
+	}
+	return wrapped
+}
+
+// WithPrefix antepone prefix a la ruta de cada campo (ej: "presets.deep.")
+func (errs ValidationErrors) WithPrefix(prefix string) ValidationErrors {
+	prefixed := make(ValidationErrors, len(errs))
+	for i, err := range errs {
+		prefixed[i] = ValidationError{Field: prefix + err.Field, Message: err.Message}
+	}
+	return prefixed
+}
+
+// ErrorOrNil retorna nil si no hay errores (evita un error no nil con una lista vacía)
+func (errs ValidationErrors) ErrorOrNil() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
 // DefaultConfig retorna la configuración por defecto
 func DefaultConfig() *Config {
 	return &Config{
@@ -35,124 +78,97 @@ func DefaultConfig() *Config {
 	}
 }
 
-// Validate valida que la configuración sea correcta
+// Validate valida la configuración con los límites actuales (ver SetBounds)
 func (c *Config) Validate() error {
-	if c.WorkDuration < 1*time.Minute {
-		return ValidationError{
-			Field:   "WorkDuration",
-			Message: "must be at least 1 minute",
-		}
-	}
-
-	if c.WorkDuration > 120*time.Minute {
-		return ValidationError{
-			Field:   "WorkDuration",
-			Message: "must be less than 2 hours",
-		}
-	}
-
-	if c.ShortBreak < 1*time.Minute {
-		return ValidationError{
-			Field:   "ShortBreak",
-			Message: "must be at least 1 minute",
-		}
-	}
+	return c.ValidateWithin(CurrentBounds())
+}
 
-	if c.ShortBreak > 30*time.Minute {
-		return ValidationError{
-			Field:   "ShortBreak",
-			Message: "must be less than 30 minutes",
-		}
-	}
+// ValidateWithin valida la configuración con los límites indicados y retorna
+// todos los errores encontrados como ValidationErrors
+func (c *Config) ValidateWithin(bounds Bounds) error {
+	var errs ValidationErrors
 
-	if c.LongBreak < 5*time.Minute {
-		return ValidationError{
-			Field:   "LongBreak",
-			Message: "must be at least 5 minutes",
+	checkDuration := func(key string, value time.Duration, r DurationRange) {
+		if value < r.Min {
+			errs = append(errs, ValidationError{Field: key, Message: "must be at least " + FormatConfigDuration(r.Min)})
 		}
-	}
-
-	if c.LongBreak > 60*time.Minute {
-		return ValidationError{
-			Field:   "LongBreak",
-			Message: "must be less than 1 hour",
+		if value > r.Max {
+			errs = append(errs, ValidationError{Field: key, Message: "must be at most " + FormatConfigDuration(r.Max)})
 		}
 	}
+	checkDuration("work_duration", c.WorkDuration, bounds.WorkDuration)
+	checkDuration("short_break", c.ShortBreak, bounds.ShortBreak)
+	checkDuration("long_break", c.LongBreak, bounds.LongBreak)
 
-	if c.LongBreakInterval < 2 {
-		return ValidationError{
-			Field:   "LongBreakInterval",
-			Message: "must be at least 2",
-		}
+	if c.LongBreakInterval < bounds.LongBreakInterval.Min {
+		errs = append(errs, ValidationError{
+			Field:   "long_break_interval",
+			Message: fmt.Sprintf("must be at least %d", bounds.LongBreakInterval.Min),
+		})
 	}
-
-	if c.LongBreakInterval > 10 {
-		return ValidationError{
-			Field:   "LongBreakInterval",
-			Message: "must be less than 10",
-		}
+	if c.LongBreakInterval > bounds.LongBreakInterval.Max {
+		errs = append(errs, ValidationError{
+			Field:   "long_break_interval",
+			Message: fmt.Sprintf("must be at most %d", bounds.LongBreakInterval.Max),
+		})
 	}
 
 	// Validación lógica: descanso largo debe ser mayor que el corto
 	if c.LongBreak <= c.ShortBreak {
-		return ValidationError{
-			Field:   "LongBreak",
+		errs = append(errs, ValidationError{
+			Field:   "long_break",
 			Message: "must be longer than short break",
-		}
+		})
 	}
 
-	return nil
+	errs = append(errs, c.validateSchedule(bounds)...)
+
+	return errs.ErrorOrNil()
 }
 
-// LoadFromFile carga la configuración desde un archivo JSON
+// LoadFromFile carga la configuración desde un archivo JSON, YAML o TOML según su extensión
 func LoadFromFile(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	config := DefaultConfig()
+	if err := ReadFile(path, config); err != nil {
+		return nil, err
 	}
 
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	return &config, nil
+	return config, nil
 }
 
-// SaveToFile guarda la configuración a un archivo JSON
+// SaveToFile guarda la configuración en un archivo JSON, YAML o TOML según su extensión
 func (c *Config) SaveToFile(path string) error {
 	if err := c.Validate(); err != nil {
 		return fmt.Errorf("cannot save invalid configuration: %w", err)
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
-
-	return nil
+	return WriteFile(path, c)
 }
 
 // Clone crea una copia profunda de la configuración
 func (c *Config) Clone() *Config {
-	return &Config{
+	clone := &Config{
 		WorkDuration:      c.WorkDuration,
 		ShortBreak:        c.ShortBreak,
 		LongBreak:         c.LongBreak,
 		LongBreakInterval: c.LongBreakInterval,
 	}
+	for _, rule := range c.Schedule {
+		clone.Schedule = append(clone.Schedule, rule.clone())
+	}
+	return clone
 }
 
 // String retorna una representación legible de la configuración
 func (c *Config) String() string {
+	if len(c.Schedule) > 0 {
+		return fmt.Sprintf("Config{Work: %v, Short: %v, Long: %v, Interval: %d, Rules: %d}",
+			c.WorkDuration, c.ShortBreak, c.LongBreak, c.LongBreakInterval, len(c.Schedule))
+	}
 	return fmt.Sprintf("Config{Work: %v, Short: %v, Long: %v, Interval: %d}",
 		c.WorkDuration, c.ShortBreak, c.LongBreak, c.LongBreakInterval)
 }
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jsonDuration serializa una duración como texto legible ("25m", "1h30m")
+// y acepta también el formato antiguo en nanosegundos
+type jsonDuration time.Duration
+
+// MarshalJSON implementa json.Marshaler
+func (d jsonDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FormatConfigDuration(time.Duration(d)))
+}
+
+// UnmarshalJSON implementa json.Unmarshaler
+func (d *jsonDuration) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		parsed, err := ParseConfigDuration(text)
+		if err != nil {
+			return err
+		}
+		*d = jsonDuration(parsed)
+		return nil
+	}
+
+	// Formato antiguo: nanosegundos
+	var nanos int64
+	if err := json.Unmarshal(data, &nanos); err != nil {
+		return fmt.Errorf("invalid duration %s: expected text like \"25m\" or nanoseconds", data)
+	}
+	*d = jsonDuration(nanos)
+	return nil
+}
+
+// ParseConfigDuration interpreta una duración de un archivo de configuración (ej: 25m, 1h30m, 90s)
+func ParseConfigDuration(text string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", text)
+	}
+	return d, nil
+}
+
+// FormatConfigDuration formatea una duración sin unidades a cero (25m en lugar de 25m0s)
+func FormatConfigDuration(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// configAlias evita la recursión al serializar Config
+type configAlias Config
+
+// MarshalJSON serializa la configuración con duraciones legibles
+func (c Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		WorkDuration jsonDuration `json:"work_duration"`
+		ShortBreak   jsonDuration `json:"short_break"`
+		LongBreak    jsonDuration `json:"long_break"`
+		configAlias
+	}{
+		configAlias:  configAlias(c),
+		WorkDuration: jsonDuration(c.WorkDuration),
+		ShortBreak:   jsonDuration(c.ShortBreak),
+		LongBreak:    jsonDuration(c.LongBreak),
+	})
+}
+
+// UnmarshalJSON lee la configuración aceptando duraciones como texto o en nanosegundos.
+// Los campos ausentes conservan su valor actual.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		WorkDuration *jsonDuration `json:"work_duration"`
+		ShortBreak   *jsonDuration `json:"short_break"`
+		LongBreak    *jsonDuration `json:"long_break"`
+		*configAlias
+	}{
+		configAlias:  (*configAlias)(c),
+		WorkDuration: (*jsonDuration)(&c.WorkDuration),
+		ShortBreak:   (*jsonDuration)(&c.ShortBreak),
+		LongBreak:    (*jsonDuration)(&c.LongBreak),
+	}
+	return json.Unmarshal(data, &aux)
+}
@@ -0,0 +1,455 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format es el formato de un archivo de configuración
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// FormatForPath elige el formato según la extensión del archivo (.json, .yaml, .yml o .toml)
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".toml":
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported config file extension %q (use .json, .yaml, .yml or .toml)", filepath.Ext(path))
+	}
+}
+
+// ToJSON convierte un documento YAML o TOML a JSON para decodificarlo con las etiquetas json existentes
+func ToJSON(format Format, data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	var err error
+
+	switch format {
+	case FormatJSON:
+		return data, nil
+	case FormatYAML:
+		doc, err = parseYAML(data)
+	case FormatTOML:
+		doc, err = parseTOML(data)
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// Unmarshal decodifica un documento en el formato indicado sobre v
+func Unmarshal(format Format, data []byte, v interface{}) error {
+	jsonData, err := ToJSON(format, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// Marshal codifica v en el formato indicado conservando el orden de los campos
+func Marshal(format Format, v interface{}) ([]byte, error) {
+	jsonData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatJSON:
+		return append(jsonData, '\n'), nil
+	case FormatYAML, FormatTOML:
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+
+	doc, err := decodeOrdered(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(*orderedMap)
+	if !ok {
+		return nil, fmt.Errorf("config document must be an object")
+	}
+
+	var buf bytes.Buffer
+	if format == FormatYAML {
+		writeYAMLMap(&buf, root, 0)
+	} else {
+		writeTOMLTable(&buf, root, nil)
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadFile lee un archivo de configuración en el formato de su extensión
+func ReadFile(path string, v interface{}) error {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := Unmarshal(format, data, v); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteFile escribe un archivo de configuración en el formato de su extensión
+func WriteFile(path string, v interface{}) error {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := Marshal(format, v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// FindConfigFile busca en dir el archivo name con cualquiera de las extensiones soportadas.
+// Si no existe ninguno retorna la ruta .json.
+func FindConfigFile(dir, name string) string {
+	for _, ext := range []string{".json", ".yaml", ".yml", ".toml"} {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, name+".json")
+}
+
+// orderedMap es un objeto JSON que conserva el orden de sus claves
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// decodeOrdered decodifica JSON en objetos ordenados, slices, json.Number, string, bool o nil
+func decodeOrdered(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeOrderedValue(decoder)
+}
+
+// decodeOrderedValue decodifica el siguiente valor del decoder
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("unexpected end of JSON")
+		}
+		return nil, err
+	}
+
+	switch delim := token.(type) {
+	case json.Delim:
+		switch delim {
+		case '{':
+			m := &orderedMap{values: make(map[string]interface{})}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := keyToken.(string)
+				value, err := decodeOrderedValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				if _, exists := m.values[key]; !exists {
+					m.keys = append(m.keys, key)
+				}
+				m.values[key] = value
+			}
+			_, err := decoder.Token()
+			return m, err
+		case '[':
+			list := make([]interface{}, 0)
+			for decoder.More() {
+				value, err := decodeOrderedValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			_, err := decoder.Token()
+			return list, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", delim)
+	default:
+		return token, nil
+	}
+}
+
+// quoteString entrecomilla un texto con los escapes comunes a YAML y TOML
+func quoteString(text string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || r == utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// unquoteString interpreta un texto entre comillas dobles con escapes de YAML/TOML
+func unquoteString(quoted string) (string, error) {
+	if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		return "", fmt.Errorf("invalid quoted string %s", quoted)
+	}
+
+	var b strings.Builder
+	body := quoted[1 : len(quoted)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(body) {
+			return "", fmt.Errorf("invalid escape at end of %s", quoted)
+		}
+		switch body[i] {
+		case '"', '\\', '/':
+			b.WriteByte(body[i])
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u', 'U':
+			size := 4
+			if body[i] == 'U' {
+				size = 8
+			}
+			if i+size >= len(body) {
+				return "", fmt.Errorf("invalid unicode escape in %s", quoted)
+			}
+			code, err := strconv.ParseUint(body[i+1:i+1+size], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid unicode escape in %s", quoted)
+			}
+			b.WriteRune(rune(code))
+			i += size
+		default:
+			return "", fmt.Errorf("invalid escape \\%c in %s", body[i], quoted)
+		}
+	}
+	return b.String(), nil
+}
+
+// flowParser decodifica listas y mapas en línea: [a, b] y {k: v} en YAML, {k = v} en TOML
+type flowParser struct {
+	text      string
+	pos       int
+	separator byte // Separador entre clave y valor de los mapas
+	scalar    func(text string) (interface{}, error)
+}
+
+// parseDocument decodifica el texto completo como un único valor
+func (p *flowParser) parseDocument() (interface{}, error) {
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.text) {
+		return nil, fmt.Errorf("unexpected %q after value", p.text[p.pos:])
+	}
+	return value, nil
+}
+
+// parseValue decodifica el siguiente valor
+func (p *flowParser) parseValue() (interface{}, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.text) {
+		return nil, fmt.Errorf("unexpected end of value")
+	}
+
+	switch p.text[p.pos] {
+	case '[':
+		return p.parseList()
+	case '{':
+		return p.parseMap()
+	case '"', '\'':
+		return p.parseQuoted()
+	default:
+		start := p.pos
+		for p.pos < len(p.text) && !strings.ContainsRune(",]}", rune(p.text[p.pos])) {
+			p.pos++
+		}
+		return p.scalar(strings.TrimSpace(p.text[start:p.pos]))
+	}
+}
+
+// parseList decodifica una lista; admite una coma final
+func (p *flowParser) parseList() (interface{}, error) {
+	p.pos++
+	list := make([]interface{}, 0)
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("unterminated inline list in %s", p.text)
+		}
+		if p.text[p.pos] == ']' {
+			p.pos++
+			return list, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+
+		if err := p.expectSeparator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseMap decodifica un mapa
+func (p *flowParser) parseMap() (interface{}, error) {
+	p.pos++
+	mapping := make(map[string]interface{})
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("unterminated inline map in %s", p.text)
+		}
+		if p.text[p.pos] == '}' {
+			p.pos++
+			return mapping, nil
+		}
+
+		var key string
+		if p.text[p.pos] == '"' || p.text[p.pos] == '\'' {
+			quoted, err := p.parseQuoted()
+			if err != nil {
+				return nil, err
+			}
+			key = quoted
+			p.skipSpaces()
+		} else {
+			start := p.pos
+			for p.pos < len(p.text) && p.text[p.pos] != p.separator && p.text[p.pos] != '}' {
+				p.pos++
+			}
+			key = strings.TrimSpace(p.text[start:p.pos])
+		}
+
+		if p.pos >= len(p.text) {
+			return nil, fmt.Errorf("unterminated inline map in %s", p.text)
+		}
+		if p.text[p.pos] != p.separator || key == "" {
+			return nil, fmt.Errorf("expected \"key %c value\" in %s", p.separator, p.text)
+		}
+		p.pos++
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		mapping[key] = value
+
+		if err := p.expectSeparator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// expectSeparator consume una coma o deja el cierre para la siguiente iteración
+func (p *flowParser) expectSeparator(closing byte) error {
+	p.skipSpaces()
+	switch {
+	case p.pos >= len(p.text):
+		kind := "list"
+		if closing == '}' {
+			kind = "map"
+		}
+		return fmt.Errorf("unterminated inline %s in %s", kind, p.text)
+	case p.text[p.pos] == ',':
+		p.pos++
+		return nil
+	case p.text[p.pos] == closing:
+		return nil
+	default:
+		return fmt.Errorf("expected ',' or '%c' in %s", closing, p.text)
+	}
+}
+
+// parseQuoted decodifica un texto entre comillas simples o dobles
+func (p *flowParser) parseQuoted() (string, error) {
+	end := closingQuote(p.text[p.pos:])
+	if end < 0 {
+		return "", fmt.Errorf("unterminated string in %s", p.text)
+	}
+
+	quoted := p.text[p.pos : p.pos+end+1]
+	p.pos += end + 1
+	if quoted[0] == '\'' {
+		return strings.ReplaceAll(quoted[1:len(quoted)-1], "''", "'"), nil
+	}
+	return unquoteString(quoted)
+}
+
+// skipSpaces avanza sobre espacios y saltos de línea
+func (p *flowParser) skipSpaces() {
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\r\n", rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
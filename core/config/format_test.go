@@ -0,0 +1,165 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{name: "scalars", input: "a: 1\nb: text\nc: true\n",
+			want: map[string]interface{}{"a": int64(1), "b": "text", "c": true}},
+		{name: "inline map", input: "a: {b: 1, c: x}\n",
+			want: map[string]interface{}{"a": map[string]interface{}{"b": int64(1), "c": "x"}}},
+		{name: "inline list", input: "a: [1, 2,]\n",
+			want: map[string]interface{}{"a": []interface{}{int64(1), int64(2)}}},
+		{name: "nested block", input: "a:\n  b: 1\n  c:\n    - x\n    - y\n",
+			want: map[string]interface{}{"a": map[string]interface{}{"b": int64(1), "c": []interface{}{"x", "y"}}}},
+
+		{name: "unterminated map", input: "a: {", wantErr: "unterminated inline map"},
+		{name: "unterminated map after comma", input: "a: {b: 1,", wantErr: "unterminated inline map"},
+		{name: "unterminated map after key", input: "a: {b", wantErr: "unterminated inline map"},
+		{name: "unterminated map after value", input: "a: {b: 1", wantErr: "unterminated inline map"},
+		{name: "unterminated list", input: "a: [", wantErr: "unterminated inline list"},
+		{name: "unterminated list after comma", input: "a: [1,", wantErr: "unterminated inline list"},
+		{name: "unterminated list after value", input: "a: [1", wantErr: "unterminated inline list"},
+		{name: "unterminated quoted key", input: `a: {"b: 1}`, wantErr: "unterminated string"},
+		{name: "missing separator", input: "a: {b 1}", wantErr: "expected"},
+		{name: "empty key", input: "a: {: 1}", wantErr: "expected"},
+		{name: "plain scalar with spaces", input: "a: [1 2]",
+			want: map[string]interface{}{"a": []interface{}{"1 2"}}},
+		{name: "trailing text", input: "a: [1] x", wantErr: "unexpected"},
+		{name: "invalid quoted value", input: `a: "b" c`, wantErr: "invalid quoted value"},
+		{name: "list item with non-ASCII space", input: "- \u00a0\n", wantErr: "must be a mapping"},
+		{name: "list item with vertical tab", input: "a:\n  - \v\n", want: map[string]interface{}{"a": []interface{}{nil}}},
+		{name: "empty quoted key", input: "'': 1\n", want: map[string]interface{}{"": int64(1)}},
+		{name: "block scalar", input: "a: |\n  text\n", wantErr: "not supported"},
+		{name: "anchor", input: "a: &x 1\n", wantErr: "not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML([]byte(tt.input))
+			checkParse(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{name: "scalars", input: "a = 1\nb = \"text\"\nc = true\n",
+			want: map[string]interface{}{"a": int64(1), "b": "text", "c": true}},
+		{name: "inline table", input: "a = {b = 1, c = \"x\"}\n",
+			want: map[string]interface{}{"a": map[string]interface{}{"b": int64(1), "c": "x"}}},
+		{name: "multi-line array", input: "a = [\n  1,\n  2,\n]\n",
+			want: map[string]interface{}{"a": []interface{}{int64(1), int64(2)}}},
+		{name: "table", input: "[a]\nb = 1\n",
+			want: map[string]interface{}{"a": map[string]interface{}{"b": int64(1)}}},
+
+		{name: "unterminated table", input: "a = {", wantErr: "unterminated inline map"},
+		{name: "unterminated table after comma", input: "a = {b = 1,", wantErr: "unterminated inline map"},
+		{name: "unterminated table after key", input: "a = {b", wantErr: "unterminated inline map"},
+		{name: "unterminated array", input: "a = [", wantErr: "unterminated inline list"},
+		{name: "unterminated array after comma", input: "a = [1,", wantErr: "unterminated inline list"},
+		{name: "missing value", input: "a =", wantErr: "unexpected end of value"},
+		{name: "missing equals", input: "a 1", wantErr: "expected \"key = value\""},
+		{name: "duplicate key", input: "a = 1\na = 2\n", wantErr: "duplicate key"},
+		{name: "unterminated header", input: "[a\n", wantErr: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTOML([]byte(tt.input))
+			checkParse(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+// TestTruncatedInput comprueba que ningún prefijo de un documento válido hace fallar al parser
+// con un pánico: un archivo a medio escribir debe dar un error o un documento válido
+func TestTruncatedInput(t *testing.T) {
+	documents := []struct {
+		name  string
+		parse func([]byte) (map[string]interface{}, error)
+		text  string
+	}{
+		{"yaml", parseYAML, "a: {b: 1, 'c d': [x, \"y\\\"z\", {e: f}]}\nlist:\n  - {g: [1, 2]}\n  - 'h'\nrules: [{days: [mon, fri], from: '09:00'}]\n"},
+		{"toml", parseTOML, "a = {b = 1, \"c d\" = [\"x\", 'y', {e = \"f\"}]}\n[[rules]]\ndays = [\"mon\",\n  \"fri\"]\nfrom = \"09:00\"\n"},
+	}
+
+	for _, doc := range documents {
+		for i := 0; i <= len(doc.text); i++ {
+			prefix := doc.text[:i]
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("%s: panic parsing %q: %v", doc.name, prefix, r)
+					}
+				}()
+				doc.parse([]byte(prefix))
+			}()
+		}
+	}
+}
+
+// checkParse compara el resultado de un parser con el documento o el error esperados.
+// wantErr vacío con want nil solo exige que haya error.
+func checkParse(t *testing.T, got map[string]interface{}, err error, want map[string]interface{}, wantErr string) {
+	t.Helper()
+	if want == nil {
+		if err == nil {
+			t.Fatalf("expected error containing %q, got %v", wantErr, got)
+		}
+		if !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("expected error containing %q, got %v", wantErr, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+// FuzzYAML comprueba que ninguna entrada hace fallar al parser de YAML con un pánico
+func FuzzYAML(f *testing.F) {
+	for _, seed := range []string{
+		"a: 1\nb: [x, {c: d}]\n",
+		"list:\n  - {g: [1, 2]}\n  - 'h'\n",
+		"- \u00a0\n",
+		"- \v\n",
+		"a: {",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ToJSON(FormatYAML, data)
+	})
+}
+
+// FuzzTOML comprueba que ninguna entrada hace fallar al parser de TOML con un pánico
+func FuzzTOML(f *testing.F) {
+	for _, seed := range []string{
+		"a = 1\n[b]\nc = [\"x\", {d = 1}]\n",
+		"[[rules]]\ndays = [\"mon\",\n  \"fri\"]\n",
+		"a = {",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ToJSON(FormatTOML, data)
+	})
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// hooksKey es la clave del archivo de configuración con los hooks de eventos
+const hooksKey = "hooks"
+
+// Valores por defecto y límites de los hooks
+const (
+	DefaultHookTimeout       = 10 * time.Second
+	DefaultHookMaxConcurrent = 4
+	maxHookTimeout           = 10 * time.Minute
+	maxHookConcurrent        = 64
+)
+
+// Hooks son los comandos de shell que se ejecutan cuando el engine publica un evento
+type Hooks struct {
+	Timeout       time.Duration                 // Tiempo máximo de cada comando
+	MaxConcurrent int                           // Comandos en ejecución a la vez como máximo
+	Commands      map[events.EventType][]string // Tipo de evento -> comandos, en orden
+}
+
+// DefaultHooks retorna la configuración de hooks sin comandos
+func DefaultHooks() Hooks {
+	return Hooks{Timeout: DefaultHookTimeout, MaxConcurrent: DefaultHookMaxConcurrent}
+}
+
+// Count retorna el número total de comandos configurados
+func (h Hooks) Count() int {
+	count := 0
+	for _, commands := range h.Commands {
+		count += len(commands)
+	}
+	return count
+}
+
+// EventTypes retorna los tipos de evento con hooks ordenados por nombre
+func (h Hooks) EventTypes() []events.EventType {
+	types := make([]events.EventType, 0, len(h.Commands))
+	for eventType := range h.Commands {
+		types = append(types, eventType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// parseHooks decodifica la clave hooks del archivo de configuración:
+//
+//	hooks:
+//	  timeout: 5s
+//	  max_concurrent: 2
+//	  on:
+//	    pomodoro_started: busylight on
+//	    pomodoro_completed: [busylight off, echo done >> ~/pomodoros.log]
+func parseHooks(data json.RawMessage) (Hooks, error) {
+	hooks := DefaultHooks()
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return hooks, fmt.Errorf("hooks must be a map with timeout, max_concurrent and on: %w", err)
+	}
+	for key := range raw {
+		if key != "timeout" && key != "max_concurrent" && key != "on" {
+			return hooks, fmt.Errorf("unknown key in hooks: %s", key)
+		}
+	}
+
+	if value, ok := raw["timeout"]; ok {
+		var timeout jsonDuration
+		if err := json.Unmarshal(value, &timeout); err != nil {
+			return hooks, fmt.Errorf("invalid hooks.timeout: %w", err)
+		}
+		hooks.Timeout = time.Duration(timeout)
+		if hooks.Timeout <= 0 || hooks.Timeout > maxHookTimeout {
+			return hooks, fmt.Errorf("hooks.timeout must be positive and at most %s", FormatConfigDuration(maxHookTimeout))
+		}
+	}
+
+	if value, ok := raw["max_concurrent"]; ok {
+		if err := json.Unmarshal(value, &hooks.MaxConcurrent); err != nil {
+			return hooks, fmt.Errorf("invalid hooks.max_concurrent: %w", err)
+		}
+		if hooks.MaxConcurrent < 1 || hooks.MaxConcurrent > maxHookConcurrent {
+			return hooks, fmt.Errorf("hooks.max_concurrent must be between 1 and %d", maxHookConcurrent)
+		}
+	}
+
+	value, ok := raw["on"]
+	if !ok {
+		return hooks, nil
+	}
+	var on map[string]json.RawMessage
+	if err := json.Unmarshal(value, &on); err != nil {
+		return hooks, fmt.Errorf("hooks.on must be a map of event type to commands: %w", err)
+	}
+
+	hooks.Commands = make(map[events.EventType][]string, len(on))
+	for name, value := range on {
+		eventType := events.EventType(name)
+		switch {
+		case !events.IsKnown(eventType):
+			return hooks, fmt.Errorf("unknown event type in hooks.on: %s", name)
+		case eventType == events.TimerTick:
+			return hooks, fmt.Errorf("hooks.on.%s: %s is published every second and cannot run hooks", name, name)
+		}
+
+		commands, err := parseHookCommands(value)
+		if err != nil {
+			return hooks, fmt.Errorf("invalid hooks.on.%s: %w", name, err)
+		}
+		hooks.Commands[eventType] = commands
+	}
+	return hooks, nil
+}
+
+// parseHookCommands acepta un comando o una lista de comandos
+func parseHookCommands(data json.RawMessage) ([]string, error) {
+	var commands []string
+	if err := json.Unmarshal(data, &commands); err != nil {
+		var command string
+		if err := json.Unmarshal(data, &command); err != nil {
+			return nil, fmt.Errorf("expected a command or a list of commands")
+		}
+		commands = []string{command}
+	}
+
+	for _, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			return nil, fmt.Errorf("empty command")
+		}
+	}
+	return commands, nil
+}
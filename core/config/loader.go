@@ -0,0 +1,521 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix es el prefijo de las variables de entorno de configuración
+const EnvPrefix = "GOMODORO_"
+
+// EnvConfigPath es la variable de entorno con la ruta del archivo de configuración
+const EnvConfigPath = EnvPrefix + "CONFIG"
+
+// EnvPreset es la variable de entorno con el preset de tiempos a usar
+const EnvPreset = EnvPrefix + "PRESET"
+
+// Source indica de qué capa proviene un valor de configuración
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourcePreset  Source = "preset"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Origin describe el origen de un valor: la capa y el archivo, variable o flag concreto
+type Origin struct {
+	Source Source
+	Name   string // Ruta del archivo, nombre de la variable o del flag ("" para default)
+}
+
+// String retorna el origen en formato legible (ej: "env GOMODORO_WORK")
+func (o Origin) String() string {
+	if o.Name == "" {
+		return string(o.Source)
+	}
+	return fmt.Sprintf("%s %s", o.Source, o.Name)
+}
+
+// field describe un valor de configuración y cómo leerlo de cada capa
+type field struct {
+	key   string // Clave en el archivo de configuración
+	env   string // Variable de entorno sin prefijo
+	flag  string // Nombre del flag
+	usage string
+	get   func(c *Config) string
+	set   func(c *Config, value string) error
+}
+
+// fields son los valores configurables por capas, en orden de presentación
+var fields = []field{
+	{
+		key: "work_duration", env: "WORK", flag: "work",
+		usage: "Duración de la sesión de trabajo",
+		get:   func(c *Config) string { return FormatConfigDuration(c.WorkDuration) },
+		set:   func(c *Config, value string) error { return setDuration(&c.WorkDuration, value) },
+	},
+	{
+		key: "short_break", env: "SHORT_BREAK", flag: "break",
+		usage: "Duración del descanso corto",
+		get:   func(c *Config) string { return FormatConfigDuration(c.ShortBreak) },
+		set:   func(c *Config, value string) error { return setDuration(&c.ShortBreak, value) },
+	},
+	{
+		key: "long_break", env: "LONG_BREAK", flag: "long",
+		usage: "Duración del descanso largo",
+		get:   func(c *Config) string { return FormatConfigDuration(c.LongBreak) },
+		set:   func(c *Config, value string) error { return setDuration(&c.LongBreak, value) },
+	},
+	{
+		key: "long_break_interval", env: "LONG_BREAK_INTERVAL", flag: "interval",
+		usage: "Número de pomodoros antes del descanso largo",
+		get:   func(c *Config) string { return strconv.Itoa(c.LongBreakInterval) },
+		set: func(c *Config, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid integer %q", value)
+			}
+			c.LongBreakInterval = n
+			return nil
+		},
+	},
+}
+
+// setDuration interpreta una duración en formato Go (ej: 25m, 1h30m)
+func setDuration(target *time.Duration, value string) error {
+	d, err := ParseConfigDuration(value)
+	if err != nil {
+		return err
+	}
+	*target = d
+	return nil
+}
+
+// Entry es un valor efectivo de la configuración con su origen
+type Entry struct {
+	Key    string
+	Value  string
+	Origin Origin
+}
+
+// Resolved es la configuración efectiva tras combinar todas las capas
+type Resolved struct {
+	Config  *Config
+	Path    string            // Archivo leído ("" si no se usó ninguno)
+	Preset  string            // Preset aplicado ("" si no se eligió ninguno)
+	Presets *PresetRegistry   // Presets incluidos y los definidos en el archivo
+	Bounds  Bounds            // Límites de validación (los por defecto con los del archivo)
+	Aliases map[string]string // Alias de comandos de la CLI definidos en el archivo (nombre -> comandos)
+	Hooks   Hooks             // Comandos de shell que se ejecutan con los eventos del engine
+	origin  map[string]Origin
+}
+
+// Origin retorna el origen del valor con la clave indicada
+func (r *Resolved) Origin(key string) Origin {
+	if origin, ok := r.origin[key]; ok {
+		return origin
+	}
+	return Origin{Source: SourceDefault}
+}
+
+// Entries retorna los valores efectivos con su origen, en orden de presentación
+// (el preset primero, si se eligió uno)
+func (r *Resolved) Entries() []Entry {
+	entries := make([]Entry, 0, len(fields)+1)
+	if r.Preset != "" {
+		entries = append(entries, Entry{Key: presetKey, Value: r.Preset, Origin: r.Origin(presetKey)})
+	}
+	for _, f := range fields {
+		entries = append(entries, Entry{
+			Key:    f.key,
+			Value:  f.get(r.Config),
+			Origin: r.Origin(f.key),
+		})
+	}
+	return entries
+}
+
+// Loader combina la configuración por capas con precedencia creciente:
+// valores por defecto, archivo, variables de entorno y flags
+type Loader struct {
+	Path      string // Archivo de configuración ("" para no leer ninguno)
+	Defaults  *Config
+	lookupEnv func(key string) (string, bool)
+	pathSet   bool              // La ruta se indicó explícitamente: si no existe es un error
+	flagVals  map[string]string // flag -> valor indicado en la línea de comandos
+}
+
+// NewLoader crea un loader con la ruta de configuración por defecto (o la de GOMODORO_CONFIG)
+func NewLoader() *Loader {
+	l := &Loader{
+		Path:      DefaultConfigPath(),
+		Defaults:  DefaultConfig(),
+		lookupEnv: os.LookupEnv,
+		flagVals:  make(map[string]string),
+	}
+	if path, ok := l.lookupEnv(EnvConfigPath); ok && path != "" {
+		l.Path = path
+		l.pathSet = true
+	}
+	return l
+}
+
+// DefaultConfigPath retorna la ruta del archivo de configuración según XDG
+// (config.json, config.yaml, config.yml o config.toml, el primero que exista)
+func DefaultConfigPath() string {
+	return FindConfigFile(ConfigDir(), "config")
+}
+
+// ConfigDir retorna el directorio de configuración de gomodoro según XDG
+func ConfigDir() string {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "gomodoro")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+	return filepath.Join(home, ".config", "gomodoro")
+}
+
+// SetPath establece explícitamente el archivo de configuración
+func (l *Loader) SetPath(path string) {
+	l.Path = path
+	l.pathSet = true
+}
+
+// RegisterFlags registra -config, -preset y un flag por cada valor configurable en el FlagSet.
+// Solo los flags indicados en la línea de comandos sobrescriben las demás capas.
+func (l *Loader) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("config", fmt.Sprintf("Archivo de configuración (por defecto %s)", l.Path), func(value string) error {
+		l.SetPath(value)
+		return nil
+	})
+
+	fs.Func(presetKey, "Preset de tiempos (ej: classic, 52-17, desk-time, short)", func(value string) error {
+		l.flagVals[presetKey] = value
+		return nil
+	})
+
+	for _, f := range fields {
+		fs.Var(&flagValue{loader: l, field: f}, f.flag, f.usage)
+	}
+}
+
+// flagValue implementa flag.Value para un valor configurable
+type flagValue struct {
+	loader *Loader
+	field  field
+}
+
+// String muestra el valor por defecto en la ayuda de los flags
+func (v *flagValue) String() string {
+	if v.loader == nil {
+		return ""
+	}
+	return v.field.get(v.loader.Defaults)
+}
+
+// Set valida y guarda el valor indicado en la línea de comandos
+func (v *flagValue) Set(value string) error {
+	if err := v.field.set(v.loader.Defaults.Clone(), value); err != nil {
+		return err
+	}
+	v.loader.flagVals[v.field.flag] = value
+	return nil
+}
+
+// Load combina las capas, valida el resultado y retorna la configuración efectiva.
+// Si se elige un preset (en el archivo, GOMODORO_PRESET o -preset) sus tiempos sustituyen
+// a los valores por defecto y las demás capas pueden ajustarlos.
+func (l *Loader) Load() (*Resolved, error) {
+	resolved := &Resolved{
+		Config: l.Defaults.Clone(),
+		Bounds: DefaultBounds(),
+		Hooks:  DefaultHooks(),
+		origin: make(map[string]Origin),
+	}
+
+	file, err := l.readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.applyBounds(resolved, file); err != nil {
+		return nil, err
+	}
+	resolved.Presets = newPresetRegistry(resolved.Bounds)
+
+	if err := l.applyPreset(resolved, file); err != nil {
+		return nil, err
+	}
+
+	if file != nil {
+		// Los campos ausentes conservan el valor de la capa anterior
+		if err := json.Unmarshal(file.data, resolved.Config); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", l.Path, err)
+		}
+		for _, f := range fields {
+			if _, ok := file.raw[f.key]; ok {
+				resolved.origin[f.key] = Origin{Source: SourceFile, Name: l.Path}
+			}
+		}
+		resolved.Path = l.Path
+
+		if data, ok := file.raw[aliasesKey]; ok {
+			if resolved.Aliases, err = parseAliases(data); err != nil {
+				return nil, fmt.Errorf("config file %s: %w", l.Path, err)
+			}
+		}
+		if data, ok := file.raw[hooksKey]; ok {
+			if resolved.Hooks, err = parseHooks(data); err != nil {
+				return nil, fmt.Errorf("config file %s: %w", l.Path, err)
+			}
+		}
+	}
+
+	for _, f := range fields {
+		name := EnvPrefix + f.env
+		value, ok := l.lookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := f.set(resolved.Config, value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		resolved.origin[f.key] = Origin{Source: SourceEnv, Name: name}
+	}
+
+	for _, f := range fields {
+		value, ok := l.flagVals[f.flag]
+		if !ok {
+			continue
+		}
+		if err := f.set(resolved.Config, value); err != nil {
+			return nil, fmt.Errorf("invalid -%s: %w", f.flag, err)
+		}
+		resolved.origin[f.key] = Origin{Source: SourceFlag, Name: "-" + f.flag}
+	}
+
+	if err := resolved.Config.ValidateWithin(resolved.Bounds); err != nil {
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			return nil, fmt.Errorf("invalid configuration: %w", resolved.withOrigins(errs))
+		}
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return resolved, nil
+}
+
+// Presets retorna los presets incluidos y los definidos en el archivo de configuración
+func (l *Loader) Presets() (*PresetRegistry, error) {
+	file, err := l.readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &Resolved{Bounds: DefaultBounds()}
+	if err := l.applyBounds(resolved, file); err != nil {
+		return nil, err
+	}
+
+	registry := newPresetRegistry(resolved.Bounds)
+	if err := l.registerFilePresets(registry, file); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// configFile es el contenido del archivo de configuración convertido a JSON
+type configFile struct {
+	data []byte
+	raw  map[string]json.RawMessage
+}
+
+// readFile lee el archivo de configuración y comprueba sus claves; retorna nil si no hay archivo
+func (l *Loader) readFile() (*configFile, error) {
+	if l.Path == "" {
+		return nil, nil
+	}
+
+	format, err := FormatForPath(l.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(l.Path)
+	if err != nil {
+		if os.IsNotExist(err) && !l.pathSet {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	data, err = ToJSON(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", l.Path, err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", l.Path, err)
+	}
+
+	known := map[string]bool{presetKey: true, presetsKey: true, boundsKey: true, scheduleKey: true, aliasesKey: true, hooksKey: true}
+	for _, f := range fields {
+		known[f.key] = true
+	}
+	unknown := make([]string, 0)
+	for key := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown keys in config file %s: %s", l.Path, strings.Join(unknown, ", "))
+	}
+
+	return &configFile{data: data, raw: raw}, nil
+}
+
+// applyBounds aplica los límites del archivo sobre los límites por defecto
+func (l *Loader) applyBounds(resolved *Resolved, file *configFile) error {
+	if file == nil {
+		return nil
+	}
+	data, ok := file.raw[boundsKey]
+	if !ok {
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("config file %s: bounds must be a map of field to range: %w", l.Path, err)
+	}
+	for key, value := range raw {
+		if !isFieldKey(key) {
+			return fmt.Errorf("config file %s: unknown key in bounds: %s", l.Path, key)
+		}
+		var limits map[string]json.RawMessage
+		if err := json.Unmarshal(value, &limits); err != nil {
+			return fmt.Errorf("config file %s: bounds.%s must have min and/or max: %w", l.Path, key, err)
+		}
+		for limit := range limits {
+			if limit != "min" && limit != "max" {
+				return fmt.Errorf("config file %s: unknown key in bounds.%s: %s", l.Path, key, limit)
+			}
+		}
+	}
+
+	if err := json.Unmarshal(data, &resolved.Bounds); err != nil {
+		return fmt.Errorf("config file %s: invalid bounds: %w", l.Path, err)
+	}
+	if err := resolved.Bounds.Validate(); err != nil {
+		return fmt.Errorf("config file %s: invalid bounds: %w", l.Path, err)
+	}
+	return nil
+}
+
+// registerFilePresets añade al registro los presets definidos en el archivo
+func (l *Loader) registerFilePresets(registry *PresetRegistry, file *configFile) error {
+	if file == nil {
+		return nil
+	}
+	data, ok := file.raw[presetsKey]
+	if !ok {
+		return nil
+	}
+
+	presets, err := parsePresets(data, l.Defaults)
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", l.Path, err)
+	}
+	for _, preset := range presets {
+		if err := registry.Register(preset); err != nil {
+			return fmt.Errorf("config file %s: %w", l.Path, err)
+		}
+	}
+	return nil
+}
+
+// applyPreset registra los presets del archivo y aplica el preset elegido en la capa de mayor prioridad
+func (l *Loader) applyPreset(resolved *Resolved, file *configFile) error {
+	if err := l.registerFilePresets(resolved.Presets, file); err != nil {
+		return err
+	}
+
+	var name string
+	var origin Origin
+
+	if file != nil {
+		if data, ok := file.raw[presetKey]; ok {
+			if err := json.Unmarshal(data, &name); err != nil {
+				return fmt.Errorf("config file %s: preset must be a string", l.Path)
+			}
+			origin = Origin{Source: SourceFile, Name: l.Path}
+		}
+	}
+	if value, ok := l.lookupEnv(EnvPreset); ok && value != "" {
+		name, origin = value, Origin{Source: SourceEnv, Name: EnvPreset}
+	}
+	if value, ok := l.flagVals[presetKey]; ok {
+		name, origin = value, Origin{Source: SourceFlag, Name: "-" + presetKey}
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	preset, err := resolved.Presets.Get(name)
+	if err != nil {
+		return fmt.Errorf("invalid preset (%s): %w", origin, err)
+	}
+
+	resolved.Config = preset.Config
+	resolved.Preset = preset.Name
+	resolved.origin[presetKey] = origin
+	for _, f := range fields {
+		resolved.origin[f.key] = Origin{Source: SourcePreset, Name: preset.Name}
+	}
+	return nil
+}
+
+// withOrigins añade a cada error de validación el origen del valor que lo provoca
+func (r *Resolved) withOrigins(errs ValidationErrors) ValidationErrors {
+	annotated := make(ValidationErrors, len(errs))
+	for i, err := range errs {
+		annotated[i] = err
+		switch {
+		case isFieldKey(err.Field):
+			annotated[i].Message = fmt.Sprintf("%s (from %s)", err.Message, r.Origin(err.Field))
+		case r.Path != "":
+			// Las reglas y los presets solo pueden venir del archivo
+			annotated[i].Message = fmt.Sprintf("%s (from %s)", err.Message, Origin{Source: SourceFile, Name: r.Path})
+		}
+	}
+	return annotated
+}
+
+// isFieldKey indica si key es la clave de un valor configurable
+func isFieldKey(key string) bool {
+	for _, f := range fields {
+		if f.key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Preset es una combinación de tiempos con nombre (ej: "classic", "52-17")
+type Preset struct {
+	Name        string
+	Description string
+	Config      *Config
+	Builtin     bool // Incluido en gomodoro (false si viene del archivo de configuración)
+}
+
+// Summary retorna los tiempos del preset en formato compacto (ej: "25m/5m/15m ×4")
+func (p Preset) Summary() string {
+	return fmt.Sprintf("%s/%s/%s ×%d",
+		FormatConfigDuration(p.Config.WorkDuration),
+		FormatConfigDuration(p.Config.ShortBreak),
+		FormatConfigDuration(p.Config.LongBreak),
+		p.Config.LongBreakInterval)
+}
+
+// presetName son los nombres válidos de preset
+var presetName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// BuiltinPresets retorna los presets incluidos en gomodoro
+func BuiltinPresets() []Preset {
+	builtin := func(name, description string, work, short, long time.Duration, interval int) Preset {
+		return Preset{
+			Name:        name,
+			Description: description,
+			Config: &Config{
+				WorkDuration:      work,
+				ShortBreak:        short,
+				LongBreak:         long,
+				LongBreakInterval: interval,
+			},
+			Builtin: true,
+		}
+	}
+
+	return []Preset{
+		builtin("classic", "Pomodoro clásico: 25 minutos de trabajo y 5 de descanso", 25*time.Minute, 5*time.Minute, 15*time.Minute, 4),
+		builtin("52-17", "52 minutos de trabajo y 17 de descanso", 52*time.Minute, 17*time.Minute, 30*time.Minute, 4),
+		builtin("desk-time", "Bloques largos de 90 minutos con 20 de descanso", 90*time.Minute, 20*time.Minute, 30*time.Minute, 3),
+		builtin("short", "Sesiones cortas para tareas pequeñas", 15*time.Minute, 3*time.Minute, 10*time.Minute, 4),
+	}
+}
+
+// PresetRegistry contiene los presets disponibles: los incluidos y los definidos por el usuario
+type PresetRegistry struct {
+	presets map[string]Preset
+	bounds  Bounds // Límites con los que se validan los presets registrados
+}
+
+// NewPresetRegistry crea un registro con los presets incluidos que valida con los límites actuales
+func NewPresetRegistry() *PresetRegistry {
+	return newPresetRegistry(CurrentBounds())
+}
+
+// newPresetRegistry crea un registro con los presets incluidos que valida con los límites indicados
+func newPresetRegistry(bounds Bounds) *PresetRegistry {
+	r := &PresetRegistry{presets: make(map[string]Preset), bounds: bounds}
+	for _, preset := range BuiltinPresets() {
+		r.presets[preset.Name] = preset
+	}
+	return r
+}
+
+// Register valida y añade un preset. Un preset del usuario con el nombre de uno incluido lo reemplaza.
+func (r *PresetRegistry) Register(preset Preset) error {
+	name := strings.ToLower(strings.TrimSpace(preset.Name))
+	if !presetName.MatchString(name) {
+		return fmt.Errorf("invalid preset name %q (use lowercase letters, digits, '-' or '_')", preset.Name)
+	}
+	if preset.Config == nil {
+		return fmt.Errorf("preset %s has no configuration", name)
+	}
+	if err := preset.Config.ValidateWithin(r.bounds); err != nil {
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			err = errs.WithPrefix(presetsKey + "." + name + ".")
+		}
+		return fmt.Errorf("invalid preset %s: %w", name, err)
+	}
+
+	preset.Name = name
+	preset.Config = preset.Config.Clone()
+	r.presets[name] = preset
+	return nil
+}
+
+// Get retorna el preset con el nombre indicado (sin distinguir mayúsculas)
+func (r *PresetRegistry) Get(name string) (Preset, error) {
+	preset, ok := r.presets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Preset{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(r.Names(), ", "))
+	}
+	preset.Config = preset.Config.Clone()
+	return preset, nil
+}
+
+// Names retorna los nombres de los presets ordenados alfabéticamente
+func (r *PresetRegistry) Names() []string {
+	names := make([]string, 0, len(r.presets))
+	for name := range r.presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List retorna los presets: primero los incluidos y después los del usuario, cada grupo por nombre
+func (r *PresetRegistry) List() []Preset {
+	list := make([]Preset, 0, len(r.presets))
+	for _, name := range r.Names() {
+		preset := r.presets[name]
+		preset.Config = preset.Config.Clone()
+		list = append(list, preset)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Builtin && !list[j].Builtin
+	})
+	return list
+}
+
+// Match retorna los presets cuyo nombre empieza por prefix o lo contiene, en ese orden (para autocompletado)
+func (r *PresetRegistry) Match(prefix string) []Preset {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	starts := make([]Preset, 0)
+	contains := make([]Preset, 0)
+	for _, preset := range r.List() {
+		switch {
+		case strings.HasPrefix(preset.Name, prefix):
+			starts = append(starts, preset)
+		case strings.Contains(preset.Name, prefix):
+			contains = append(contains, preset)
+		}
+	}
+	return append(starts, contains...)
+}
+
+// ParseTimings aplica sobre una copia de base unos tiempos en formato compacto
+// "trabajo/descanso[/descanso largo]" (ej: "90/20", "50m/10m/30m"). Los números sin unidad son minutos.
+func ParseTimings(spec string, base *Config) (*Config, error) {
+	parts := strings.Split(strings.TrimSpace(spec), "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid timings %q (use work/break or work/break/long, e.g. 90/20)", spec)
+	}
+
+	cfg := base.Clone()
+	targets := []*time.Duration{&cfg.WorkDuration, &cfg.ShortBreak, &cfg.LongBreak}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if _, err := strconv.Atoi(part); err == nil {
+			part += "m"
+		}
+		if err := setDuration(targets[i], part); err != nil {
+			return nil, fmt.Errorf("invalid timings %q: %w", spec, err)
+		}
+	}
+	return cfg, nil
+}
+
+// presetsKey es la clave del archivo de configuración con los presets del usuario
+const presetsKey = "presets"
+
+// presetKey es la clave del archivo de configuración con el preset a usar
+const presetKey = "preset"
+
+// boundsKey es la clave del archivo de configuración con los límites de validación
+const boundsKey = "bounds"
+
+// scheduleKey es la clave del archivo de configuración con las reglas por horario
+const scheduleKey = "schedule"
+
+// parsePresets decodifica los presets definidos en el archivo de configuración.
+// Los valores ausentes de cada preset toman el de base.
+func parsePresets(data json.RawMessage, base *Config) ([]Preset, error) {
+	var raw map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("presets must be a map of name to settings: %w", err)
+	}
+
+	known := map[string]bool{"description": true}
+	for _, f := range fields {
+		known[f.key] = true
+	}
+
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	presets := make([]Preset, 0, len(raw))
+	for _, name := range names {
+		values := raw[name]
+
+		unknown := make([]string, 0)
+		for key := range values {
+			if !known[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return nil, fmt.Errorf("unknown keys in preset %s: %s", name, strings.Join(unknown, ", "))
+		}
+
+		preset := Preset{Name: name, Config: base.Clone()}
+		if description, ok := values["description"]; ok {
+			if err := json.Unmarshal(description, &preset.Description); err != nil {
+				return nil, fmt.Errorf("invalid description in preset %s: %w", name, err)
+			}
+			delete(values, "description")
+		}
+
+		settings, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(settings, preset.Config); err != nil {
+			return nil, fmt.Errorf("invalid preset %s: %w", name, err)
+		}
+		presets = append(presets, preset)
+	}
+
+	return presets, nil
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleRule cambia los tiempos según la hora local y el día de la semana.
+// Los valores sin indicar se toman de la configuración base.
+type ScheduleRule struct {
+	Name string
+	Days []time.Weekday // Días en los que se aplica (vacío = todos)
+	// Franja horaria [Start, End) en minutos desde medianoche. Si End <= Start la franja
+	// cruza la medianoche y sus horas de madrugada cuentan como del día en que empezó
+	// ("fri 22:00-02:00" incluye el sábado a la 01:00); si ambas son nil se aplica todo el día.
+	Start *TimeOfDay
+	End   *TimeOfDay
+
+	WorkDuration      *time.Duration
+	ShortBreak        *time.Duration
+	LongBreak         *time.Duration
+	LongBreakInterval *int
+}
+
+// TimeOfDay es una hora del día en minutos desde medianoche ("09:30" = 570)
+type TimeOfDay int
+
+// ParseTimeOfDay interpreta una hora en formato HH:MM ("24:00" es el final del día)
+func ParseTimeOfDay(text string) (TimeOfDay, error) {
+	text = strings.TrimSpace(text)
+	if text == "24:00" {
+		return TimeOfDay(24 * 60), nil
+	}
+	t, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q (use HH:MM)", text)
+	}
+	return TimeOfDay(t.Hour()*60 + t.Minute()), nil
+}
+
+// String retorna la hora en formato HH:MM
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+// Matches indica si la regla se aplica en el instante indicado (en su zona horaria)
+func (r ScheduleRule) Matches(now time.Time) bool {
+	day := now.Weekday()
+
+	if r.Start != nil || r.End != nil {
+		minute := TimeOfDay(now.Hour()*60 + now.Minute())
+		start, end := TimeOfDay(0), TimeOfDay(24*60)
+		if r.Start != nil {
+			start = *r.Start
+		}
+		if r.End != nil {
+			end = *r.End
+		}
+
+		switch {
+		case start < end:
+			if minute < start || minute >= end {
+				return false
+			}
+		case minute >= start:
+			// La franja cruza la medianoche (ej: 22:00 - 02:00) y empezó hoy
+		case minute < end:
+			// Madrugada de una franja que empezó el día anterior
+			day = (day + 6) % 7
+		default:
+			return false
+		}
+	}
+
+	if len(r.Days) == 0 {
+		return true
+	}
+	for _, d := range r.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// apply retorna una copia de base con los valores indicados por la regla
+func (r ScheduleRule) apply(base *Config) *Config {
+	cfg := base.Clone()
+	cfg.Schedule = nil
+	if r.WorkDuration != nil {
+		cfg.WorkDuration = *r.WorkDuration
+	}
+	if r.ShortBreak != nil {
+		cfg.ShortBreak = *r.ShortBreak
+	}
+	if r.LongBreak != nil {
+		cfg.LongBreak = *r.LongBreak
+	}
+	if r.LongBreakInterval != nil {
+		cfg.LongBreakInterval = *r.LongBreakInterval
+	}
+	return cfg
+}
+
+// Summary retorna la condición de la regla en formato legible (ej: "mon,fri 09:00-13:00")
+func (r ScheduleRule) Summary() string {
+	parts := make([]string, 0, 2)
+	if len(r.Days) > 0 {
+		days := make([]string, len(r.Days))
+		for i, day := range r.Days {
+			days[i] = weekdayName(day)
+		}
+		parts = append(parts, strings.Join(days, ","))
+	}
+	if r.Start != nil || r.End != nil {
+		start, end := "00:00", "24:00"
+		if r.Start != nil {
+			start = r.Start.String()
+		}
+		if r.End != nil {
+			end = r.End.String()
+		}
+		parts = append(parts, start+"-"+end)
+	}
+	if len(parts) == 0 {
+		return "00:00-24:00" // Todos los días, todo el día
+	}
+	return strings.Join(parts, " ")
+}
+
+// Changes retorna los valores que cambia la regla (ej: "work_duration=50m short_break=10m")
+func (r ScheduleRule) Changes() string {
+	parts := make([]string, 0, 4)
+	if r.WorkDuration != nil {
+		parts = append(parts, "work_duration="+FormatConfigDuration(*r.WorkDuration))
+	}
+	if r.ShortBreak != nil {
+		parts = append(parts, "short_break="+FormatConfigDuration(*r.ShortBreak))
+	}
+	if r.LongBreak != nil {
+		parts = append(parts, "long_break="+FormatConfigDuration(*r.LongBreak))
+	}
+	if r.LongBreakInterval != nil {
+		parts = append(parts, fmt.Sprintf("long_break_interval=%d", *r.LongBreakInterval))
+	}
+	return strings.Join(parts, " ")
+}
+
+// clone crea una copia profunda de la regla
+func (r ScheduleRule) clone() ScheduleRule {
+	c := r
+	c.Days = append([]time.Weekday(nil), r.Days...)
+	if r.Start != nil {
+		start := *r.Start
+		c.Start = &start
+	}
+	if r.End != nil {
+		end := *r.End
+		c.End = &end
+	}
+	if r.WorkDuration != nil {
+		work := *r.WorkDuration
+		c.WorkDuration = &work
+	}
+	if r.ShortBreak != nil {
+		short := *r.ShortBreak
+		c.ShortBreak = &short
+	}
+	if r.LongBreak != nil {
+		long := *r.LongBreak
+		c.LongBreak = &long
+	}
+	if r.LongBreakInterval != nil {
+		interval := *r.LongBreakInterval
+		c.LongBreakInterval = &interval
+	}
+	return c
+}
+
+// Resolve retorna la configuración que corresponde al instante indicado y el nombre
+// de la regla aplicada ("" si no se aplica ninguna). Gana la primera regla que coincida.
+func (c *Config) Resolve(now time.Time) (*Config, string) {
+	for _, rule := range c.Schedule {
+		if rule.Matches(now) {
+			return rule.apply(c), rule.Name
+		}
+	}
+
+	cfg := c.Clone()
+	cfg.Schedule = nil
+	return cfg, ""
+}
+
+// validateSchedule valida las reglas y la configuración efectiva de cada una
+func (c *Config) validateSchedule(bounds Bounds) ValidationErrors {
+	var errs ValidationErrors
+	seen := make(map[string]bool, len(c.Schedule))
+
+	for i, rule := range c.Schedule {
+		prefix := fmt.Sprintf("schedule[%d].", i)
+		if rule.Name == "" {
+			errs = append(errs, ValidationError{Field: prefix + "name", Message: "is required"})
+		} else {
+			prefix = fmt.Sprintf("schedule.%s.", rule.Name)
+			if seen[rule.Name] {
+				errs = append(errs, ValidationError{Field: prefix + "name", Message: "is duplicated"})
+			}
+			seen[rule.Name] = true
+		}
+
+		if rule.Start != nil && rule.End != nil && *rule.Start == *rule.End {
+			errs = append(errs, ValidationError{Field: prefix + "end", Message: "must differ from start"})
+		}
+
+		// Solo se informan los errores de los valores que cambia la regla;
+		// los de la configuración base ya se informan sin prefijo
+		overrides := map[string]bool{
+			"work_duration":       rule.WorkDuration != nil,
+			"short_break":         rule.ShortBreak != nil,
+			"long_break":          rule.LongBreak != nil || rule.ShortBreak != nil,
+			"long_break_interval": rule.LongBreakInterval != nil,
+		}
+		if err := rule.apply(c).ValidateWithin(bounds); err != nil {
+			ruleErrs, _ := err.(ValidationErrors)
+			for _, ruleErr := range ruleErrs {
+				if overrides[ruleErr.Field] {
+					errs = append(errs, ValidationError{Field: prefix + ruleErr.Field, Message: ruleErr.Message})
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// jsonScheduleRule es la forma de una regla en los archivos de configuración
+type jsonScheduleRule struct {
+	Name              string        `json:"name"`
+	Days              []string      `json:"days,omitempty"`
+	Start             string        `json:"start,omitempty"`
+	End               string        `json:"end,omitempty"`
+	WorkDuration      *jsonDuration `json:"work_duration,omitempty"`
+	ShortBreak        *jsonDuration `json:"short_break,omitempty"`
+	LongBreak         *jsonDuration `json:"long_break,omitempty"`
+	LongBreakInterval *int          `json:"long_break_interval,omitempty"`
+}
+
+// MarshalJSON serializa la regla con días por nombre, horas HH:MM y duraciones legibles
+func (r ScheduleRule) MarshalJSON() ([]byte, error) {
+	aux := jsonScheduleRule{
+		Name:              r.Name,
+		WorkDuration:      (*jsonDuration)(r.WorkDuration),
+		ShortBreak:        (*jsonDuration)(r.ShortBreak),
+		LongBreak:         (*jsonDuration)(r.LongBreak),
+		LongBreakInterval: r.LongBreakInterval,
+	}
+	for _, day := range r.Days {
+		aux.Days = append(aux.Days, weekdayName(day))
+	}
+	if r.Start != nil {
+		aux.Start = r.Start.String()
+	}
+	if r.End != nil {
+		aux.End = r.End.String()
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON lee una regla rechazando claves desconocidas
+func (r *ScheduleRule) UnmarshalJSON(data []byte) error {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	for key := range keys {
+		switch key {
+		case "name", "days", "start", "end", "work_duration", "short_break", "long_break", "long_break_interval":
+		default:
+			return fmt.Errorf("unknown key in schedule rule: %s", key)
+		}
+	}
+
+	var aux jsonScheduleRule
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	rule := ScheduleRule{
+		Name:              strings.TrimSpace(aux.Name),
+		WorkDuration:      (*time.Duration)(aux.WorkDuration),
+		ShortBreak:        (*time.Duration)(aux.ShortBreak),
+		LongBreak:         (*time.Duration)(aux.LongBreak),
+		LongBreakInterval: aux.LongBreakInterval,
+	}
+
+	if len(aux.Days) > 0 {
+		days, err := ParseWeekdays(strings.Join(aux.Days, ","))
+		if err != nil {
+			return fmt.Errorf("schedule rule %q: %w", rule.Name, err)
+		}
+		rule.Days = days
+	}
+	if aux.Start != "" {
+		start, err := ParseTimeOfDay(aux.Start)
+		if err != nil {
+			return fmt.Errorf("schedule rule %q: %w", rule.Name, err)
+		}
+		rule.Start = &start
+	}
+	if aux.End != "" {
+		end, err := ParseTimeOfDay(aux.End)
+		if err != nil {
+			return fmt.Errorf("schedule rule %q: %w", rule.Name, err)
+		}
+		rule.End = &end
+	}
+
+	*r = rule
+	return nil
+}
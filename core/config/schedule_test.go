@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleRuleMatches(t *testing.T) {
+	at := func(day time.Weekday, hour, minute int) time.Time {
+		// 2026-01-04 es domingo
+		return time.Date(2026, 1, 4+int(day), hour, minute, 0, 0, time.UTC)
+	}
+	tod := func(text string) *TimeOfDay {
+		value, err := ParseTimeOfDay(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &value
+	}
+
+	night := ScheduleRule{Name: "night", Days: []time.Weekday{time.Friday}, Start: tod("22:00"), End: tod("02:00")}
+	morning := ScheduleRule{Name: "morning", Days: []time.Weekday{time.Monday, time.Tuesday}, Start: tod("09:00"), End: tod("13:00")}
+	weekend := ScheduleRule{Name: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}}
+	everyNight := ScheduleRule{Name: "every-night", Start: tod("23:00"), End: tod("01:00")}
+
+	tests := []struct {
+		name string
+		rule ScheduleRule
+		now  time.Time
+		want bool
+	}{
+		{"night starts on friday", night, at(time.Friday, 22, 0), true},
+		{"night before start", night, at(time.Friday, 21, 59), false},
+		{"night carries into saturday", night, at(time.Saturday, 1, 30), true},
+		{"night ends on saturday", night, at(time.Saturday, 2, 0), false},
+		{"night early friday belongs to thursday", night, at(time.Friday, 1, 0), false},
+		{"night not on saturday evening", night, at(time.Saturday, 23, 0), false},
+		{"morning inside", morning, at(time.Tuesday, 12, 59), true},
+		{"morning end excluded", morning, at(time.Tuesday, 13, 0), false},
+		{"morning other day", morning, at(time.Wednesday, 10, 0), false},
+		{"weekend all day", weekend, at(time.Sunday, 0, 0), true},
+		{"weekend not monday", weekend, at(time.Monday, 0, 0), false},
+		{"every night after midnight", everyNight, at(time.Monday, 0, 30), true},
+		{"every night before start", everyNight, at(time.Monday, 22, 30), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.now); got != tt.want {
+				t.Fatalf("Matches(%s) = %v, want %v", tt.now.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWeekdays(t *testing.T) {
+	days, err := ParseWeekdays("sat, Domingo,mié,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []time.Weekday{time.Saturday, time.Sunday, time.Wednesday}
+	if len(days) != len(want) {
+		t.Fatalf("got %v, want %v", days, want)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Fatalf("got %v, want %v", days, want)
+		}
+	}
+
+	if _, err := ParseWeekdays("mon,funday"); err == nil {
+		t.Fatalf("expected an error for an unknown weekday")
+	}
+}
@@ -0,0 +1,334 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Soporte de TOML para archivos de configuración: pares clave = valor (también con claves con puntos),
+// tablas [a.b], listas de tablas [[a]], listas y tablas en línea, textos, números y booleanos.
+// No admite textos multilínea; las fechas sin comillas se leen como texto.
+
+// tomlBareKey son las claves que no necesitan comillas
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// parseTOML decodifica un documento TOML
+func parseTOML(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	current := root
+
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		num := i + 1
+		text := strings.TrimSpace(stripComment(strings.TrimRight(lines[i], "\r")))
+		if text == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "[["):
+			if !strings.HasSuffix(text, "]]") {
+				return nil, fmt.Errorf("line %d: invalid table header %s", num, text)
+			}
+			path, err := splitTOMLKey(text[2 : len(text)-2])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+			parent, err := tomlTable(root, path[:len(path)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+
+			key := path[len(path)-1]
+			list, ok := parent[key].([]interface{})
+			if _, exists := parent[key]; exists && !ok {
+				return nil, fmt.Errorf("line %d: key %q is not an array of tables", num, key)
+			}
+			current = make(map[string]interface{})
+			parent[key] = append(list, current)
+
+		case strings.HasPrefix(text, "["):
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("line %d: invalid table header %s", num, text)
+			}
+			path, err := splitTOMLKey(text[1 : len(text)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+			current, err = tomlTable(root, path)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+
+		default:
+			eq := strings.IndexByte(text, '=')
+			if eq < 0 {
+				return nil, fmt.Errorf("line %d: expected \"key = value\"", num)
+			}
+			path, err := splitTOMLKey(text[:eq])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+
+			// Las listas pueden ocupar varias líneas
+			valueText := strings.TrimSpace(text[eq+1:])
+			for !bracketsBalanced(valueText) && i+1 < len(lines) {
+				i++
+				valueText += "\n" + strings.TrimSpace(stripComment(strings.TrimRight(lines[i], "\r")))
+			}
+
+			parser := &flowParser{text: valueText, separator: '=', scalar: tomlScalar}
+			value, err := parser.parseDocument()
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+
+			table, err := tomlTable(current, path[:len(path)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+			key := path[len(path)-1]
+			if _, exists := table[key]; exists {
+				return nil, fmt.Errorf("line %d: duplicate key %q", num, key)
+			}
+			table[key] = value
+		}
+	}
+
+	return root, nil
+}
+
+// tomlTable recorre (creando si hace falta) las tablas de la ruta indicada.
+// En una lista de tablas usa la última, como hace TOML.
+func tomlTable(table map[string]interface{}, path []string) (map[string]interface{}, error) {
+	for _, key := range path {
+		switch value := table[key].(type) {
+		case nil:
+			next := make(map[string]interface{})
+			table[key] = next
+			table = next
+		case map[string]interface{}:
+			table = value
+		case []interface{}:
+			if len(value) == 0 {
+				return nil, fmt.Errorf("key %q is not a table", key)
+			}
+			last, ok := value[len(value)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("key %q is not a table", key)
+			}
+			table = last
+		default:
+			return nil, fmt.Errorf("key %q is not a table", key)
+		}
+	}
+	return table, nil
+}
+
+// splitTOMLKey separa una clave con puntos (a.b."c.d") en sus partes
+func splitTOMLKey(text string) ([]string, error) {
+	parts := make([]string, 0)
+	text = strings.TrimSpace(text)
+
+	for text != "" {
+		var part string
+		if text[0] == '"' || text[0] == '\'' {
+			end := closingQuote(text)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted key %s", text)
+			}
+			if text[0] == '"' {
+				unquoted, err := unquoteString(text[:end+1])
+				if err != nil {
+					return nil, err
+				}
+				part = unquoted
+			} else {
+				part = text[1:end]
+			}
+			text = strings.TrimSpace(text[end+1:])
+		} else {
+			dot := strings.IndexByte(text, '.')
+			if dot < 0 {
+				dot = len(text)
+			}
+			part = strings.TrimSpace(text[:dot])
+			if !tomlBareKey.MatchString(part) {
+				return nil, fmt.Errorf("invalid key %q", part)
+			}
+			text = text[dot:]
+		}
+
+		parts = append(parts, part)
+		if text == "" {
+			break
+		}
+		if text[0] != '.' {
+			return nil, fmt.Errorf("invalid key %q", text)
+		}
+		text = strings.TrimSpace(text[1:])
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("empty key")
+	}
+	return parts, nil
+}
+
+// bracketsBalanced indica si los corchetes y llaves fuera de comillas están cerrados
+func bracketsBalanced(text string) bool {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '\'':
+			end := closingQuote(text[i:])
+			if end < 0 {
+				return true // El error se informa al decodificar
+			}
+			i += end
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// tomlScalar interpreta un valor sin comillas: booleano, número o fecha/hora (como texto)
+func tomlScalar(text string) (interface{}, error) {
+	switch text {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf", "-inf", "nan", "+nan", "-nan":
+		return nil, fmt.Errorf("infinite and NaN values are not supported")
+	}
+
+	if text != "" && looksNumeric(text) {
+		number := strings.ReplaceAll(text, "_", "")
+		if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(number, 64); err == nil {
+			return f, nil
+		}
+		if strings.ContainsAny(text, ":-") {
+			return text, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid value %q (text must be quoted)", text)
+}
+
+// writeTOMLTable escribe los pares de una tabla y después sus subtablas y listas de tablas
+func writeTOMLTable(buf *bytes.Buffer, m *orderedMap, path []string) {
+	for _, key := range m.keys {
+		value := m.values[key]
+		if value == nil || isTOMLTable(value) || isTOMLTableList(value) {
+			continue
+		}
+		buf.WriteString(tomlKey(key) + " = " + tomlInline(value) + "\n")
+	}
+
+	for _, key := range m.keys {
+		childPath := append(append([]string(nil), path...), key)
+
+		switch value := m.values[key].(type) {
+		case *orderedMap:
+			writeTOMLHeader(buf, "["+tomlPath(childPath)+"]")
+			writeTOMLTable(buf, value, childPath)
+		case []interface{}:
+			if !isTOMLTableList(value) {
+				continue
+			}
+			for _, item := range value {
+				writeTOMLHeader(buf, "[["+tomlPath(childPath)+"]]")
+				writeTOMLTable(buf, item.(*orderedMap), childPath)
+			}
+		}
+	}
+}
+
+// writeTOMLHeader escribe la cabecera de una tabla separada por una línea en blanco
+func writeTOMLHeader(buf *bytes.Buffer, header string) {
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString(header + "\n")
+}
+
+// isTOMLTable indica si un valor se escribe como tabla [a]
+func isTOMLTable(value interface{}) bool {
+	_, ok := value.(*orderedMap)
+	return ok
+}
+
+// isTOMLTableList indica si un valor es una lista no vacía de tablas [[a]]
+func isTOMLTableList(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(*orderedMap); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlInline escribe un valor en una sola línea
+func tomlInline(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return quoteString(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, tomlInline(item))
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *orderedMap:
+		items := make([]string, 0, len(v.keys))
+		for _, key := range v.keys {
+			if v.values[key] != nil {
+				items = append(items, tomlKey(key)+" = "+tomlInline(v.values[key]))
+			}
+		}
+		if len(items) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	default:
+		return quoteString(fmt.Sprint(v))
+	}
+}
+
+// tomlPath escribe una ruta de tablas con claves entre comillas cuando hace falta
+func tomlPath(path []string) string {
+	parts := make([]string, len(path))
+	for i, key := range path {
+		parts[i] = tomlKey(key)
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlKey escribe una clave, entre comillas si no es una clave simple
+func tomlKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return quoteString(key)
+}
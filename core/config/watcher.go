@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval es el intervalo por defecto entre comprobaciones del archivo
+const DefaultWatchInterval = 2 * time.Second
+
+// Watcher detecta cambios en un archivo comprobando periódicamente su fecha de
+// modificación y su hash, sin depender de notificaciones del sistema operativo.
+// Si el archivo desaparece (por ejemplo al guardarlo con un editor que lo reemplaza)
+// se espera a que vuelva a existir; solo cuenta como cambio si su contenido es distinto.
+type Watcher struct {
+	path     string
+	interval time.Duration
+	onChange func()
+
+	mu      sync.Mutex
+	exists  bool
+	modTime time.Time
+	size    int64
+	hash    [sha256.Size]byte
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatcher crea un watcher para path que llama a onChange cuando su contenido cambia.
+// El estado actual del archivo se toma como punto de partida.
+func NewWatcher(path string, interval time.Duration, onChange func()) *Watcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	w := &Watcher{
+		path:     path,
+		interval: interval,
+		onChange: onChange,
+	}
+	w.Check()
+	return w
+}
+
+// Path retorna el archivo vigilado
+func (w *Watcher) Path() string {
+	return w.path
+}
+
+// Start comprueba el archivo en segundo plano hasta que se cancele ctx o se llame a Stop
+func (w *Watcher) Start(ctx context.Context) {
+	w.mu.Lock()
+	if w.cancel != nil {
+		w.mu.Unlock()
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	done := w.done
+	w.mu.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if w.Check() && w.onChange != nil {
+					w.onChange()
+				}
+			}
+		}
+	}()
+}
+
+// Stop detiene las comprobaciones y espera a que termine la última
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// Check comprueba el archivo una vez y retorna si su contenido cambió desde la última comprobación.
+// El hash solo se calcula cuando cambian la fecha de modificación o el tamaño.
+func (w *Watcher) Check() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false
+	}
+	if w.exists && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+
+	hash, err := hashFile(w.path)
+	if err != nil {
+		return false
+	}
+
+	changed := !w.exists || hash != w.hash
+	w.exists = true
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+	w.hash = hash
+	return changed
+}
+
+// hashFile calcula el hash SHA-256 del contenido de un archivo
+func hashFile(path string) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	file, err := os.Open(path)
+	if err != nil {
+		return sum, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return sum, err
+	}
+	copy(sum[:], hasher.Sum(nil))
+	return sum, nil
+}
+
+// Watch vigila el archivo del loader y, cuando cambia, vuelve a combinar todas las capas.
+// Si la configuración resultante es válida se pasa a apply; si no, el error se pasa a
+// report y la configuración anterior sigue en uso.
+func (l *Loader) Watch(interval time.Duration, apply func(*Resolved), report func(error)) *Watcher {
+	return NewWatcher(l.Path, interval, func() {
+		resolved, err := l.Load()
+		if err != nil {
+			if report != nil {
+				report(err)
+			}
+			return
+		}
+		if apply != nil {
+			apply(resolved)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdayNames son los nombres de los días que se aceptan, en inglés y en español
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "dom": time.Sunday, "domingo": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "lun": time.Monday, "lunes": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday, "mar": time.Tuesday, "martes": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "mie": time.Wednesday, "mié": time.Wednesday, "miercoles": time.Wednesday, "miércoles": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday, "jue": time.Thursday, "jueves": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "vie": time.Friday, "viernes": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "sab": time.Saturday, "sáb": time.Saturday, "sabado": time.Saturday, "sábado": time.Saturday,
+}
+
+// ParseWeekdays convierte una lista separada por comas ("sat,sun", "sáb,dom") a días de la semana
+func ParseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		day, ok := weekdayNames[part]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday: %s", part)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// weekdayName retorna el nombre corto en inglés de un día ("mon")
+func weekdayName(day time.Weekday) string {
+	return strings.ToLower(day.String()[:3])
+}
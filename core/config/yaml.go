@@ -0,0 +1,426 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Soporte de YAML para archivos de configuración: mapas y listas en bloque por indentación,
+// listas y mapas en línea ([a, b], {k: v}), escalares planos y entre comillas, y comentarios.
+// No admite anclas, etiquetas, textos multilínea (| y >) ni varios documentos.
+
+// yamlLine es una línea con contenido de un documento YAML
+type yamlLine struct {
+	num    int // Número de línea (desde 1)
+	indent int
+	text   string // Sin indentación ni comentario
+}
+
+// yamlParser recorre las líneas de un documento YAML
+type yamlParser struct {
+	lines []yamlLine
+}
+
+// parseYAML decodifica un documento YAML cuyo nivel superior es un mapa
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	lines, err := yamlLines(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	p := &yamlParser{lines: lines}
+	value, next, err := p.parseBlock(0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[next].num)
+	}
+
+	doc, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("yaml document must be a mapping")
+	}
+	return doc, nil
+}
+
+// yamlLines separa el documento en líneas con contenido, sin comentarios
+func yamlLines(data []byte) ([]yamlLine, error) {
+	lines := make([]yamlLine, 0)
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		text := strings.TrimRight(stripComment(raw), " \t")
+
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" || trimmed == "..." {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+
+		lines = append(lines, yamlLine{
+			num:    i + 1,
+			indent: len(text) - len(trimmed),
+			text:   trimmed,
+		})
+	}
+
+	return lines, nil
+}
+
+// stripComment elimina un comentario (#) que no esté dentro de comillas
+func stripComment(line string) string {
+	inSingle, inDouble := false, false
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && inDouble:
+			i++
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+		case c == '#' && !inSingle && !inDouble && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// isSequenceItem indica si una línea es un elemento de lista ("- valor")
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseBlock decodifica el mapa o la lista que empieza en la línea i con la indentación indicada
+func (p *yamlParser) parseBlock(i, indent int) (interface{}, int, error) {
+	if isSequenceItem(p.lines[i].text) {
+		return p.parseSequence(i, indent)
+	}
+	return p.parseMapping(i, indent)
+}
+
+// parseMapping decodifica un mapa en bloque
+func (p *yamlParser) parseMapping(i, indent int) (interface{}, int, error) {
+	mapping := make(map[string]interface{})
+
+	for i < len(p.lines) {
+		line := p.lines[i]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, i, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+		if isSequenceItem(line.text) {
+			return nil, i, fmt.Errorf("line %d: unexpected list item in a mapping", line.num)
+		}
+
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, i, fmt.Errorf("line %d: expected \"key: value\"", line.num)
+		}
+		if _, exists := mapping[key]; exists {
+			return nil, i, fmt.Errorf("line %d: duplicate key %q", line.num, key)
+		}
+		i++
+
+		var value interface{}
+		var err error
+
+		switch {
+		case rest != "":
+			value, err = parseYAMLValue(rest)
+			if err != nil {
+				return nil, i, fmt.Errorf("line %d: %w", line.num, err)
+			}
+		case i < len(p.lines) && p.lines[i].indent > indent:
+			value, i, err = p.parseBlock(i, p.lines[i].indent)
+		case i < len(p.lines) && p.lines[i].indent == indent && isSequenceItem(p.lines[i].text):
+			// Lista con la misma indentación que su clave
+			value, i, err = p.parseSequence(i, indent)
+		}
+		if err != nil {
+			return nil, i, err
+		}
+
+		mapping[key] = value
+	}
+
+	return mapping, i, nil
+}
+
+// parseSequence decodifica una lista en bloque
+func (p *yamlParser) parseSequence(i, indent int) (interface{}, int, error) {
+	list := make([]interface{}, 0)
+
+	for i < len(p.lines) {
+		line := p.lines[i]
+		if line.indent < indent || !isSequenceItem(line.text) {
+			break
+		}
+		if line.indent > indent {
+			return nil, i, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if strings.TrimSpace(rest) == "" {
+			// Como en los mapas, los espacios no ASCII ("- \u00a0") no son un valor
+			rest = ""
+		}
+		var value interface{}
+		var err error
+
+		switch {
+		case rest == "":
+			i++
+			if i < len(p.lines) && p.lines[i].indent > indent {
+				value, i, err = p.parseBlock(i, p.lines[i].indent)
+			}
+		case isSequenceItem(rest) || isMappingEntry(rest):
+			// El elemento continúa en las líneas siguientes con la indentación de su contenido
+			itemIndent := indent + len(line.text) - len(rest)
+			p.lines[i] = yamlLine{num: line.num, indent: itemIndent, text: rest}
+			value, i, err = p.parseBlock(i, itemIndent)
+		default:
+			value, err = parseYAMLValue(rest)
+			if err != nil {
+				err = fmt.Errorf("line %d: %w", line.num, err)
+			}
+			i++
+		}
+		if err != nil {
+			return nil, i, err
+		}
+
+		list = append(list, value)
+	}
+
+	return list, i, nil
+}
+
+// isMappingEntry indica si un texto es una entrada "clave: valor"
+func isMappingEntry(text string) bool {
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		return false
+	}
+	_, _, ok := splitYAMLKey(text)
+	return ok
+}
+
+// splitYAMLKey separa "clave: valor" en clave y valor (vacío si el valor está en las líneas siguientes)
+func splitYAMLKey(text string) (string, string, bool) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text)
+		if end < 0 || end+1 >= len(text) || text[end+1] != ':' {
+			return "", "", false
+		}
+		key, err := parseYAMLValue(text[:end+1])
+		if err != nil {
+			return "", "", false
+		}
+		return key.(string), strings.TrimSpace(text[end+2:]), true
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			key := strings.TrimSpace(text[:i])
+			if key == "" {
+				return "", "", false
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// closingQuote retorna la posición de la comilla que cierra el texto que empieza en text[0]
+func closingQuote(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// parseYAMLValue decodifica un valor escrito en una sola línea
+func parseYAMLValue(text string) (interface{}, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("empty value")
+	}
+
+	switch text[0] {
+	case '[', '{':
+		parser := &flowParser{text: text, separator: ':', scalar: yamlScalar}
+		return parser.parseDocument()
+	case '"', '\'':
+		end := closingQuote(text)
+		if end != len(text)-1 {
+			return nil, fmt.Errorf("invalid quoted value %s", text)
+		}
+		if text[0] == '\'' {
+			return strings.ReplaceAll(text[1:end], "''", "'"), nil
+		}
+		return unquoteString(text)
+	case '|', '>':
+		return nil, fmt.Errorf("multi-line text blocks are not supported")
+	case '&', '*', '!':
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported")
+	default:
+		return yamlScalar(text)
+	}
+}
+
+// yamlScalar interpreta un escalar sin comillas: null, booleano, número o texto
+func yamlScalar(text string) (interface{}, error) {
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+
+	if looksNumeric(text) {
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f, nil
+		}
+	}
+	return text, nil
+}
+
+// looksNumeric indica si un texto empieza como un número (evita interpretar "inf" o "nan")
+func looksNumeric(text string) bool {
+	start := 0
+	if text[0] == '+' || text[0] == '-' {
+		start = 1
+	}
+	return start < len(text) && (text[start] >= '0' && text[start] <= '9' || text[start] == '.')
+}
+
+// writeYAMLMap escribe un mapa en bloque con la indentación indicada
+func writeYAMLMap(buf *bytes.Buffer, m *orderedMap, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	for _, key := range m.keys {
+		buf.WriteString(pad + yamlText(key) + ":")
+
+		switch value := m.values[key].(type) {
+		case *orderedMap:
+			if len(value.keys) == 0 {
+				buf.WriteString(" {}\n")
+				continue
+			}
+			buf.WriteString("\n")
+			writeYAMLMap(buf, value, indent+2)
+		case []interface{}:
+			if len(value) == 0 || isScalarList(value) {
+				buf.WriteString(" " + yamlFlow(value) + "\n")
+				continue
+			}
+			buf.WriteString("\n")
+			writeYAMLList(buf, value, indent+2)
+		default:
+			buf.WriteString(" " + yamlFlow(value) + "\n")
+		}
+	}
+}
+
+// writeYAMLList escribe una lista en bloque; los mapas empiezan en la línea del guion
+func writeYAMLList(buf *bytes.Buffer, list []interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	for _, item := range list {
+		switch value := item.(type) {
+		case *orderedMap:
+			if len(value.keys) == 0 {
+				buf.WriteString(pad + "- {}\n")
+				continue
+			}
+			var item bytes.Buffer
+			writeYAMLMap(&item, value, indent+2)
+			buf.WriteString(pad + "- " + strings.TrimPrefix(item.String(), pad+"  "))
+		case []interface{}:
+			if len(value) == 0 || isScalarList(value) {
+				buf.WriteString(pad + "- " + yamlFlow(value) + "\n")
+				continue
+			}
+			buf.WriteString(pad + "-\n")
+			writeYAMLList(buf, value, indent+2)
+		default:
+			buf.WriteString(pad + "- " + yamlFlow(value) + "\n")
+		}
+	}
+}
+
+// isScalarList indica si una lista no contiene mapas ni listas
+func isScalarList(list []interface{}) bool {
+	for _, item := range list {
+		switch item.(type) {
+		case *orderedMap, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+// yamlFlow escribe un valor en una sola línea
+func yamlFlow(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlText(v)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = yamlFlow(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *orderedMap:
+		items := make([]string, len(v.keys))
+		for i, key := range v.keys {
+			items[i] = yamlText(key) + ": " + yamlFlow(v.values[key])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// yamlText escribe un texto sin comillas si se puede leer de vuelta igual
+func yamlText(text string) string {
+	if text == "" || text != strings.TrimSpace(text) ||
+		strings.ContainsAny(text[:1], "#-?'\"&*!|>%@`") || strings.Contains(text, " #") ||
+		strings.ContainsAny(text, "\n\r\t,[]{}:") {
+		return quoteString(text)
+	}
+	if value, _ := yamlScalar(text); value != text {
+		return quoteString(text)
+	}
+	return text
+}
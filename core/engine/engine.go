@@ -22,20 +22,20 @@ const (
 	StateStopped State = "stopped"
 )
 
-// SessionType representa el tipo de sesión actual
-type SessionType string
+// SessionType representa el tipo de sesión actual (alias del tipo compartido con events y stats)
+type SessionType = events.SessionKind
 
 const (
-	SessionWork       SessionType = "work"
-	SessionShortBreak SessionType = "short_break"
-	SessionLongBreak  SessionType = "long_break"
+	SessionWork       = events.KindWork
+	SessionShortBreak = events.KindShortBreak
+	SessionLongBreak  = events.KindLongBreak
 )
 
 // Engine es el motor principal del pomodoro, thread-safe e independiente de UI
 type Engine struct {
 	mu sync.RWMutex
 
-	// Configuración inmutable
+	// Configuración (se reemplaza completa con UpdateConfig, nunca se modifica)
 	config *config.Config
 
 	// Estado mutable
@@ -51,6 +51,9 @@ type Engine struct {
 
 	// Control de tiempo
 	sessionStartTime time.Time
+	sessionID        string
+	sessionRule      string // Regla de horario aplicada a la sesión actual ("" si ninguna)
+	labels           stats.Labels
 
 	// Control de contexto
 	ctx    context.Context
@@ -76,13 +79,18 @@ type EngineInterface interface {
 	Pause() error
 	Resume() error
 	Skip() error
+	Extend(d time.Duration) error
 	GetState() State
 	GetCurrentSession() SessionType
+	GetTimerData() events.TimerEventData
 	GetPomodoroCount() int
 	IsRunning() bool
 	GetStats() *stats.SessionStats
 	GetEventBus() *events.EventBus
 	GetConfig() *config.Config
+	UpdateConfig(cfg *config.Config) error
+	SetLabels(labels stats.Labels)
+	GetLabels() stats.Labels
 }
 
 // NewEngine crea una nueva instancia del motor de pomodoro
@@ -202,6 +210,11 @@ func (e *Engine) Skip() error {
 	return e.sendCommand("skip", nil)
 }
 
+// Extend añade tiempo a la sesión en curso
+func (e *Engine) Extend(d time.Duration) error {
+	return e.sendCommand("extend", d)
+}
+
 // GetState retorna el estado actual del engine
 func (e *Engine) GetState() State {
 	e.mu.RLock()
@@ -216,6 +229,16 @@ func (e *Engine) GetCurrentSession() SessionType {
 	return e.currentSession
 }
 
+// GetTimerData retorna el estado del timer de la sesión en curso (vacío si aún no empezó ninguna)
+func (e *Engine) GetTimerData() events.TimerEventData {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.currentTimer == nil {
+		return events.TimerEventData{}
+	}
+	return e.createTimerEventData(e.currentTimer.GetSnapshot())
+}
+
 // GetPomodoroCount retorna el número de pomodoros completados
 func (e *Engine) GetPomodoroCount() int {
 	e.mu.RLock()
@@ -249,8 +272,46 @@ func (e *Engine) GetConfig() *config.Config {
 	return e.config.Clone()
 }
 
+// UpdateConfig valida y reemplaza la configuración. La sesión en curso conserva su
+// duración; la nueva configuración se aplica desde la siguiente sesión.
+func (e *Engine) UpdateConfig(cfg *config.Config) error {
+	if cfg == nil {
+		return fmt.Errorf("config cannot be nil")
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	e.mu.Lock()
+	previous := e.config
+	e.config = cfg.Clone()
+	current := e.config
+	e.mu.Unlock()
+
+	e.eventBus.Publish(events.ConfigUpdated, events.ConfigEventData{
+		Previous: previous,
+		Current:  current,
+	})
+
+	return nil
+}
+
 // Métodos privados
 
+// SetLabels establece la tarea, proyecto y tags del pomodoro actual y los siguientes
+func (e *Engine) SetLabels(labels stats.Labels) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.labels = labels
+}
+
+// GetLabels retorna las etiquetas del trabajo actual
+func (e *Engine) GetLabels() stats.Labels {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.labels
+}
+
 // runEventLoop es el bucle principal del engine
 func (e *Engine) runEventLoop() {
 	defer func() {
@@ -315,6 +376,9 @@ func (e *Engine) handleCommand(cmd command) {
 		err = e.resumeCurrentTimer()
 	case "skip":
 		err = e.skipCurrentTimer()
+	case "extend":
+		d, _ := cmd.data.(time.Duration)
+		err = e.extendCurrentTimer(d)
 	default:
 		err = fmt.Errorf("unknown command: %s", cmd.action)
 	}
@@ -358,6 +422,10 @@ func (e *Engine) startNextSession() {
 		return
 	}
 
+	// Las reglas de horario se resuelven al empezar cada sesión
+	now := time.Now()
+	cfg, rule := e.config.Resolve(now)
+
 	// Determinar tipo y duración de sesión
 	var duration time.Duration
 	var nextSessionType SessionType
@@ -365,12 +433,12 @@ func (e *Engine) startNextSession() {
 	// Si es la primera sesión (no hay timer previo), empezar con trabajo
 	if e.currentTimer == nil {
 		nextSessionType = SessionWork
-		duration = e.config.WorkDuration
+		duration = cfg.WorkDuration
 	} else if e.currentSession == SessionWork {
 		// Trabajo completado, siguiente es descanso
 		e.pomodoroCount++
 		var isLong bool
-		duration, isLong = e.config.GetNextBreakType(e.pomodoroCount)
+		duration, isLong = cfg.GetNextBreakType(e.pomodoroCount)
 		if isLong {
 			nextSessionType = SessionLongBreak
 		} else {
@@ -379,12 +447,14 @@ func (e *Engine) startNextSession() {
 	} else {
 		// Descanso completado, siguiente es trabajo
 		nextSessionType = SessionWork
-		duration = e.config.WorkDuration
+		duration = cfg.WorkDuration
 	}
 
 	e.currentSession = nextSessionType
 	e.updateStateFromSession()
-	e.sessionStartTime = time.Now()
+	e.sessionStartTime = now
+	e.sessionID = stats.NewSessionID()
+	e.sessionRule = rule
 
 	// Crear nuevo timer
 	e.currentTimer = timer.NewTimer(duration)
@@ -399,18 +469,28 @@ func (e *Engine) startNextSession() {
 
 // emitSessionStartedEvent emite el evento apropiado según el tipo de sesión
 func (e *Engine) emitSessionStartedEvent(sessionType SessionType, duration time.Duration) {
+	labels := e.GetLabels()
+
 	switch sessionType {
 	case SessionWork:
 		e.eventBus.Publish(events.PomodoroStarted, events.PomodoroEventData{
+			SessionID: e.sessionID,
 			Number:    e.pomodoroCount + 1, // +1 porque aún no se ha completado
 			Duration:  duration,
 			StartTime: e.sessionStartTime,
+			Rule:      e.sessionRule,
+			Task:      labels.Task,
+			Project:   labels.Project,
+			Tags:      labels.Tags,
+			Estimate:  labels.Estimate,
 		})
 	case SessionShortBreak, SessionLongBreak:
 		e.eventBus.Publish(events.BreakStarted, events.BreakEventData{
-			Type:        e.getBreakTypeString(sessionType),
+			SessionID:   e.sessionID,
+			Type:        sessionType,
 			Duration:    duration,
 			StartTime:   e.sessionStartTime,
+			Rule:        e.sessionRule,
 			IsLongBreak: sessionType == SessionLongBreak,
 		})
 	}
@@ -419,27 +499,19 @@ func (e *Engine) emitSessionStartedEvent(sessionType SessionType, duration time.
 // handleTimerCompleted maneja cuando un timer se completa
 func (e *Engine) handleTimerCompleted() {
 	e.mu.Lock()
-	sessionEndTime := time.Now()
-	actualTime := sessionEndTime.Sub(e.sessionStartTime)
 	currentSession := e.currentSession
-	duration := e.currentTimer.GetDuration()
+	session := e.finishSession(true)
 	e.mu.Unlock()
 
 	// Actualizar estadísticas
-	switch currentSession {
-	case SessionWork:
-		e.statsManager.AddCompletedPomodoro(duration, actualTime, e.sessionStartTime, sessionEndTime)
-	case SessionShortBreak, SessionLongBreak:
-		breakType := e.getBreakTypeString(currentSession)
-		e.statsManager.AddCompletedBreak(breakType, duration, actualTime, e.sessionStartTime, sessionEndTime)
-	}
+	e.statsManager.AddSession(session)
 
 	// Emitir eventos
 	e.eventBus.Publish(events.TimerCompleted, e.createTimerEventData(e.currentTimer.GetSnapshot()))
 	e.eventBus.Publish(events.StatsUpdated, e.createStatsEventData())
 
 	// Emitir evento específico de sesión
-	e.emitSessionCompletedEvent(currentSession, duration, actualTime, sessionEndTime)
+	e.emitSessionCompletedEvent(currentSession, session)
 
 	// Continuar con siguiente sesión
 	go e.startNextSession()
@@ -448,75 +520,112 @@ func (e *Engine) handleTimerCompleted() {
 // handleTimerSkipped maneja cuando un timer es saltado
 func (e *Engine) handleTimerSkipped() {
 	e.mu.Lock()
-	sessionEndTime := time.Now()
-	actualTime := sessionEndTime.Sub(e.sessionStartTime)
 	currentSession := e.currentSession
-	duration := e.currentTimer.GetDuration()
+	session := e.finishSession(false)
 	e.mu.Unlock()
 
 	// Actualizar estadísticas
-	switch currentSession {
-	case SessionWork:
-		e.statsManager.AddSkippedPomodoro(duration, actualTime, e.sessionStartTime, sessionEndTime)
-	case SessionShortBreak, SessionLongBreak:
-		breakType := e.getBreakTypeString(currentSession)
-		e.statsManager.AddSkippedBreak(breakType, duration, actualTime, e.sessionStartTime, sessionEndTime)
-	}
+	e.statsManager.AddSession(session)
 
 	// Emitir eventos
 	e.eventBus.Publish(events.TimerSkipped, e.createTimerEventData(e.currentTimer.GetSnapshot()))
 	e.eventBus.Publish(events.StatsUpdated, e.createStatsEventData())
 
 	// Emitir evento específico de sesión
-	e.emitSessionSkippedEvent(currentSession, duration, actualTime, sessionEndTime)
+	e.emitSessionSkippedEvent(currentSession, session)
 
 	// Continuar con siguiente sesión
 	go e.startNextSession()
 }
 
+// finishSession construye el registro de la sesión actual con sus pausas (debe llamarse con lock)
+func (e *Engine) finishSession(completed bool) stats.CompletedSession {
+	sessionEndTime := time.Now()
+	actualTime := sessionEndTime.Sub(e.sessionStartTime)
+	snapshot := e.currentTimer.GetSnapshot()
+
+	activeTime := snapshot.ElapsedActive
+	if activeTime > actualTime {
+		activeTime = actualTime
+	}
+	pausedTime := actualTime - activeTime
+	if activeTime < 0 {
+		activeTime, pausedTime = 0, actualTime
+	}
+
+	session := stats.CompletedSession{
+		ID:         e.sessionID,
+		Type:       e.currentSession,
+		Duration:   snapshot.Duration,
+		ActualTime: actualTime,
+		StartTime:  e.sessionStartTime,
+		EndTime:    sessionEndTime,
+		Completed:  completed,
+		PauseCount: snapshot.PauseCount,
+		PausedTime: pausedTime,
+		ActiveTime: activeTime,
+		Rule:       e.sessionRule,
+	}
+	if e.currentSession == SessionWork {
+		session.Labels = e.labels
+	}
+	return session
+}
+
 // emitSessionCompletedEvent emite evento de sesión completada
-func (e *Engine) emitSessionCompletedEvent(sessionType SessionType, duration, actualTime time.Duration, endTime time.Time) {
+func (e *Engine) emitSessionCompletedEvent(sessionType SessionType, session stats.CompletedSession) {
 	switch sessionType {
 	case SessionWork:
-		e.eventBus.Publish(events.PomodoroCompleted, events.PomodoroEventData{
-			Number:     e.pomodoroCount,
-			Duration:   duration,
-			ActualTime: actualTime,
-			StartTime:  e.sessionStartTime,
-			EndTime:    endTime,
-		})
+		e.eventBus.Publish(events.PomodoroCompleted, e.createPomodoroEventData(session))
 	case SessionShortBreak, SessionLongBreak:
-		e.eventBus.Publish(events.BreakCompleted, events.BreakEventData{
-			Type:        e.getBreakTypeString(sessionType),
-			Duration:    duration,
-			ActualTime:  actualTime,
-			StartTime:   e.sessionStartTime,
-			EndTime:     endTime,
-			IsLongBreak: sessionType == SessionLongBreak,
-		})
+		e.eventBus.Publish(events.BreakCompleted, e.createBreakEventData(sessionType, session))
 	}
 }
 
 // emitSessionSkippedEvent emite evento de sesión saltada
-func (e *Engine) emitSessionSkippedEvent(sessionType SessionType, duration, actualTime time.Duration, endTime time.Time) {
+func (e *Engine) emitSessionSkippedEvent(sessionType SessionType, session stats.CompletedSession) {
 	switch sessionType {
 	case SessionWork:
-		e.eventBus.Publish(events.PomodoroSkipped, events.PomodoroEventData{
-			Number:     e.pomodoroCount,
-			Duration:   duration,
-			ActualTime: actualTime,
-			StartTime:  e.sessionStartTime,
-			EndTime:    endTime,
-		})
+		e.eventBus.Publish(events.PomodoroSkipped, e.createPomodoroEventData(session))
 	case SessionShortBreak, SessionLongBreak:
-		e.eventBus.Publish(events.BreakSkipped, events.BreakEventData{
-			Type:        e.getBreakTypeString(sessionType),
-			Duration:    duration,
-			ActualTime:  actualTime,
-			StartTime:   e.sessionStartTime,
-			EndTime:     endTime,
-			IsLongBreak: sessionType == SessionLongBreak,
-		})
+		e.eventBus.Publish(events.BreakSkipped, e.createBreakEventData(sessionType, session))
+	}
+}
+
+// createPomodoroEventData crea datos de evento de un pomodoro terminado
+func (e *Engine) createPomodoroEventData(session stats.CompletedSession) events.PomodoroEventData {
+	return events.PomodoroEventData{
+		SessionID:  session.ID,
+		Number:     e.pomodoroCount,
+		Duration:   session.Duration,
+		ActualTime: session.ActualTime,
+		StartTime:  session.StartTime,
+		EndTime:    session.EndTime,
+		PauseCount: session.PauseCount,
+		PausedTime: session.PausedTime,
+		ActiveTime: session.ActiveTime,
+		Rule:       session.Rule,
+		Task:       session.Task,
+		Project:    session.Project,
+		Tags:       session.Tags,
+		Estimate:   session.Estimate,
+	}
+}
+
+// createBreakEventData crea datos de evento de un descanso terminado
+func (e *Engine) createBreakEventData(sessionType SessionType, session stats.CompletedSession) events.BreakEventData {
+	return events.BreakEventData{
+		SessionID:   session.ID,
+		Type:        sessionType,
+		Duration:    session.Duration,
+		ActualTime:  session.ActualTime,
+		StartTime:   session.StartTime,
+		EndTime:     session.EndTime,
+		PauseCount:  session.PauseCount,
+		PausedTime:  session.PausedTime,
+		ActiveTime:  session.ActiveTime,
+		Rule:        session.Rule,
+		IsLongBreak: sessionType == SessionLongBreak,
 	}
 }
 
@@ -557,6 +666,21 @@ func (e *Engine) skipCurrentTimer() error {
 	return nil
 }
 
+// extendCurrentTimer añade tiempo al timer actual
+func (e *Engine) extendCurrentTimer(d time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.currentTimer == nil {
+		return fmt.Errorf("no session in progress")
+	}
+	if err := e.currentTimer.Extend(d); err != nil {
+		return err
+	}
+	e.eventBus.Publish(events.TimerExtended, e.createTimerEventData(e.currentTimer.GetSnapshot()))
+	return nil
+}
+
 // updateStateFromSession actualiza el estado basado en la sesión actual
 func (e *Engine) updateStateFromSession() {
 	switch e.currentSession {
@@ -567,28 +691,8 @@ func (e *Engine) updateStateFromSession() {
 	}
 }
 
-// getBreakTypeString convierte SessionType a string
-func (e *Engine) getBreakTypeString(sessionType SessionType) string {
-	switch sessionType {
-	case SessionLongBreak:
-		return "DESCANSO LARGO"
-	default:
-		return "DESCANSO"
-	}
-}
-
 // createTimerEventData crea datos de evento del timer
 func (e *Engine) createTimerEventData(snapshot timer.TimerSnapshot) events.TimerEventData {
-	var stateStr string
-	switch e.currentSession {
-	case SessionWork:
-		stateStr = "TRABAJO"
-	case SessionShortBreak:
-		stateStr = "DESCANSO"
-	case SessionLongBreak:
-		stateStr = "DESCANSO LARGO"
-	}
-
 	var statusStr string
 	switch snapshot.State {
 	case timer.StatePaused:
@@ -602,7 +706,7 @@ func (e *Engine) createTimerEventData(snapshot timer.TimerSnapshot) events.Timer
 	return events.TimerEventData{
 		Remaining:    snapshot.Remaining,
 		Total:        snapshot.Duration,
-		State:        stateStr,
+		State:        e.currentSession,
 		Status:       statusStr,
 		Progress:     snapshot.Progress,
 		SessionCount: e.pomodoroCount,
@@ -623,5 +727,7 @@ func (e *Engine) createStatsEventData() events.StatsEventData {
 		TotalBreakTime:     snapshot.TotalBreakTime,
 		SessionDuration:    snapshot.SessionDuration,
 		WorkEfficiency:     snapshot.WorkEfficiency,
+		FocusScore:         snapshot.FocusScore,
+		TotalPauses:        snapshot.TotalPauses,
 	}
 }
@@ -18,6 +18,7 @@ const (
 	TimerTick      EventType = "timer_tick"
 	TimerPaused    EventType = "timer_paused"
 	TimerResumed   EventType = "timer_resumed"
+	TimerExtended  EventType = "timer_extended"
 	TimerCompleted EventType = "timer_completed"
 	TimerSkipped   EventType = "timer_skipped"
 
@@ -38,10 +39,38 @@ const (
 	// Eventos de Stats
 	StatsUpdated EventType = "stats_updated"
 
+	// Eventos de Config
+	ConfigUpdated EventType = "config_updated"
+
 	// Eventos de Error
 	ErrorOccurred EventType = "error_occurred"
 )
 
+// types son todos los tipos de evento conocidos, en orden de declaración
+var types = []EventType{
+	EngineStarted, EngineStopped,
+	TimerStarted, TimerTick, TimerPaused, TimerResumed, TimerExtended, TimerCompleted, TimerSkipped,
+	SessionStarted, SessionEnded,
+	PomodoroStarted, PomodoroCompleted, PomodoroSkipped,
+	BreakStarted, BreakCompleted, BreakSkipped,
+	StatsUpdated, ConfigUpdated, ErrorOccurred,
+}
+
+// Types retorna todos los tipos de evento conocidos
+func Types() []EventType {
+	return append([]EventType(nil), types...)
+}
+
+// IsKnown indica si t es un tipo de evento conocido
+func IsKnown(t EventType) bool {
+	for _, known := range types {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
 // Event representa un evento emitido por el sistema
 type Event struct {
 	Type      EventType   `json:"type"`
@@ -168,7 +197,7 @@ func (eb *EventBus) GetGlobalSubscriberCount() int {
 type TimerEventData struct {
 	Remaining    time.Duration `json:"remaining"`
 	Total        time.Duration `json:"total"`
-	State        string        `json:"state"`    // "TRABAJO", "DESCANSO", "DESCANSO LARGO"
+	State        SessionKind   `json:"state"`    // Tipo de la sesión en curso
 	Status       string        `json:"status"`   // "RUNNING", "PAUSED", "STOPPED"
 	Progress     float64       `json:"progress"` // 0.0 - 1.0
 	SessionCount int           `json:"session_count"`
@@ -176,22 +205,36 @@ type TimerEventData struct {
 
 // PomodoroEventData contiene datos específicos de eventos de pomodoro
 type PomodoroEventData struct {
+	SessionID    string        `json:"session_id"`
 	Number       int           `json:"number"`
 	Duration     time.Duration `json:"duration"`
 	ActualTime   time.Duration `json:"actual_time"`
 	StartTime    time.Time     `json:"start_time"`
 	EndTime      time.Time     `json:"end_time"`
-	NextBreak    string        `json:"next_break"`
+	PauseCount   int           `json:"pause_count"`
+	PausedTime   time.Duration `json:"paused_time"`
+	ActiveTime   time.Duration `json:"active_time"`
+	Rule         string        `json:"rule,omitempty"` // Regla de horario aplicada
+	Task         string        `json:"task,omitempty"`
+	Project      string        `json:"project,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	Estimate     int           `json:"estimate,omitempty"`
+	NextBreak    SessionKind   `json:"next_break"`
 	NextDuration time.Duration `json:"next_duration"`
 }
 
 // BreakEventData contiene datos específicos de eventos de break
 type BreakEventData struct {
-	Type        string        `json:"type"` // "DESCANSO", "DESCANSO LARGO"
+	SessionID   string        `json:"session_id"`
+	Type        SessionKind   `json:"type"` // KindShortBreak o KindLongBreak
 	Duration    time.Duration `json:"duration"`
 	ActualTime  time.Duration `json:"actual_time"`
 	StartTime   time.Time     `json:"start_time"`
 	EndTime     time.Time     `json:"end_time"`
+	PauseCount  int           `json:"pause_count"`
+	PausedTime  time.Duration `json:"paused_time"`
+	ActiveTime  time.Duration `json:"active_time"`
+	Rule        string        `json:"rule,omitempty"` // Regla de horario aplicada
 	IsLongBreak bool          `json:"is_long_break"`
 }
 
@@ -207,6 +250,8 @@ type StatsEventData struct {
 	TotalBreakTime     time.Duration `json:"total_break_time"`
 	SessionDuration    time.Duration `json:"session_duration"`
 	WorkEfficiency     float64       `json:"work_efficiency"`
+	FocusScore         float64       `json:"focus_score"`
+	TotalPauses        int           `json:"total_pauses"`
 }
 
 // SessionEventData contiene datos específicos de eventos de sesión
@@ -218,6 +263,12 @@ type SessionEventData struct {
 	ConfigUsed interface{}   `json:"config_used"`
 }
 
+// ConfigEventData contiene la configuración anterior y la nueva tras una recarga
+type ConfigEventData struct {
+	Previous interface{} `json:"previous"`
+	Current  interface{} `json:"current"`
+}
+
 // ErrorEventData contiene datos específicos de eventos de error
 type ErrorEventData struct {
 	Message string      `json:"message"`
@@ -229,7 +280,7 @@ type ErrorEventData struct {
 // Helper functions para crear eventos comunes
 
 // NewTimerEvent crea un evento de timer con los datos proporcionados
-func NewTimerEvent(eventType EventType, remaining, total time.Duration, state, status string, progress float64, sessionCount int) Event {
+func NewTimerEvent(eventType EventType, remaining, total time.Duration, state SessionKind, status string, progress float64, sessionCount int) Event {
 	return Event{
 		Type:      eventType,
 		Timestamp: time.Now(),
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SessionKind identifica el tipo de sesión independientemente del idioma de la interfaz.
+// Lo comparten engine, events y stats; el texto a mostrar lo decide cada frontend.
+type SessionKind string
+
+const (
+	KindWork       SessionKind = "work"
+	KindShortBreak SessionKind = "short_break"
+	KindLongBreak  SessionKind = "long_break"
+)
+
+// legacyKinds mapea los textos que se guardaban antes de tipar las sesiones
+var legacyKinds = map[string]SessionKind{
+	"TRABAJO":        KindWork,
+	"DESCANSO":       KindShortBreak,
+	"DESCANSO LARGO": KindLongBreak,
+}
+
+// ParseSessionKind convierte un texto a SessionKind. Acepta los valores canónicos,
+// los textos antiguos ("TRABAJO", "DESCANSO", "DESCANSO LARGO") y alias comunes.
+func ParseSessionKind(value string) (SessionKind, error) {
+	if kind, ok := legacyKinds[value]; ok {
+		return kind, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "work", "pomodoro", "trabajo":
+		return KindWork, nil
+	case "short_break", "short", "break", "descanso":
+		return KindShortBreak, nil
+	case "long_break", "long", "descanso largo", "largo":
+		return KindLongBreak, nil
+	default:
+		return "", fmt.Errorf("unknown session kind: %s", value)
+	}
+}
+
+// IsWork indica si la sesión es un pomodoro
+func (k SessionKind) IsWork() bool {
+	return k == KindWork
+}
+
+// IsBreak indica si la sesión es un descanso (corto o largo)
+func (k SessionKind) IsBreak() bool {
+	return k == KindShortBreak || k == KindLongBreak
+}
+
+// UnmarshalJSON lee tanto los valores canónicos como los de exportaciones antiguas
+func (k *SessionKind) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to parse session kind: %w", err)
+	}
+	if value == "" {
+		*k = ""
+		return nil
+	}
+
+	kind, err := ParseSessionKind(value)
+	if err != nil {
+		return err
+	}
+	*k = kind
+	return nil
+}
@@ -0,0 +1,288 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// Source es el origen de los eventos de error que publica el runner
+const Source = "hooks.Runner"
+
+// Códigos de los eventos de error de los hooks
+const (
+	CodeFailed  = "HOOK_FAILED"  // El comando no se pudo ejecutar o terminó con error
+	CodeTimeout = "HOOK_TIMEOUT" // El comando superó el tiempo máximo y se detuvo
+	CodeBusy    = "HOOK_BUSY"    // No quedó sitio en el límite de concurrencia a tiempo
+)
+
+// EnvPrefix es el prefijo de las variables de entorno con los datos del evento
+const EnvPrefix = "GOMODORO_"
+
+// maxStderr es la cantidad de salida de error que se guarda de cada comando
+const maxStderr = 4 * 1024
+
+// waitDelay es el tiempo que se espera a que se cierre la salida de un comando detenido
+const waitDelay = time.Second
+
+// Result describe la ejecución de un hook; es el Details de sus eventos de error
+type Result struct {
+	Event    events.EventType `json:"event"`
+	Command  string           `json:"command"`
+	ExitCode int              `json:"exit_code"` // -1 si no llegó a terminar
+	Duration time.Duration    `json:"duration"`
+	Stderr   string           `json:"stderr,omitempty"`
+}
+
+// Runner ejecuta comandos de shell cuando el engine publica eventos. Cada comando recibe los datos
+// del evento en variables de entorno GOMODORO_* y el evento completo en JSON por la entrada estándar.
+// Los fallos se publican en el bus como events.ErrorOccurred con Source igual a hooks.Source.
+type Runner struct {
+	mu       sync.RWMutex
+	config   config.Hooks
+	slots    chan struct{} // Semáforo del límite de concurrencia
+	eventBus *events.EventBus
+	running  sync.WaitGroup
+}
+
+// NewRunner crea un runner con la configuración de hooks indicada
+func NewRunner(cfg config.Hooks) *Runner {
+	r := &Runner{}
+	r.Update(cfg)
+	return r
+}
+
+// Update reemplaza la configuración. Los comandos en ejecución terminan con la anterior.
+func (r *Runner) Update(cfg config.Hooks) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = config.DefaultHookTimeout
+	}
+	if cfg.MaxConcurrent < 1 {
+		cfg.MaxConcurrent = config.DefaultHookMaxConcurrent
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.slots == nil || cap(r.slots) != cfg.MaxConcurrent {
+		r.slots = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	r.config = cfg
+}
+
+// Subscribe registra el runner en todos los eventos del bus; los que no tienen hooks se ignoran
+func (r *Runner) Subscribe(eventBus *events.EventBus) {
+	r.mu.Lock()
+	r.eventBus = eventBus
+	r.mu.Unlock()
+
+	eventBus.SubscribeGlobal(r)
+}
+
+// Wait espera a que terminen los comandos en ejecución
+func (r *Runner) Wait() {
+	r.running.Wait()
+}
+
+// HandleEvent implementa events.EventHandler: ejecuta en orden los comandos del tipo de evento
+func (r *Runner) HandleEvent(event events.Event) {
+	// Un hook de error_occurred que falla no debe provocar otro error en bucle
+	if data, ok := event.Data.(events.ErrorEventData); ok && data.Source == Source {
+		return
+	}
+
+	r.mu.RLock()
+	commands := r.config.Commands[event.Type]
+	timeout := r.config.Timeout
+	slots := r.slots
+	r.mu.RUnlock()
+	if len(commands) == 0 {
+		return
+	}
+
+	r.running.Add(1)
+	defer r.running.Done()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		r.report(CodeFailed, fmt.Sprintf("failed to encode %s for hooks: %v", event.Type, err), Result{Event: event.Type})
+		return
+	}
+	env := append(os.Environ(), EventEnv(event)...)
+
+	for _, command := range commands {
+		select {
+		case slots <- struct{}{}:
+		case <-time.After(timeout):
+			r.report(CodeBusy, fmt.Sprintf("hook %q for %s skipped: %d hooks already running", command, event.Type, cap(slots)),
+				Result{Event: event.Type, Command: command, ExitCode: -1})
+			continue
+		}
+
+		result, code, err := run(command, event.Type, env, payload, timeout)
+		<-slots
+		if err != nil {
+			r.report(code, fmt.Sprintf("hook %q for %s failed: %v", command, event.Type, err), result)
+		}
+	}
+}
+
+// run ejecuta un comando con el shell del sistema y retorna su resultado y, si falla, el código y el error
+func run(command string, eventType events.EventType, env []string, payload []byte, timeout time.Duration) (Result, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	name, args := shell(command)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(payload)
+	stderr := &limitedBuffer{limit: maxStderr}
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+
+	started := time.Now()
+	err := cmd.Run()
+	result := Result{
+		Event:    eventType,
+		Command:  command,
+		ExitCode: -1,
+		Duration: time.Since(started),
+		Stderr:   strings.TrimSpace(stderr.String()),
+	}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return result, CodeTimeout, fmt.Errorf("timed out after %s", timeout)
+	case err != nil && result.Stderr != "":
+		return result, CodeFailed, fmt.Errorf("%v: %s", err, firstLine(result.Stderr))
+	case err != nil:
+		return result, CodeFailed, err
+	}
+	return result, "", nil
+}
+
+// report publica el fallo de un hook en el bus de eventos
+func (r *Runner) report(code, message string, result Result) {
+	r.mu.RLock()
+	eventBus := r.eventBus
+	r.mu.RUnlock()
+	if eventBus == nil {
+		return
+	}
+
+	eventBus.Publish(events.ErrorOccurred, events.ErrorEventData{
+		Message: message,
+		Code:    code,
+		Source:  Source,
+		Details: result,
+	})
+}
+
+// shell retorna el intérprete de comandos del sistema con sus argumentos
+func shell(command string) (string, []string) {
+	if runtime.GOOS == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
+
+// EventEnv retorna las variables de entorno con los datos de un evento: GOMODORO_EVENT,
+// GOMODORO_TIMESTAMP y una por cada campo de sus datos con el nombre de su clave JSON
+// (ej: GOMODORO_NUMBER, GOMODORO_TASK). Las duraciones van en segundos, las fechas en
+// RFC 3339 y las listas separadas por comas.
+func EventEnv(event events.Event) []string {
+	env := []string{
+		EnvPrefix + "EVENT=" + string(event.Type),
+		EnvPrefix + "TIMESTAMP=" + event.Timestamp.Format(time.RFC3339),
+	}
+
+	value := reflect.ValueOf(event.Data)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return env
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return env
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		if text, ok := envValue(value.Field(i)); ok {
+			env = append(env, EnvPrefix+strings.ToUpper(name)+"="+text)
+		}
+	}
+	return env
+}
+
+// envValue convierte el valor de un campo en texto; los tipos compuestos se omiten
+func envValue(value reflect.Value) (string, bool) {
+	switch v := value.Interface().(type) {
+	case time.Duration:
+		return strconv.FormatInt(int64(v/time.Second), 10), true
+	case time.Time:
+		if v.IsZero() {
+			return "", true
+		}
+		return v.Format(time.RFC3339), true
+	case []string:
+		return strings.Join(v, ","), true
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// firstLine retorna la primera línea de un texto
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// limitedBuffer guarda como máximo limit bytes y descarta el resto sin fallar
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+// Write implementa io.Writer
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,282 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/config"
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+func TestEventEnv(t *testing.T) {
+	madrid := time.FixedZone("CET", 60*60)
+	timestamp := time.Date(2026, 3, 2, 10, 25, 0, 0, madrid)
+
+	tests := []struct {
+		name  string
+		data  interface{}
+		want  map[string]string // Variables esperadas (con valor vacío si el campo está vacío)
+		exact bool              // No admite más variables que las esperadas
+	}{
+		{
+			name: "pomodoro",
+			data: events.PomodoroEventData{
+				SessionID:  "abc",
+				Number:     3,
+				Duration:   25 * time.Minute,
+				ActualTime: 90*time.Second + 900*time.Millisecond,
+				StartTime:  timestamp.Add(-25 * time.Minute),
+				Task:       "informe",
+				Tags:       []string{"a", "b c"},
+				NextBreak:  events.KindShortBreak,
+			},
+			want: map[string]string{
+				"GOMODORO_SESSION_ID":  "abc",
+				"GOMODORO_NUMBER":      "3",
+				"GOMODORO_DURATION":    "1500",
+				"GOMODORO_ACTUAL_TIME": "90", // Segundos completos
+				"GOMODORO_START_TIME":  "2026-03-02T10:00:00+01:00",
+				"GOMODORO_END_TIME":    "", // Fecha cero
+				"GOMODORO_TASK":        "informe",
+				"GOMODORO_PROJECT":     "",
+				"GOMODORO_TAGS":        "a,b c",
+				"GOMODORO_NEXT_BREAK":  "short_break",
+			},
+		},
+		{
+			name: "pointer",
+			data: &events.TimerEventData{Progress: 0.25, Status: "RUNNING", SessionCount: 2},
+			want: map[string]string{
+				"GOMODORO_PROGRESS":      "0.25",
+				"GOMODORO_STATUS":        "RUNNING",
+				"GOMODORO_SESSION_COUNT": "2",
+			},
+		},
+		{
+			name: "skipped fields",
+			data: struct {
+				Visible bool              `json:"visible"`
+				Ignored string            `json:"-"`
+				NoTag   string            // Sin etiqueta JSON
+				Nested  map[string]string `json:"nested"`
+			}{Visible: true, Ignored: "x", NoTag: "x", Nested: map[string]string{"a": "b"}},
+			want:  map[string]string{"GOMODORO_VISIBLE": "true"},
+			exact: true,
+		},
+		{name: "nil pointer", data: (*events.PomodoroEventData)(nil), exact: true},
+		{name: "not a struct", data: "texto", exact: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := EventEnv(events.Event{Type: events.PomodoroCompleted, Timestamp: timestamp, Data: tt.data})
+
+			got := make(map[string]string, len(env))
+			for _, variable := range env {
+				name, value, _ := strings.Cut(variable, "=")
+				got[name] = value
+			}
+			want := map[string]string{
+				"GOMODORO_EVENT":     "pomodoro_completed",
+				"GOMODORO_TIMESTAMP": "2026-03-02T10:25:00+01:00",
+			}
+			for name, value := range tt.want {
+				want[name] = value
+			}
+
+			for name, value := range want {
+				if actual, ok := got[name]; !ok || actual != value {
+					t.Errorf("%s: got %q (set %v), want %q", name, actual, ok, value)
+				}
+			}
+			if !tt.exact {
+				return
+			}
+			for name := range got {
+				if _, ok := want[name]; !ok {
+					t.Errorf("unexpected variable %s=%s", name, got[name])
+				}
+			}
+		})
+	}
+}
+
+// testRunner es un runner suscrito a un bus real que guarda los errores publicados
+type testRunner struct {
+	*Runner
+	bus    *events.EventBus
+	mu     sync.Mutex
+	errors []events.ErrorEventData
+}
+
+// newTestRunner crea el runner; los hooks se ejecutan con sh, así que no se prueba en Windows
+func newTestRunner(t *testing.T, cfg config.Hooks) *testRunner {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use sh -c")
+	}
+	tr := &testRunner{Runner: NewRunner(cfg), bus: events.NewEventBus()}
+	tr.bus.SubscribeFunc(events.ErrorOccurred, func(event events.Event) {
+		if data, ok := event.Data.(events.ErrorEventData); ok {
+			tr.mu.Lock()
+			tr.errors = append(tr.errors, data)
+			tr.mu.Unlock()
+		}
+	})
+	tr.Subscribe(tr.bus)
+	t.Cleanup(tr.Wait)
+	return tr
+}
+
+// reported retorna una copia de los errores publicados hasta ahora
+func (tr *testRunner) reported() []events.ErrorEventData {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return append([]events.ErrorEventData(nil), tr.errors...)
+}
+
+// waitFor espera a que se cumpla la condición; el bus entrega los eventos en goroutines
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// fileExists indica si existe un archivo
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestRunnerEnvAndStdin(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOOK_DIR", dir)
+	tr := newTestRunner(t, config.Hooks{Commands: map[events.EventType][]string{
+		events.PomodoroCompleted: {
+			`printf '%s|%s|%s' "$GOMODORO_EVENT" "$GOMODORO_TASK" "$GOMODORO_DURATION" > "$HOOK_DIR/env"`,
+			`cat > "$HOOK_DIR/stdin.tmp" && mv "$HOOK_DIR/stdin.tmp" "$HOOK_DIR/stdin"`,
+		},
+	}})
+
+	tr.bus.Publish(events.PomodoroCompleted, events.PomodoroEventData{Task: "deep work", Duration: 25 * time.Minute})
+	// Los comandos de un evento se ejecutan en orden: cuando existe stdin ya terminó el primero
+	waitFor(t, "hooks", func() bool { return fileExists(filepath.Join(dir, "stdin")) })
+
+	env, err := os.ReadFile(filepath.Join(dir, "env"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(env) != "pomodoro_completed|deep work|1500" {
+		t.Errorf("unexpected environment: %q", env)
+	}
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event struct {
+		Type events.EventType         `json:"type"`
+		Data events.PomodoroEventData `json:"data"`
+	}
+	if err := json.Unmarshal(stdin, &event); err != nil {
+		t.Fatalf("stdin is not the event JSON: %v\n%s", err, stdin)
+	}
+	if event.Type != events.PomodoroCompleted || event.Data.Task != "deep work" {
+		t.Errorf("unexpected event on stdin: %+v", event)
+	}
+	if errors := tr.reported(); len(errors) != 0 {
+		t.Errorf("unexpected errors: %+v", errors)
+	}
+}
+
+func TestRunnerFailure(t *testing.T) {
+	tr := newTestRunner(t, config.Hooks{Commands: map[events.EventType][]string{
+		events.TimerStarted: {"echo boom >&2; exit 3"},
+	}})
+
+	tr.bus.Publish(events.TimerStarted, nil)
+	waitFor(t, "failure report", func() bool { return len(tr.reported()) > 0 })
+
+	data := tr.reported()[0]
+	result, _ := data.Details.(Result)
+	if data.Code != CodeFailed || data.Source != Source || !strings.Contains(data.Message, "boom") {
+		t.Errorf("unexpected error: %+v", data)
+	}
+	if result.ExitCode != 3 || result.Stderr != "boom" || result.Event != events.TimerStarted {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// Los errores de los hooks no vuelven a lanzar los hooks de error_occurred
+	tr.Update(config.Hooks{Commands: map[events.EventType][]string{events.ErrorOccurred: {"exit 1"}}})
+	tr.report(CodeFailed, "again", Result{})
+	time.Sleep(50 * time.Millisecond)
+	if errors := tr.reported(); len(errors) != 2 {
+		t.Errorf("a failing error_occurred hook must not report itself, got %d errors", len(errors))
+	}
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	tr := newTestRunner(t, config.Hooks{
+		Timeout:  100 * time.Millisecond,
+		Commands: map[events.EventType][]string{events.TimerStarted: {"exec sleep 5"}},
+	})
+
+	started := time.Now()
+	tr.bus.Publish(events.TimerStarted, nil)
+	waitFor(t, "timeout report", func() bool { return len(tr.reported()) > 0 })
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Errorf("the hook was not stopped on time (took %s)", elapsed)
+	}
+
+	data := tr.reported()[0]
+	result, _ := data.Details.(Result)
+	if data.Code != CodeTimeout || !strings.Contains(data.Message, "timed out after 100ms") {
+		t.Errorf("unexpected error: %+v", data)
+	}
+	if result.ExitCode != -1 {
+		t.Errorf("a stopped hook has no exit code, got %d", result.ExitCode)
+	}
+}
+
+func TestRunnerBusy(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOOK_DIR", dir)
+	commands := map[events.EventType][]string{
+		events.TimerStarted: {`touch "$HOOK_DIR/started"; sleep 0.5; exit 7`},
+	}
+	tr := newTestRunner(t, config.Hooks{Timeout: 3 * time.Second, MaxConcurrent: 1, Commands: commands})
+
+	tr.bus.Publish(events.TimerStarted, nil)
+	waitFor(t, "first hook", func() bool { return fileExists(filepath.Join(dir, "started")) })
+
+	// Con el mismo límite se conserva el semáforo: el segundo evento espera poco por un hueco
+	tr.Update(config.Hooks{Timeout: 100 * time.Millisecond, MaxConcurrent: 1, Commands: commands})
+	tr.bus.Publish(events.TimerStarted, nil)
+	waitFor(t, "both reports", func() bool { return len(tr.reported()) == 2 })
+
+	reported := tr.reported()
+	busy, _ := reported[0].Details.(Result)
+	if reported[0].Code != CodeBusy || !strings.Contains(reported[0].Message, "1 hooks already running") {
+		t.Errorf("unexpected error: %+v", reported[0])
+	}
+	if busy.ExitCode != -1 {
+		t.Errorf("a skipped hook has no exit code, got %d", busy.ExitCode)
+	}
+
+	// El primero termina con el tiempo máximo con el que empezó, no con el nuevo
+	first, _ := reported[1].Details.(Result)
+	if reported[1].Code != CodeFailed || first.ExitCode != 7 {
+		t.Errorf("the running hook must finish with its own timeout, got %+v", reported[1])
+	}
+}
@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// Valores de la etiqueta outcome
+const (
+	OutcomeCompleted = "completed"
+	OutcomeSkipped   = "skipped"
+)
+
+// Motivos de la etiqueta reason de los fallbacks de DM en Discord
+const (
+	ReasonDMUnavailable = "dm_unavailable"
+	ReasonDMFailed      = "dm_failed"
+)
+
+// timerState es el estado del timer de un engine visto desde sus eventos
+type timerState int
+
+const (
+	stateIdle timerState = iota
+	stateRunning
+	statePaused
+)
+
+// busState guarda el último estado conocido de un bus de eventos
+type busState struct {
+	state     timerState
+	updatedAt time.Time
+}
+
+// Collector escucha los buses de eventos de los engines y mantiene las métricas de la aplicación.
+// Todos sus métodos aceptan un receptor nil, así las métricas son opcionales sin comprobar en cada llamada.
+type Collector struct {
+	registry *Registry
+
+	sessions             *CounterVec
+	sessionSeconds       *CounterVec
+	notificationFailures *CounterVec
+	dmFallbacks          *CounterVec
+	busLag               *Histogram
+
+	mu    sync.Mutex
+	buses map[*events.EventBus]*busState
+}
+
+// NewCollector crea un collector con sus métricas registradas en un registro nuevo
+func NewCollector() *Collector {
+	c := &Collector{
+		registry: NewRegistry(),
+		buses:    make(map[*events.EventBus]*busState),
+	}
+
+	c.sessions = c.registry.Counter("gomodoro_sessions_total",
+		"Sesiones terminadas por tipo y resultado", "kind", "outcome")
+	c.sessionSeconds = c.registry.Counter("gomodoro_session_seconds_total",
+		"Segundos de sesión terminada por tipo", "kind")
+	c.registry.GaugeFunc("gomodoro_active_sessions",
+		"Sesiones con el timer en marcha", func() float64 { return c.count(stateRunning) })
+	c.registry.GaugeFunc("gomodoro_paused_sessions",
+		"Sesiones con el timer en pausa", func() float64 { return c.count(statePaused) })
+	c.registry.GaugeFunc("gomodoro_engines",
+		"Engines suscritos al collector", func() float64 { return c.count(-1) })
+	c.notificationFailures = c.registry.Counter("gomodoro_notification_failures_total",
+		"Notificaciones que no se pudieron entregar por canal", "channel")
+	c.dmFallbacks = c.registry.Counter("gomodoro_discord_dm_fallbacks_total",
+		"Notificaciones de Discord enviadas al canal por no poder usar DM", "reason")
+	c.busLag = c.registry.Histogram("gomodoro_event_bus_lag_seconds",
+		"Tiempo entre la publicación de un evento y su recepción", DefaultLagBuckets)
+
+	// Series a cero para que aparezcan antes de la primera sesión
+	for _, kind := range []events.SessionKind{events.KindWork, events.KindShortBreak, events.KindLongBreak} {
+		c.sessions.Add(0, string(kind), OutcomeCompleted)
+		c.sessions.Add(0, string(kind), OutcomeSkipped)
+		c.sessionSeconds.Add(0, string(kind))
+	}
+
+	return c
+}
+
+// Registry retorna el registro con las métricas del collector
+func (c *Collector) Registry() *Registry {
+	if c == nil {
+		return nil
+	}
+	return c.registry
+}
+
+// Subscribe registra el collector en todos los eventos del bus de un engine
+func (c *Collector) Subscribe(eventBus *events.EventBus) {
+	if c == nil || eventBus == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.buses[eventBus] = &busState{}
+	c.mu.Unlock()
+
+	eventBus.SubscribeGlobalFunc(func(event events.Event) {
+		c.handleEvent(eventBus, event)
+	})
+}
+
+// handleEvent actualiza las métricas con un evento de un bus
+func (c *Collector) handleEvent(eventBus *events.EventBus, event events.Event) {
+	c.busLag.Observe(time.Since(event.Timestamp).Seconds())
+
+	switch event.Type {
+	case events.TimerStarted, events.TimerResumed:
+		c.setState(eventBus, event.Timestamp, stateRunning)
+	case events.TimerPaused:
+		c.setState(eventBus, event.Timestamp, statePaused)
+	case events.TimerCompleted, events.TimerSkipped:
+		c.setState(eventBus, event.Timestamp, stateIdle)
+	case events.EngineStopped:
+		c.mu.Lock()
+		delete(c.buses, eventBus)
+		c.mu.Unlock()
+
+	case events.PomodoroCompleted, events.PomodoroSkipped, events.BreakCompleted, events.BreakSkipped:
+		c.recordSession(event)
+	}
+}
+
+// setState cambia el estado de un bus ignorando eventos más antiguos que el último aplicado,
+// ya que el bus entrega cada evento en su propia goroutine
+func (c *Collector) setState(eventBus *events.EventBus, at time.Time, state timerState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bus, ok := c.buses[eventBus]
+	if !ok || at.Before(bus.updatedAt) {
+		return
+	}
+	bus.state = state
+	bus.updatedAt = at
+}
+
+// count retorna el número de buses en el estado indicado (-1 para todos)
+func (c *Collector) count(state timerState) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state < 0 {
+		return float64(len(c.buses))
+	}
+
+	total := 0
+	for _, bus := range c.buses {
+		if bus.state == state {
+			total++
+		}
+	}
+	return float64(total)
+}
+
+// recordSession cuenta una sesión terminada
+func (c *Collector) recordSession(event events.Event) {
+	outcome := OutcomeCompleted
+	if event.Type == events.PomodoroSkipped || event.Type == events.BreakSkipped {
+		outcome = OutcomeSkipped
+	}
+
+	var kind events.SessionKind
+	var actual time.Duration
+
+	switch data := event.Data.(type) {
+	case events.PomodoroEventData:
+		kind, actual = events.KindWork, data.ActualTime
+	case events.BreakEventData:
+		kind, actual = data.Type, data.ActualTime
+	default:
+		return
+	}
+
+	c.sessions.Inc(string(kind), outcome)
+	c.sessionSeconds.Add(actual.Seconds(), string(kind))
+}
+
+// NotificationFailed cuenta una notificación que no se pudo entregar por el canal indicado
+func (c *Collector) NotificationFailed(channel string) {
+	if c == nil {
+		return
+	}
+	c.notificationFailures.Inc(channel)
+}
+
+// DMFallback cuenta una notificación de Discord enviada al canal en lugar de por DM
+func (c *Collector) DMFallback(reason string) {
+	if c == nil {
+		return
+	}
+	c.dmFallbacks.Inc(reason)
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// waitFor espera a que se cumpla la condición; el bus entrega los eventos en goroutines
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCollectorTimerState(t *testing.T) {
+	collector := NewCollector()
+	bus := events.NewEventBus()
+	collector.Subscribe(bus)
+
+	if engines := collector.count(-1); engines != 1 {
+		t.Fatalf("expected 1 engine after Subscribe, got %v", engines)
+	}
+
+	bus.Publish(events.TimerStarted, nil)
+	waitFor(t, "running", func() bool { return collector.count(stateRunning) == 1 })
+
+	bus.Publish(events.TimerPaused, nil)
+	waitFor(t, "paused", func() bool { return collector.count(statePaused) == 1 })
+	if running := collector.count(stateRunning); running != 0 {
+		t.Errorf("a paused timer must not count as running, got %v", running)
+	}
+
+	bus.Publish(events.EngineStopped, nil)
+	waitFor(t, "engine removed", func() bool { return collector.count(-1) == 0 })
+
+	// Los eventos que llegan después de parar no vuelven a añadir el engine
+	bus.Publish(events.TimerResumed, nil)
+	time.Sleep(20 * time.Millisecond)
+	if engines, running := collector.count(-1), collector.count(stateRunning); engines != 0 || running != 0 {
+		t.Errorf("a stopped engine must stay removed, got %v engines and %v running", engines, running)
+	}
+}
+
+func TestCollectorIgnoresOlderEvents(t *testing.T) {
+	collector := NewCollector()
+	bus := events.NewEventBus()
+	collector.Subscribe(bus)
+
+	// Entregados fuera de orden, como puede ocurrir con una goroutine por evento
+	now := time.Now()
+	collector.handleEvent(bus, events.Event{Type: events.TimerPaused, Timestamp: now})
+	collector.handleEvent(bus, events.Event{Type: events.TimerStarted, Timestamp: now.Add(-time.Second)})
+
+	if paused, running := collector.count(statePaused), collector.count(stateRunning); paused != 1 || running != 0 {
+		t.Fatalf("the older start must be ignored, got %v paused and %v running", paused, running)
+	}
+
+	collector.handleEvent(bus, events.Event{Type: events.TimerCompleted, Timestamp: now.Add(time.Second)})
+	if paused := collector.count(statePaused); paused != 0 {
+		t.Fatalf("a newer completion must apply, got %v paused", paused)
+	}
+}
+
+func TestCollectorSessions(t *testing.T) {
+	collector := NewCollector()
+	bus := events.NewEventBus()
+	collector.Subscribe(bus)
+
+	bus.Publish(events.PomodoroCompleted, events.PomodoroEventData{ActualTime: 25 * time.Minute})
+	bus.Publish(events.BreakSkipped, events.BreakEventData{Type: events.KindShortBreak, ActualTime: time.Minute})
+
+	output := func() string {
+		var out strings.Builder
+		collector.Registry().WriteTo(&out)
+		return out.String()
+	}
+	for _, line := range []string{
+		`gomodoro_sessions_total{kind="work",outcome="completed"} 1`,
+		`gomodoro_sessions_total{kind="short_break",outcome="skipped"} 1`,
+		`gomodoro_session_seconds_total{kind="work"} 1500`,
+		`gomodoro_event_bus_lag_seconds_count 2`,
+	} {
+		waitFor(t, line, func() bool { return strings.Contains(output(), line) })
+	}
+	if !strings.Contains(output(), `gomodoro_sessions_total{kind="long_break",outcome="completed"} 0`) {
+		t.Errorf("series for every kind must exist from the start:\n%s", output())
+	}
+}
+
+func TestCollectorNil(t *testing.T) {
+	var collector *Collector
+	collector.Subscribe(events.NewEventBus())
+	collector.NotificationFailed("desktop")
+	collector.DMFallback(ReasonDMFailed)
+	if collector.Registry() != nil {
+		t.Errorf("a nil collector must have no registry")
+	}
+}
@@ -0,0 +1,322 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType es el tipo MIME del formato de texto de Prometheus
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricType define el tipo de una familia de métricas
+type metricType string
+
+const (
+	typeCounter   metricType = "counter"
+	typeGauge     metricType = "gauge"
+	typeHistogram metricType = "histogram"
+)
+
+// family agrupa las series de una métrica con sus etiquetas
+type family struct {
+	name       string
+	help       string
+	kind       metricType
+	labelNames []string
+
+	mu      sync.Mutex
+	values  map[string]float64  // clave de etiquetas -> valor (counter y gauge)
+	labels  map[string][]string // clave de etiquetas -> valores de etiquetas
+	valueFn func() float64      // gauge calculado al exportar
+	buckets []float64           // límites del histograma
+	hist    *histogramData
+}
+
+// histogramData contiene las observaciones de un histograma
+type histogramData struct {
+	counts []uint64 // Por bucket, no acumulado
+	sum    float64
+	count  uint64
+}
+
+// Registry contiene las métricas de una aplicación y las exporta en formato Prometheus
+type Registry struct {
+	mu       sync.RWMutex
+	families []*family
+	byName   map[string]*family
+}
+
+// NewRegistry crea un registro de métricas vacío
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*family)}
+}
+
+// register añade una familia; registrar dos veces el mismo nombre retorna la existente
+func (r *Registry) register(f *family) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.byName[f.name]; ok {
+		return existing
+	}
+	f.values = make(map[string]float64)
+	f.labels = make(map[string][]string)
+	r.families = append(r.families, f)
+	r.byName[f.name] = f
+	return f
+}
+
+// CounterVec es un contador con etiquetas
+type CounterVec struct{ f *family }
+
+// Counter registra un contador con las etiquetas indicadas
+func (r *Registry) Counter(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{r.register(&family{name: name, help: help, kind: typeCounter, labelNames: labelNames})}
+}
+
+// Inc incrementa en 1 la serie con los valores de etiqueta indicados
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add suma delta (no negativo) a la serie con los valores de etiqueta indicados
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	c.f.add(delta, labelValues)
+}
+
+// GaugeVec es un valor que puede subir y bajar, con etiquetas
+type GaugeVec struct{ f *family }
+
+// Gauge registra un gauge con las etiquetas indicadas
+func (r *Registry) Gauge(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{r.register(&family{name: name, help: help, kind: typeGauge, labelNames: labelNames})}
+}
+
+// Set fija el valor de la serie con los valores de etiqueta indicados
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.f.set(value, labelValues)
+}
+
+// Add suma delta a la serie con los valores de etiqueta indicados
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.f.add(delta, labelValues)
+}
+
+// GaugeFunc registra un gauge sin etiquetas cuyo valor se calcula al exportar
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	r.register(&family{name: name, help: help, kind: typeGauge, valueFn: fn})
+}
+
+// Histogram es una distribución de observaciones en buckets
+type Histogram struct{ f *family }
+
+// DefaultLagBuckets son buckets en segundos adecuados para latencias internas
+var DefaultLagBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+// Histogram registra un histograma sin etiquetas con los límites indicados (ordenados)
+func (r *Registry) Histogram(name, help string, buckets []float64) *Histogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	f := r.register(&family{name: name, help: help, kind: typeHistogram, buckets: sorted})
+
+	f.mu.Lock()
+	if f.hist == nil {
+		f.hist = &histogramData{counts: make([]uint64, len(f.buckets))}
+	}
+	f.mu.Unlock()
+	return &Histogram{f}
+}
+
+// Observe añade una observación al histograma
+func (h *Histogram) Observe(value float64) {
+	h.f.mu.Lock()
+	defer h.f.mu.Unlock()
+
+	idx := sort.SearchFloat64s(h.f.buckets, value)
+	if idx < len(h.f.buckets) {
+		h.f.hist.counts[idx]++
+	}
+	h.f.hist.sum += value
+	h.f.hist.count++
+}
+
+// add suma delta a una serie
+func (f *family) add(delta float64, labelValues []string) {
+	key := f.key(labelValues)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] += delta
+	f.labels[key] = labelValues
+}
+
+// set fija el valor de una serie
+func (f *family) set(value float64, labelValues []string) {
+	key := f.key(labelValues)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+	f.labels[key] = labelValues
+}
+
+// key valida el número de etiquetas y construye la clave de la serie
+func (f *family) key(labelValues []string) string {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// WriteTo escribe todas las métricas en formato de texto de Prometheus
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.RLock()
+	families := append([]*family(nil), r.families...)
+	r.mu.RUnlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		f.write(cw)
+	}
+	if cw.err == nil {
+		cw.err = cw.w.(*bufio.Writer).Flush()
+	}
+	return cw.n, cw.err
+}
+
+// write escribe una familia de métricas
+func (f *family) write(w *countingWriter) {
+	w.printf("# HELP %s %s\n", f.name, escapeHelp(f.help))
+	w.printf("# TYPE %s %s\n", f.name, f.kind)
+
+	if f.valueFn != nil {
+		w.printf("%s %s\n", f.name, formatValue(f.valueFn()))
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.hist != nil {
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += f.hist.counts[i]
+			w.printf("%s_bucket{le=\"%s\"} %d\n", f.name, formatValue(bound), cumulative)
+		}
+		w.printf("%s_bucket{le=\"+Inf\"} %d\n", f.name, f.hist.count)
+		w.printf("%s_sum %s\n", f.name, formatValue(f.hist.sum))
+		w.printf("%s_count %d\n", f.name, f.hist.count)
+		return
+	}
+
+	keys := make([]string, 0, len(f.values))
+	for key := range f.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		w.printf("%s%s %s\n", f.name, formatLabels(f.labelNames, f.labels[key]), formatValue(f.values[key]))
+	}
+}
+
+// Handler retorna un http.Handler que sirve las métricas
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.WriteTo(w)
+	})
+}
+
+// ListenAndServe sirve /metrics en la dirección indicada en segundo plano.
+// Retorna el servidor, con Addr resuelta, para poder cerrarlo con Shutdown.
+func (r *Registry) ListenAndServe(addr string) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r.Handler())
+
+	server := &http.Server{
+		Addr:              listener.Addr().String(),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go server.Serve(listener)
+
+	return server, nil
+}
+
+// formatLabels formatea las etiquetas de una serie ({a="1",b="2"})
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabel(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// formatValue formatea un valor según el formato de Prometheus
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// escapeLabel escapa barras, comillas y saltos de línea en valores de etiqueta
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// escapeHelp escapa barras y saltos de línea en el texto de ayuda
+func escapeHelp(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(value)
+}
+
+// countingWriter cuenta los bytes escritos y recuerda el primer error
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// printf escribe con formato si no hubo errores previos
+func (cw *countingWriter) printf(format string, args ...interface{}) {
+	if cw.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(cw.w, format, args...)
+	cw.n += int64(n)
+	cw.err = err
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteTo(t *testing.T) {
+	registry := NewRegistry()
+
+	requests := registry.Counter("requests_total", "Peticiones\ncon \\ barra", "path", "code")
+	requests.Inc(`C:\tmp`, "200")
+	requests.Add(2, "a\"b\nc", "500")
+	requests.Add(-1, `C:\tmp`, "200") // Los contadores no bajan
+
+	registry.Gauge("temperature", "Temperatura").Set(21.5)
+
+	// Límites desordenados: se ordenan al registrar
+	lag := registry.Histogram("lag_seconds", "Latencia", []float64{1, 0.5, 5})
+	for _, value := range []float64{0.25, 0.5, 3, 10} {
+		lag.Observe(value)
+	}
+
+	registry.GaugeFunc("up", "Siempre", func() float64 { return math.Inf(1) })
+
+	want := `# HELP requests_total Peticiones\ncon \\ barra
+# TYPE requests_total counter
+requests_total{path="C:\\tmp",code="200"} 1
+requests_total{path="a\"b\nc",code="500"} 2
+# HELP temperature Temperatura
+# TYPE temperature gauge
+temperature 21.5
+# HELP lag_seconds Latencia
+# TYPE lag_seconds histogram
+lag_seconds_bucket{le="0.5"} 2
+lag_seconds_bucket{le="1"} 2
+lag_seconds_bucket{le="5"} 3
+lag_seconds_bucket{le="+Inf"} 4
+lag_seconds_sum 13.75
+lag_seconds_count 4
+# HELP up Siempre
+# TYPE up gauge
+up +Inf
+`
+
+	var out strings.Builder
+	n, err := registry.WriteTo(&out)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if got := out.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+	if n != int64(out.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, out.Len())
+	}
+}
+
+func TestRegistryRegisterTwice(t *testing.T) {
+	registry := NewRegistry()
+	registry.Counter("events_total", "Eventos", "type").Inc("a")
+	registry.Counter("events_total", "Otra ayuda", "type").Inc("a")
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	if !strings.Contains(out.String(), `events_total{type="a"} 2`) || strings.Contains(out.String(), "Otra ayuda") {
+		t.Fatalf("registering twice must reuse the family:\n%s", out.String())
+	}
+}
@@ -0,0 +1,185 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+)
+
+// DayStreakConfig define qué cuenta como un día cumplido para la racha diaria
+type DayStreakConfig struct {
+	MinPomodoros int            // Pomodoros completados necesarios por día
+	RestDays     []time.Weekday // Días que no rompen la racha si no se cumple el objetivo
+	Location     *time.Location // Zona horaria para delimitar los días
+}
+
+// DayStreak representa la racha de días consecutivos cumpliendo el objetivo
+type DayStreak struct {
+	Current        int
+	Best           int
+	TodayCompleted int
+	GoalMetToday   bool
+	MinPomodoros   int
+}
+
+// DefaultDayStreakConfig retorna la configuración por defecto (4 pomodoros al día, sin descansos)
+func DefaultDayStreakConfig() DayStreakConfig {
+	return DayStreakConfig{
+		MinPomodoros: 4,
+		Location:     time.Local,
+	}
+}
+
+// IsRestDay indica si el día de la semana está marcado como descanso
+func (c DayStreakConfig) IsRestDay(day time.Weekday) bool {
+	for _, rest := range c.RestDays {
+		if rest == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeDayStreak calcula la racha diaria hasta now. Un día de descanso sin objetivo cumplido
+// no rompe ni suma a la racha, y el día en curso solo suma cuando se cumple el objetivo.
+func ComputeDayStreak(sessions []CompletedSession, config DayStreakConfig, now time.Time) DayStreak {
+	return computeDayStreak(sessions, nil, config, now)
+}
+
+// computeDayStreak calcula la racha sumando también los días compactados
+func computeDayStreak(sessions []CompletedSession, daily []DailyAggregate, config DayStreakConfig, now time.Time) DayStreak {
+	loc := config.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	minPomodoros := config.MinPomodoros
+	if minPomodoros < 1 {
+		minPomodoros = 1
+	}
+
+	streak := DayStreak{MinPomodoros: minPomodoros}
+
+	perDay := make(map[time.Time]int)
+	var first time.Time
+	for _, session := range sessions {
+		if !session.Type.IsWork() || !session.Completed {
+			continue
+		}
+		day, _ := PeriodBounds(PeriodDay, session.StartTime, loc)
+		perDay[day]++
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+	for _, aggregate := range daily {
+		if aggregate.PomodorosCompleted == 0 {
+			continue
+		}
+		day := aggregate.DayIn(loc)
+		perDay[day] += aggregate.PomodorosCompleted
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+
+	today, _ := PeriodBounds(PeriodDay, now, loc)
+	streak.TodayCompleted = perDay[today]
+	streak.GoalMetToday = streak.TodayCompleted >= minPomodoros
+
+	if first.IsZero() {
+		return streak
+	}
+
+	running := 0
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		switch {
+		case perDay[day] >= minPomodoros:
+			running++
+		case config.IsRestDay(day.Weekday()) || day.Equal(today):
+			// Ni suma ni rompe: descanso o día aún en curso
+		default:
+			running = 0
+		}
+		if running > streak.Best {
+			streak.Best = running
+		}
+	}
+	streak.Current = running
+
+	return streak
+}
+
+// GenerateDayStreak calcula la racha diaria de un usuario a partir del historial
+func GenerateDayStreak(store Store, userID string, config DayStreakConfig, now time.Time) (DayStreak, error) {
+	result, err := NewQuery().ForUser(userID).WorkOnly().Completed().Run(store)
+	if err != nil {
+		return DayStreak{}, err
+	}
+	daily, err := storeDaily(store, userID, time.Time{}, time.Time{})
+	if err != nil {
+		return DayStreak{}, fmt.Errorf("failed to read daily aggregates: %w", err)
+	}
+	return computeDayStreak(result.CompletedSessions(), daily, config, now), nil
+}
+
+// ConfigureDayStreak indica de dónde calcular la racha diaria del snapshot.
+// Con store nil se usan solo las sesiones en memoria.
+func (s *SessionStats) ConfigureDayStreak(store Store, userID string, config DayStreakConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.historyStore = store
+	s.historyUserID = userID
+	s.dayStreakConfig = config
+	s.invalidateDayStreak()
+}
+
+// InvalidateDayStreak descarta la racha diaria calculada. Hay que llamarlo si el historial
+// cambia sin pasar por estas estadísticas (por ejemplo, al importar sesiones con MergeIntoStore).
+func (s *SessionStats) InvalidateDayStreak() {
+	s.invalidateDayStreak()
+}
+
+// invalidateDayStreak descarta la racha en caché
+func (s *SessionStats) invalidateDayStreak() {
+	s.streakMu.Lock()
+	s.streakValid = false
+	s.streakMu.Unlock()
+}
+
+// calculateDayStreak retorna la racha diaria, recalculándola solo si cambió el historial
+// o el día (debe llamarse con lock)
+func (s *SessionStats) calculateDayStreak() DayStreak {
+	now := time.Now()
+	loc := s.dayStreakConfig.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	today, _ := PeriodBounds(PeriodDay, now, loc)
+
+	s.streakMu.Lock()
+	defer s.streakMu.Unlock()
+	if s.streakValid && s.streakDay.Equal(today) {
+		return s.streak
+	}
+
+	s.streak = s.loadDayStreak(now)
+	s.streakDay = today
+	s.streakValid = true
+	return s.streak
+}
+
+// loadDayStreak calcula la racha diaria uniendo historial y sesiones en memoria (debe llamarse con lock)
+func (s *SessionStats) loadDayStreak(now time.Time) DayStreak {
+	sessions := s.CompletedSessions
+	var daily []DailyAggregate
+
+	if s.historyStore != nil {
+		// Las sesiones recientes pueden no estar aún en el historial; se unen sin duplicar por ID
+		if records, err := s.historyStore.Range(s.historyUserID, time.Time{}, time.Time{}); err == nil {
+			sessions, _ = MergeSessions(RecordSessions(records), s.CompletedSessions)
+		}
+		daily, _ = storeDaily(s.historyStore, s.historyUserID, time.Time{}, time.Time{})
+	}
+
+	return computeDayStreak(sessions, daily, s.dayStreakConfig, now)
+}
@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// countingStore cuenta las lecturas del historial
+type countingStore struct {
+	*MemoryStore
+	mu     sync.Mutex
+	ranges int
+}
+
+func (c *countingStore) Range(userID string, from, to time.Time) ([]SessionRecord, error) {
+	c.mu.Lock()
+	c.ranges++
+	c.mu.Unlock()
+	return c.MemoryStore.Range(userID, from, to)
+}
+
+func (c *countingStore) reads() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ranges
+}
+
+func TestDayStreakCache(t *testing.T) {
+	store := &countingStore{MemoryStore: NewMemoryStore()}
+	s := NewSessionStats()
+	s.ConfigureDayStreak(store, "u", DayStreakConfig{MinPomodoros: 1, Location: time.UTC})
+
+	now := time.Now()
+	store.Append(SessionRecord{UserID: "u", CompletedSession: CompletedSession{ID: "old",
+		Type: events.KindWork, Completed: true, StartTime: now.AddDate(0, 0, -1), Duration: 25 * time.Minute}})
+
+	first := s.GetSnapshot().DayStreak
+	reads := store.reads()
+	if reads == 0 {
+		t.Fatalf("expected the streak to read the history")
+	}
+	if again := s.GetSnapshot().DayStreak; again != first || store.reads() != reads {
+		t.Fatalf("expected a cached streak without reading the history again (reads %d -> %d)", reads, store.reads())
+	}
+
+	// Una sesión nueva invalida la racha
+	s.AddSession(CompletedSession{Type: events.KindWork, Completed: true, StartTime: now, Duration: 25 * time.Minute})
+	updated := s.GetSnapshot().DayStreak
+	if store.reads() == reads {
+		t.Fatalf("expected a recalculation after AddSession")
+	}
+	if updated.TodayCompleted != first.TodayCompleted+1 {
+		t.Fatalf("today completed = %d, want %d", updated.TodayCompleted, first.TodayCompleted+1)
+	}
+
+	// Al cambiar de día también se recalcula
+	reads = store.reads()
+	s.streakMu.Lock()
+	s.streakDay = s.streakDay.AddDate(0, 0, -1)
+	s.streakMu.Unlock()
+	s.GetSnapshot()
+	if store.reads() == reads {
+		t.Fatalf("expected a recalculation after the day changed")
+	}
+
+	// Los cambios externos del historial necesitan InvalidateDayStreak
+	reads = store.reads()
+	s.InvalidateDayStreak()
+	s.GetSnapshot()
+	if store.reads() == reads {
+		t.Fatalf("expected a recalculation after InvalidateDayStreak")
+	}
+}
@@ -0,0 +1,306 @@
+package stats
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat define los formatos de exportación del historial
+type ExportFormat string
+
+const (
+	FormatJSON      ExportFormat = "json"
+	FormatCSV       ExportFormat = "csv"
+	FormatMarkdown  ExportFormat = "md"
+	FormatICalendar ExportFormat = "ics"
+)
+
+// ParseExportFormat convierte un texto a ExportFormat
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "ics", "ical", "icalendar":
+		return FormatICalendar, nil
+	default:
+		return "", fmt.Errorf("unknown export format: %s", value)
+	}
+}
+
+// Extension retorna la extensión de archivo del formato (sin punto)
+func (f ExportFormat) Extension() string {
+	return string(f)
+}
+
+// ContentType retorna el tipo MIME del formato
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatMarkdown:
+		return "text/markdown"
+	case FormatICalendar:
+		return "text/calendar"
+	default:
+		return "application/json"
+	}
+}
+
+// Export escribe los registros en el formato indicado
+func Export(w io.Writer, format ExportFormat, records []SessionRecord, loc *time.Location) error {
+	switch format {
+	case FormatJSON:
+		return ExportRecordsJSON(w, records)
+	case FormatCSV:
+		return ExportCSV(w, records)
+	case FormatMarkdown:
+		return ExportMarkdown(w, records, loc)
+	case FormatICalendar:
+		return ExportICalendar(w, records)
+	default:
+		return fmt.Errorf("unknown export format: %s", format)
+	}
+}
+
+// ExportRecordsJSON escribe los registros como un array JSON
+func ExportRecordsJSON(w io.Writer, records []SessionRecord) error {
+	if records == nil {
+		records = []SessionRecord{}
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal records: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write JSON export: %w", err)
+	}
+	return nil
+}
+
+// ExportCSV escribe una fila por sesión
+func ExportCSV(w io.Writer, records []SessionRecord) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"user_id", "type", "completed", "start_time", "end_time", "duration_seconds", "actual_seconds", "pause_count", "paused_seconds", "focus_score", "task", "project", "tags", "rule"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, record := range records {
+		row := []string{
+			record.UserID,
+			string(record.Type),
+			strconv.FormatBool(record.Completed),
+			record.StartTime.Format(time.RFC3339),
+			record.EndTime.Format(time.RFC3339),
+			strconv.FormatInt(int64(record.Duration.Seconds()), 10),
+			strconv.FormatInt(int64(record.ActualTime.Seconds()), 10),
+			strconv.Itoa(record.PauseCount),
+			strconv.FormatInt(int64(record.PausedTime.Seconds()), 10),
+			strconv.FormatFloat(record.FocusScore(), 'f', 1, 64),
+			record.Task,
+			record.Project,
+			strings.Join(record.Tags, ","),
+			record.Rule,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV export: %w", err)
+	}
+	return nil
+}
+
+// ExportMarkdown escribe tablas resumen (totales y desglose diario) en Markdown
+func ExportMarkdown(w io.Writer, records []SessionRecord, loc *time.Location) error {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	sessions := RecordSessions(records)
+	var buf bytes.Buffer
+
+	buf.WriteString("# 🍅 Historial de Pomodoros\n\n")
+
+	if len(sessions) == 0 {
+		buf.WriteString("_Sin sesiones registradas._\n")
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+
+	days := AggregateSessions(sessions, PeriodDay, loc)
+	first, last := days[0].Start, days[len(days)-1].Start
+	total := aggregate(sessions, first, days[len(days)-1].End)
+
+	buf.WriteString(fmt.Sprintf("Del %s al %s (%s)\n\n", first.Format("2006-01-02"), last.Format("2006-01-02"), loc.String()))
+
+	buf.WriteString("## Resumen\n\n")
+	buf.WriteString("| Métrica | Valor |\n")
+	buf.WriteString("| --- | ---: |\n")
+	buf.WriteString(fmt.Sprintf("| Pomodoros completados | %d |\n", total.PomodorosCompleted))
+	buf.WriteString(fmt.Sprintf("| Pomodoros saltados | %d |\n", total.PomodorosSkipped))
+	buf.WriteString(fmt.Sprintf("| Descansos completados | %d |\n", total.BreaksCompleted))
+	buf.WriteString(fmt.Sprintf("| Descansos saltados | %d |\n", total.BreaksSkipped))
+	buf.WriteString(fmt.Sprintf("| Tiempo de enfoque | %s |\n", FormatDuration(total.FocusTime)))
+	buf.WriteString(fmt.Sprintf("| Tiempo de descanso | %s |\n", FormatDuration(total.BreakTime)))
+	buf.WriteString(fmt.Sprintf("| Eficiencia | %.1f%% |\n", total.Efficiency))
+	buf.WriteString(fmt.Sprintf("| Enfoque | %.1f%% |\n", total.FocusScore))
+	buf.WriteString(fmt.Sprintf("| Pausas | %d |\n", total.Pauses))
+	buf.WriteString(fmt.Sprintf("| Racha más larga | %d |\n", total.LongestStreak))
+
+	buf.WriteString("\n## Por día\n\n")
+	buf.WriteString("| Día | Completados | Saltados | Enfoque | Descanso | Eficiencia |\n")
+	buf.WriteString("| --- | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, day := range days {
+		buf.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %s | %.1f%% |\n",
+			day.Start.Format("2006-01-02"),
+			day.PomodorosCompleted,
+			day.PomodorosSkipped,
+			FormatDuration(day.FocusTime),
+			FormatDuration(day.BreakTime),
+			day.Efficiency))
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write Markdown export: %w", err)
+	}
+	return nil
+}
+
+// ExportICalendar escribe cada pomodoro como un VEVENT de iCalendar (RFC 5545)
+func ExportICalendar(w io.Writer, records []SessionRecord) error {
+	const icsTime = "20060102T150405Z"
+	var buf bytes.Buffer
+
+	writeLine := func(line string) {
+		buf.WriteString(foldICalLine(line))
+		buf.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format(icsTime)
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//gomodoro//Pomodoro History//ES")
+	writeLine("CALSCALE:GREGORIAN")
+
+	for _, record := range records {
+		if !record.Type.IsWork() {
+			continue
+		}
+
+		end := record.EndTime
+		if end.IsZero() {
+			end = record.StartTime.Add(record.ActualTime)
+		}
+
+		summary := "🍅 Pomodoro"
+		status := "CONFIRMED"
+		if !record.Completed {
+			summary = "⏭️ Pomodoro saltado"
+			status = "CANCELLED"
+		}
+		if !record.Labels.IsEmpty() {
+			summary += ": " + record.Labels.Summary()
+		}
+
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + recordUID(record) + "@gomodoro")
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART:" + record.StartTime.UTC().Format(icsTime))
+		writeLine("DTEND:" + end.UTC().Format(icsTime))
+		writeLine("SUMMARY:" + escapeICalText(summary))
+		writeLine("DESCRIPTION:" + escapeICalText(fmt.Sprintf("Duración configurada: %s\nTiempo real: %s",
+			FormatDuration(record.Duration), FormatDuration(record.ActualTime))))
+		writeLine("STATUS:" + status)
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write iCalendar export: %w", err)
+	}
+	return nil
+}
+
+// recordUID genera un identificador estable para un registro a partir del ID de su sesión,
+// de modo que la misma sesión importada en otro dispositivo actualice el mismo evento
+func recordUID(record SessionRecord) string {
+	sum := sha1.Sum([]byte(record.UserID + "|" + SessionKey(record.CompletedSession)))
+	return hex.EncodeToString(sum[:])
+}
+
+// escapeICalText escapa texto según RFC 5545
+func escapeICalText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// foldICalLine divide líneas de más de 75 octetos sin cortar caracteres UTF-8
+func foldICalLine(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	var folded strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			folded.WriteString("\r\n ")
+			width = 1
+		}
+		folded.WriteRune(r)
+		width += size
+	}
+	return folded.String()
+}
+
+// ExportCSV exporta las sesiones en memoria a CSV
+func (s *SessionStats) ExportCSV() ([]byte, error) {
+	var buf bytes.Buffer
+	err := ExportCSV(&buf, s.sessionRecords())
+	return buf.Bytes(), err
+}
+
+// ExportMarkdown exporta las sesiones en memoria a tablas Markdown
+func (s *SessionStats) ExportMarkdown() ([]byte, error) {
+	var buf bytes.Buffer
+	err := ExportMarkdown(&buf, s.sessionRecords(), time.Local)
+	return buf.Bytes(), err
+}
+
+// ExportICalendar exporta los pomodoros en memoria a iCalendar
+func (s *SessionStats) ExportICalendar() ([]byte, error) {
+	var buf bytes.Buffer
+	err := ExportICalendar(&buf, s.sessionRecords())
+	return buf.Bytes(), err
+}
+
+// sessionRecords convierte las sesiones en memoria a registros sin usuario
+func (s *SessionStats) sessionRecords() []SessionRecord {
+	sessions := s.GetCompletedSessions()
+	records := make([]SessionRecord, len(sessions))
+	for i, session := range sessions {
+		records[i] = SessionRecord{CompletedSession: session}
+	}
+	return records
+}
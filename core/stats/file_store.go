@@ -0,0 +1,363 @@
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileStore implementa DailyStore sobre un archivo local JSON Lines de solo anexado.
+// Cada sesión se escribe en una única línea seguida de fsync, por lo que una
+// caída solo puede dejar incompleta la última línea, que se descarta al abrir.
+//
+// La primera línea es una cabecera con la versión del esquema y le siguen los
+// agregados diarios de días compactados y las sesiones. Los archivos sin cabecera
+// (versión 1) se migran automáticamente al abrirlos.
+//
+// Mientras está abierto, el store mantiene un bloqueo exclusivo sobre el historial:
+// otro proceso que intente abrirlo (o repararlo) recibe ErrHistoryInUse.
+type FileStore struct {
+	mu      sync.RWMutex
+	path    string
+	file    *os.File
+	lock    *os.File
+	records []SessionRecord
+	daily   []DailyAggregate
+	version int
+	skipped int
+}
+
+// historyLine es una línea del archivo de historial: cabecera, agregado diario o sesión
+type historyLine struct {
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	Daily         *DailyAggregate `json:"daily,omitempty"`
+	SessionRecord
+}
+
+// MarshalJSON escribe solo la parte de la línea que corresponde a su tipo
+func (l historyLine) MarshalJSON() ([]byte, error) {
+	switch {
+	case l.SchemaVersion != 0:
+		return json.Marshal(struct {
+			SchemaVersion int `json:"schema_version"`
+		}{l.SchemaVersion})
+	case l.Daily != nil:
+		return json.Marshal(struct {
+			Daily *DailyAggregate `json:"daily"`
+		}{l.Daily})
+	default:
+		return json.Marshal(l.SessionRecord)
+	}
+}
+
+// parseHistoryLine interpreta una línea del historial
+func parseHistoryLine(data []byte) (historyLine, error) {
+	var line historyLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		return line, fmt.Errorf("failed to parse history line: %w", err)
+	}
+	return line, nil
+}
+
+// OpenFileStore abre (o crea) el historial en la ruta indicada. Si otro proceso
+// lo tiene abierto retorna ErrHistoryInUse sin leer ni modificar el archivo.
+func OpenFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	lock, err := lockHistory(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// O_APPEND: cada escritura va al final del archivo aunque se haya leído o recortado antes
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		lock.Close()
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+
+	store := &FileStore{
+		path:    path,
+		file:    file,
+		lock:    lock,
+		records: make([]SessionRecord, 0),
+		daily:   make([]DailyAggregate, 0),
+	}
+
+	if err := store.load(); err != nil {
+		store.file.Close()
+		lock.Close()
+		return nil, err
+	}
+
+	if err := store.upgrade(); err != nil {
+		store.file.Close()
+		lock.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// load lee el historial existente y recorta una posible línea final incompleta
+func (fs *FileStore) load() error {
+	if _, err := fs.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	reader := bufio.NewReader(fs.file)
+	var validSize int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Línea sin salto final: escritura interrumpida, se descarta
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read history file: %w", err)
+		}
+
+		validSize += int64(len(line))
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		parsed, err := parseHistoryLine(line)
+		if err != nil {
+			fs.skipped++
+			continue
+		}
+
+		switch {
+		case parsed.SchemaVersion != 0:
+			if err := checkSchemaVersion(parsed.SchemaVersion); err != nil {
+				return err
+			}
+			fs.version = parsed.SchemaVersion
+		case parsed.Daily != nil:
+			fs.daily = append(fs.daily, *parsed.Daily)
+		default:
+			fs.records = insertSorted(fs.records, parsed.SessionRecord)
+		}
+	}
+	sortDaily(fs.daily)
+
+	if err := fs.file.Truncate(validSize); err != nil {
+		return fmt.Errorf("failed to truncate history file: %w", err)
+	}
+
+	return nil
+}
+
+// upgrade migra un archivo sin cabecera (versión 1) al esquema actual.
+// Un archivo vacío solo recibe la cabecera.
+func (fs *FileStore) upgrade() error {
+	if fs.version == SchemaVersion {
+		return nil
+	}
+
+	if fs.version == 0 && len(fs.records) == 0 && len(fs.daily) == 0 {
+		fs.version = SchemaVersion
+		return fs.writeLines(historyLine{SchemaVersion: SchemaVersion})
+	}
+
+	for i := range fs.records {
+		fs.records[i].ID = SessionKey(fs.records[i].CompletedSession)
+	}
+	return fs.rewrite()
+}
+
+// Append escribe una sesión al final del archivo y la sincroniza a disco
+func (fs *FileStore) Append(record SessionRecord) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.file == nil {
+		return fmt.Errorf("history store is closed")
+	}
+
+	if err := fs.writeLines(historyLine{SessionRecord: record}); err != nil {
+		return err
+	}
+
+	fs.records = insertSorted(fs.records, record)
+	return nil
+}
+
+// Compact aplica la política de retención: las sesiones anteriores al corte se
+// sustituyen por agregados diarios y el archivo se reescribe de forma atómica.
+// Se ejecuta con el bloqueo del store, así que ningún otro proceso usa el historial.
+func (fs *FileStore) Compact(policy RetentionPolicy, now time.Time) (CompactResult, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.file == nil {
+		return CompactResult{}, fmt.Errorf("history store is closed")
+	}
+
+	records, daily, result := CompactRecords(fs.records, fs.daily, policy, now)
+	if result.Compacted == 0 {
+		return result, nil
+	}
+
+	previousRecords, previousDaily := fs.records, fs.daily
+	fs.records, fs.daily = records, daily
+	if err := fs.rewrite(); err != nil {
+		fs.records, fs.daily = previousRecords, previousDaily
+		return CompactResult{}, err
+	}
+	return result, nil
+}
+
+// Daily retorna los agregados diarios de un usuario cuyo día empieza en [from, to)
+func (fs *FileStore) Daily(userID string, from, to time.Time) ([]DailyAggregate, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return filterDaily(fs.daily, userID, from, to), nil
+}
+
+// writeLines añade líneas al final del archivo y las sincroniza a disco (debe llamarse con lock)
+func (fs *FileStore) writeLines(lines ...historyLine) error {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		data, err := json.Marshal(line)
+		if err != nil {
+			return fmt.Errorf("failed to marshal history line: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	if _, err := fs.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := fs.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync history file: %w", err)
+	}
+	return nil
+}
+
+// rewrite sustituye el archivo por el contenido en memoria con la versión actual
+// del esquema y lo vuelve a abrir para seguir anexando (debe llamarse con lock)
+func (fs *FileStore) rewrite() error {
+	if err := writeHistoryFile(fs.path, fs.records, fs.daily); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(fs.path, os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen history file: %w", err)
+	}
+	fs.file.Close()
+	fs.file = file
+	fs.version = SchemaVersion
+	return nil
+}
+
+// writeHistoryFile escribe un historial completo en un archivo temporal y lo renombra
+// sobre la ruta indicada, de forma que una caída nunca deja el archivo a medias
+func writeHistoryFile(path string, records []SessionRecord, daily []DailyAggregate) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	lines := make([]historyLine, 0, 1+len(daily)+len(records))
+	lines = append(lines, historyLine{SchemaVersion: SchemaVersion})
+	for i := range daily {
+		lines = append(lines, historyLine{Daily: &daily[i]})
+	}
+	for _, record := range records {
+		lines = append(lines, historyLine{SessionRecord: record})
+	}
+
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write history line: %w", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close history file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace history file: %w", err)
+	}
+	return nil
+}
+
+// Range retorna las sesiones dentro del rango indicado
+func (fs *FileStore) Range(userID string, from, to time.Time) ([]SessionRecord, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return filterRange(fs.records, userID, from, to), nil
+}
+
+// Close cierra el archivo del historial y libera el bloqueo
+func (fs *FileStore) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.file == nil {
+		return nil
+	}
+
+	err := fs.file.Close()
+	fs.file = nil
+	if lockErr := fs.lock.Close(); err == nil {
+		err = lockErr
+	}
+	fs.lock = nil
+	return err
+}
+
+// Path retorna la ruta del archivo del historial
+func (fs *FileStore) Path() string {
+	return fs.path
+}
+
+// Len retorna el número de sesiones almacenadas
+func (fs *FileStore) Len() int {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return len(fs.records)
+}
+
+// SchemaVersion retorna la versión del esquema del archivo
+func (fs *FileStore) SchemaVersion() int {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.version
+}
+
+// SkippedLines retorna cuántas líneas corruptas se ignoraron al abrir el archivo
+func (fs *FileStore) SkippedLines() int {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.skipped
+}
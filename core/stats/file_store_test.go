@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+func TestFileStoreLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	record := SessionRecord{UserID: "u", CompletedSession: CompletedSession{
+		Type: events.KindWork, StartTime: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), Duration: 25 * time.Minute}}
+	if err := store.Append(record); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenFileStore(path); !errors.Is(err, ErrHistoryInUse) {
+		t.Fatalf("second open: expected ErrHistoryInUse, got %v", err)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Fatalf("second open modified the history:\n%s\nwant:\n%s", after, before)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen after close: %v", err)
+	}
+	defer reopened.Close()
+	if reopened.Len() != 1 {
+		t.Fatalf("expected 1 session after reopen, got %d", reopened.Len())
+	}
+}
+
+func TestFileStoreAppendAfterTruncatedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(path, []byte("{\"schema_version\":2}\n{\"type\":\"wo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	record := SessionRecord{UserID: "u", CompletedSession: CompletedSession{
+		Type: events.KindWork, StartTime: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), Duration: 25 * time.Minute}}
+	if err := store.Append(record); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	store.Close()
+
+	check, err := VerifyHistoryFile(path)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !check.Healthy() || check.Sessions != 1 {
+		t.Fatalf("expected a healthy history with 1 session, got %+v", check)
+	}
+}
@@ -0,0 +1,93 @@
+package stats
+
+import "time"
+
+// pausePenalty son los puntos de enfoque que resta cada interrupción
+const pausePenalty = 5.0
+
+// FocusScore calcula la calidad de enfoque de una sesión (0 a 100): proporción de tiempo
+// activo sobre el tiempo real, menos una penalización por pausa
+func (c CompletedSession) FocusScore() float64 {
+	active := c.Active()
+	if c.ActualTime <= 0 || active <= 0 {
+		return 0
+	}
+
+	score := float64(active) / float64(c.ActualTime) * 100
+	score -= pausePenalty * float64(c.PauseCount)
+
+	return clampScore(score)
+}
+
+// Active retorna el tiempo activo de la sesión. Las sesiones registradas antes de medir
+// pausas se consideran activas durante todo su tiempo real.
+func (c CompletedSession) Active() time.Duration {
+	if c.ActiveTime == 0 && c.PausedTime == 0 {
+		return c.ActualTime
+	}
+	return c.ActiveTime
+}
+
+// focusSummary agrega pausas y enfoque de las sesiones de trabajo
+type focusSummary struct {
+	Sessions   int
+	Pauses     int
+	PausedTime time.Duration
+	ActiveTime time.Duration
+	Score      float64 // Media de FocusScore ponderada por duración configurada
+}
+
+// summarizeFocus calcula el resumen de enfoque de una lista de sesiones
+func summarizeFocus(sessions []CompletedSession) focusSummary {
+	var summary focusSummary
+	var weighted, weights float64
+
+	for _, session := range sessions {
+		if !session.Type.IsWork() {
+			continue
+		}
+
+		summary.Sessions++
+		summary.Pauses += session.PauseCount
+		summary.PausedTime += session.PausedTime
+		summary.ActiveTime += session.Active()
+
+		weight := float64(session.Duration)
+		if weight <= 0 {
+			weight = float64(session.ActualTime)
+		}
+		weighted += session.FocusScore() * weight
+		weights += weight
+	}
+
+	if weights > 0 {
+		summary.Score = weighted / weights
+	}
+	return summary
+}
+
+// workEfficiency combina la tasa de pomodoros completados con la calidad de enfoque.
+// Sin sesiones de trabajo detalladas solo cuenta la tasa de completados.
+func workEfficiency(completed, skipped int, focus focusSummary) float64 {
+	total := completed + skipped
+	if total == 0 {
+		return 0
+	}
+	completion := float64(completed) / float64(total) * 100
+	if focus.Sessions == 0 {
+		return completion
+	}
+	return clampScore(completion * focus.Score / 100)
+}
+
+// clampScore limita una puntuación al rango [0, 100]
+func clampScore(score float64) float64 {
+	switch {
+	case score < 0:
+		return 0
+	case score > 100:
+		return 100
+	default:
+		return score
+	}
+}
@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"time"
+)
+
+// HeatmapCell contiene los pomodoros iniciados en un día de la semana y hora concretos
+type HeatmapCell struct {
+	Completed int
+	Skipped   int
+	FocusTime time.Duration
+}
+
+// Heatmap es una matriz día de la semana × hora con la actividad de trabajo.
+// Las filas se indexan por time.Weekday (domingo = 0) y las columnas por hora local.
+type Heatmap struct {
+	Location     *time.Location
+	Cells        [7][24]HeatmapCell
+	MaxCompleted int
+	Completed    int
+	Skipped      int
+}
+
+// BuildHeatmap agrupa los pomodoros por día de la semana y hora de inicio en la zona indicada
+func BuildHeatmap(sessions []CompletedSession, loc *time.Location) *Heatmap {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	heatmap := &Heatmap{Location: loc}
+	for _, session := range sessions {
+		if !session.Type.IsWork() {
+			continue
+		}
+
+		start := session.StartTime.In(loc)
+		cell := &heatmap.Cells[start.Weekday()][start.Hour()]
+		cell.FocusTime += session.ActualTime
+
+		if session.Completed {
+			cell.Completed++
+			heatmap.Completed++
+			if cell.Completed > heatmap.MaxCompleted {
+				heatmap.MaxCompleted = cell.Completed
+			}
+		} else {
+			cell.Skipped++
+			heatmap.Skipped++
+		}
+	}
+
+	return heatmap
+}
+
+// GenerateHeatmap construye el heatmap del historial en el rango [from, to)
+func GenerateHeatmap(store Store, userID string, from, to time.Time, loc *time.Location) (*Heatmap, error) {
+	result, err := NewQuery().ForUser(userID).Between(from, to).WorkOnly().Run(store)
+	if err != nil {
+		return nil, err
+	}
+	return BuildHeatmap(result.CompletedSessions(), loc), nil
+}
+
+// Intensity retorna la actividad de una celda relativa a la más productiva (0 a 1)
+func (h *Heatmap) Intensity(day time.Weekday, hour int) float64 {
+	if h.MaxCompleted == 0 {
+		return 0
+	}
+	return float64(h.Cells[day][hour].Completed) / float64(h.MaxCompleted)
+}
+
+// HourRange retorna la primera y última hora con actividad (ok es false si no hay ninguna)
+func (h *Heatmap) HourRange() (first, last int, ok bool) {
+	first, last = 24, -1
+	for day := range h.Cells {
+		for hour, cell := range h.Cells[day] {
+			if cell.Completed+cell.Skipped == 0 {
+				continue
+			}
+			if hour < first {
+				first = hour
+			}
+			if hour > last {
+				last = hour
+			}
+		}
+	}
+	return first, last, last >= 0
+}
+
+// Peak retorna el día y la hora con más pomodoros completados
+func (h *Heatmap) Peak() (day time.Weekday, hour int, ok bool) {
+	best := 0
+	for d := range h.Cells {
+		for hr, cell := range h.Cells[d] {
+			if cell.Completed > best {
+				best = cell.Completed
+				day, hour = time.Weekday(d), hr
+			}
+		}
+	}
+	return day, hour, best > 0
+}
@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrHistoryInUse indica que otro proceso tiene abierto el historial
+var ErrHistoryInUse = errors.New("history in use by another process")
+
+// errLocked lo retorna tryLock cuando otro proceso tiene el bloqueo
+var errLocked = errors.New("file is locked")
+
+// lockHistory bloquea el historial de la ruta indicada para este proceso. El bloqueo se
+// toma sobre un archivo .lock junto al historial, porque las reescrituras atómicas
+// sustituyen el archivo del historial (y un bloqueo sobre él se perdería).
+// El sistema lo libera al cerrar el archivo retornado o si el proceso termina.
+func lockHistory(path string) (*os.File, error) {
+	file, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history lock: %w", err)
+	}
+
+	if err := tryLock(file); err != nil {
+		file.Close()
+		if errors.Is(err, errLocked) {
+			return nil, fmt.Errorf("%w: %s", ErrHistoryInUse, path)
+		}
+		return nil, fmt.Errorf("failed to lock history file: %w", err)
+	}
+	return file, nil
+}
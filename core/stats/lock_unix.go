@@ -0,0 +1,18 @@
+//go:build !windows
+
+package stats
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLock toma sin esperar un bloqueo exclusivo sobre el archivo
+func tryLock(file *os.File) error {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errLocked
+	}
+	return err
+}
@@ -0,0 +1,210 @@
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// HistoryCheck resume el estado de un archivo de historial
+type HistoryCheck struct {
+	Path            string
+	SchemaVersion   int // Versión de la cabecera (1 si no tiene, 0 si el archivo está vacío)
+	Lines           int
+	Sessions        int // Sesiones válidas y únicas
+	DailyAggregates int
+	CorruptLines    []int // Líneas que no se pudieron leer
+	InvalidLines    []int // Sesiones con datos imposibles
+	Duplicates      int
+	Unsorted        bool // Sesiones fuera de orden: solo informativo, al leerlas se ordenan
+	TruncatedTail   bool // Última línea sin salto final (escritura interrumpida)
+}
+
+// NeedsMigration indica si el archivo usa un esquema anterior al actual
+func (c *HistoryCheck) NeedsMigration() bool {
+	return c.SchemaVersion != 0 && c.SchemaVersion < SchemaVersion
+}
+
+// Healthy indica si el archivo no necesita reparación
+func (c *HistoryCheck) Healthy() bool {
+	return len(c.CorruptLines) == 0 &&
+		len(c.InvalidLines) == 0 &&
+		c.Duplicates == 0 &&
+		!c.TruncatedTail &&
+		!c.NeedsMigration()
+}
+
+// historyContents es el contenido recuperable de un archivo de historial
+type historyContents struct {
+	check   HistoryCheck
+	records []SessionRecord
+	daily   []DailyAggregate
+}
+
+// VerifyHistoryFile comprueba un archivo de historial sin modificarlo
+func VerifyHistoryFile(path string) (*HistoryCheck, error) {
+	contents, err := readHistoryFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &contents.check, nil
+}
+
+// RepairHistoryFile reescribe el historial con las sesiones válidas, sin duplicados,
+// ordenadas y con el esquema actual. El archivo original se guarda con extensión .bak.
+// Retorna la comprobación previa a la reparación. Toma el mismo bloqueo que FileStore,
+// por lo que retorna ErrHistoryInUse si otro proceso tiene abierto el historial.
+func RepairHistoryFile(path string) (*HistoryCheck, error) {
+	lock, err := lockHistory(path)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Close()
+
+	contents, err := readHistoryFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if contents.check.Healthy() {
+		return &contents.check, nil
+	}
+
+	if err := copyFile(path, path+".bak"); err != nil {
+		return nil, err
+	}
+	if err := writeHistoryFile(path, contents.records, contents.daily); err != nil {
+		return nil, err
+	}
+
+	return &contents.check, nil
+}
+
+// readHistoryFile lee y valida un archivo de historial línea a línea
+func readHistoryFile(path string) (*historyContents, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	contents := &historyContents{
+		check:   HistoryCheck{Path: path},
+		records: make([]SessionRecord, 0),
+		daily:   make([]DailyAggregate, 0),
+	}
+	check := &contents.check
+
+	seen := make(map[string]bool)
+	seenDays := make(map[string]bool)
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read history file: %w", err)
+		}
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) > 0 {
+				check.TruncatedTail = true
+			}
+			break
+		}
+
+		check.Lines++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		parsed, parseErr := parseHistoryLine(line)
+		if parseErr != nil {
+			check.CorruptLines = append(check.CorruptLines, check.Lines)
+			continue
+		}
+
+		switch {
+		case parsed.SchemaVersion != 0:
+			if err := checkSchemaVersion(parsed.SchemaVersion); err != nil {
+				return nil, err
+			}
+			check.SchemaVersion = parsed.SchemaVersion
+
+		case parsed.Daily != nil:
+			key := parsed.Daily.UserID + "|" + parsed.Daily.Day.UTC().String()
+			if seenDays[key] {
+				check.Duplicates++
+				continue
+			}
+			seenDays[key] = true
+			contents.daily = append(contents.daily, *parsed.Daily)
+
+		default:
+			record := parsed.SessionRecord
+			if validateSession(record.CompletedSession) != nil {
+				check.InvalidLines = append(check.InvalidLines, check.Lines)
+				continue
+			}
+
+			record.ID = SessionKey(record.CompletedSession)
+			key := record.UserID + "|" + record.ID
+			if seen[key] {
+				check.Duplicates++
+				continue
+			}
+			seen[key] = true
+
+			if n := len(contents.records); n > 0 && record.StartTime.Before(contents.records[n-1].StartTime) {
+				check.Unsorted = true
+			}
+			contents.records = insertSorted(contents.records, record)
+		}
+	}
+
+	// Un archivo con contenido pero sin cabecera es de la versión 1
+	if check.SchemaVersion == 0 && (len(contents.records) > 0 || len(contents.daily) > 0 || len(check.CorruptLines) > 0) {
+		check.SchemaVersion = 1
+	}
+
+	check.Sessions = len(contents.records)
+	check.DailyAggregates = len(contents.daily)
+	sortDaily(contents.daily)
+
+	return contents, nil
+}
+
+// validateSession detecta sesiones con datos imposibles
+func validateSession(session CompletedSession) error {
+	switch {
+	case !session.Type.IsWork() && !session.Type.IsBreak():
+		return fmt.Errorf("unknown session kind: %q", session.Type)
+	case session.StartTime.IsZero():
+		return fmt.Errorf("missing start time")
+	case session.Duration < 0 || session.ActualTime < 0 || session.PausedTime < 0 || session.ActiveTime < 0:
+		return fmt.Errorf("negative duration")
+	case !session.EndTime.IsZero() && session.EndTime.Before(session.StartTime):
+		return fmt.Errorf("end time before start time")
+	default:
+		return nil
+	}
+}
+
+// copyFile copia un archivo conservando sus permisos
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("failed to stat history file: %w", err)
+	}
+
+	if err := os.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write history backup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyUnsortedIsHealthy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	lines := `{"schema_version":2}
+{"type":"work","duration":1500000000000,"start_time":"2026-01-05T10:00:00Z","completed":true}
+{"type":"work","duration":1500000000000,"start_time":"2026-01-05T09:00:00Z","completed":true}
+`
+	if err := os.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := VerifyHistoryFile(path)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !check.Unsorted {
+		t.Fatalf("expected Unsorted to be reported")
+	}
+	if !check.Healthy() {
+		t.Fatalf("unsorted sessions must not make the history unhealthy: %+v", check)
+	}
+}
+
+func TestRepairRefusesWhileInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer store.Close()
+
+	// Un final incompleto obliga a reparar
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"type":"wo`)
+	file.Close()
+	before, _ := os.ReadFile(path)
+
+	if _, err := RepairHistoryFile(path); !errors.Is(err, ErrHistoryInUse) {
+		t.Fatalf("expected ErrHistoryInUse, got %v", err)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Fatalf("repair modified a history in use")
+	}
+	if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+		t.Fatalf("repair left a backup while refusing to run: %v", err)
+	}
+
+	store.Close()
+	check, err := RepairHistoryFile(path)
+	if err != nil {
+		t.Fatalf("repair after close: %v", err)
+	}
+	if !check.TruncatedTail {
+		t.Fatalf("expected the truncated tail to be reported, got %+v", check)
+	}
+}
@@ -0,0 +1,316 @@
+package stats
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// mergeTolerance es la diferencia máxima para considerar dos sesiones solapadas como la misma
+const mergeTolerance = time.Minute
+
+// MergeConflict describe una sesión importada que no se pudo fusionar
+type MergeConflict struct {
+	Existing CompletedSession
+	Imported CompletedSession
+	Reason   string
+}
+
+// MergeResult resume el resultado de una importación con fusión
+type MergeResult struct {
+	Added      int
+	Duplicates int
+	Conflicts  []MergeConflict
+}
+
+// NewSessionID genera un identificador aleatorio para una sesión nueva
+func NewSessionID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// SessionKey retorna el identificador estable de una sesión. Las sesiones antiguas sin ID
+// reciben uno derivado de su tipo e inicio, igual en todos los dispositivos.
+func SessionKey(session CompletedSession) string {
+	if session.ID != "" {
+		return session.ID
+	}
+	sum := sha1.Sum([]byte(string(session.Type) + "|" + session.StartTime.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParseSessionsJSON lee sesiones de una exportación de SessionStats (ExportJSON)
+// o de un array de registros del historial (ExportRecordsJSON)
+func ParseSessionsJSON(data []byte) ([]CompletedSession, error) {
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '[' {
+		var records []SessionRecord
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal records: %w", err)
+		}
+		return RecordSessions(records), nil
+	}
+
+	data, err := MigrateExportJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var exported struct {
+		CompletedSessions []CompletedSession `json:"completed_sessions"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stats: %w", err)
+	}
+	return exported.CompletedSessions, nil
+}
+
+// MergeSessions une dos listas de sesiones sin duplicados. Las sesiones se emparejan por ID
+// estable o, si no coinciden, por solapamiento en el tiempo. En caso de conflicto se
+// conserva la sesión existente. El resultado queda ordenado por inicio.
+func MergeSessions(existing, imported []CompletedSession) ([]CompletedSession, MergeResult) {
+	merged, _, result := mergeSessions(existing, imported)
+	return merged, result
+}
+
+// MergeJSON fusiona una exportación JSON con las estadísticas actuales y recalcula
+// contadores y rachas a partir del historial combinado
+func (s *SessionStats) MergeJSON(data []byte) (MergeResult, error) {
+	imported, err := ParseSessionsJSON(data)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged, _, result := mergeSessions(s.CompletedSessions, imported)
+	s.CompletedSessions = merged
+	s.recalculate()
+	s.invalidateDayStreak()
+
+	if len(merged) > 0 && merged[0].StartTime.Before(s.SessionStartTime) {
+		s.SessionStartTime = merged[0].StartTime
+	}
+
+	return result, nil
+}
+
+// MergeIntoStore añade al historial del usuario las sesiones importadas que aún no tiene
+func MergeIntoStore(store Store, userID string, imported []CompletedSession) (MergeResult, error) {
+	records, err := store.Range(userID, time.Time{}, time.Time{})
+	if err != nil {
+		return MergeResult{}, fmt.Errorf("failed to read session history: %w", err)
+	}
+
+	// Las sesiones de días ya compactados están resumidas en sus agregados
+	daily, err := storeDaily(store, userID, time.Time{}, time.Time{})
+	if err != nil {
+		return MergeResult{}, fmt.Errorf("failed to read daily aggregates: %w", err)
+	}
+	compacted := 0
+	if len(daily) > 0 {
+		pending := make([]CompletedSession, 0, len(imported))
+		for _, session := range imported {
+			if inCompactedDay(daily, session.StartTime) {
+				compacted++
+				continue
+			}
+			pending = append(pending, session)
+		}
+		imported = pending
+	}
+
+	_, added, result := mergeSessions(RecordSessions(records), imported)
+	result.Duplicates += compacted
+	for _, session := range added {
+		if err := store.Append(SessionRecord{UserID: userID, CompletedSession: session}); err != nil {
+			return result, fmt.Errorf("failed to append merged session: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// inCompactedDay indica si un instante cae en un día ya compactado
+func inCompactedDay(daily []DailyAggregate, t time.Time) bool {
+	for _, aggregate := range daily {
+		if aggregate.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeSessions implementa la fusión y retorna además las sesiones añadidas.
+// Primero descarta duplicados y conflictos y después une las dos listas ordenadas en una
+// sola pasada, de forma que importar un historial grande no desplaza el resultado en cada sesión.
+func mergeSessions(existing, imported []CompletedSession) ([]CompletedSession, []CompletedSession, MergeResult) {
+	var result MergeResult
+
+	current := make([]CompletedSession, len(existing))
+	for i, session := range existing {
+		session.ID = SessionKey(session)
+		current[i] = session
+	}
+	sortSessions(current)
+	byKey := make(map[string]int, len(current))
+	for i, session := range current {
+		byKey[session.ID] = i
+	}
+	index := newSessionIndex(current)
+
+	incoming := make([]CompletedSession, len(imported))
+	copy(incoming, imported)
+	sortSessions(incoming)
+
+	added := make([]CompletedSession, 0)
+	addedKeys := make(map[string]int)
+	latest := -1 // Sesión añadida que termina más tarde
+
+	for _, session := range incoming {
+		session.ID = SessionKey(session)
+
+		// Coincidencia con una sesión existente o con una importada que ya se añadió
+		var match *CompletedSession
+		reason := "mismo ID con datos distintos"
+		if i, ok := byKey[session.ID]; ok {
+			match = &current[i]
+		} else if i, ok := addedKeys[session.ID]; ok {
+			match = &added[i]
+		} else if i := index.overlap(session); i >= 0 {
+			match, reason = &current[i], "se solapa con una sesión existente"
+		} else if n := len(added); n > 0 && (sessionEnd(added[latest]).After(session.StartTime) ||
+			added[n-1].StartTime.Equal(session.StartTime)) {
+			// Las añadidas empiezan antes que session: solo puede solaparse la que termina más tarde
+			// o, si session dura cero, la que empieza a la vez
+			i := latest
+			if !sessionEnd(added[latest]).After(session.StartTime) {
+				i = n - 1
+			}
+			match, reason = &added[i], "se solapa con una sesión existente"
+		}
+
+		if match != nil {
+			if sameSession(*match, session) {
+				result.Duplicates++
+			} else {
+				result.Conflicts = append(result.Conflicts, MergeConflict{
+					Existing: *match,
+					Imported: session,
+					Reason:   reason,
+				})
+			}
+			continue
+		}
+
+		addedKeys[session.ID] = len(added)
+		added = append(added, session)
+		if latest < 0 || sessionEnd(session).After(sessionEnd(added[latest])) {
+			latest = len(added) - 1
+		}
+		result.Added++
+	}
+
+	return mergeSorted(current, added), added, result
+}
+
+// mergeSorted une dos listas ordenadas por inicio; a igual inicio van primero las de a
+func mergeSorted(a, b []CompletedSession) []CompletedSession {
+	merged := make([]CompletedSession, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if b[j].StartTime.Before(a[i].StartTime) {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	return append(merged, b[j:]...)
+}
+
+// sessionIndex busca solapamientos en sesiones ordenadas por inicio. Las sesiones pueden
+// solaparse entre sí (el historial recibe lo que registra cada interfaz), por lo que se
+// guarda el mayor fin hasta cada posición para saber cuándo dejar de buscar.
+type sessionIndex struct {
+	sorted []CompletedSession
+	maxEnd []time.Time // maxEnd[i] es el mayor fin de sorted[:i+1]
+}
+
+// newSessionIndex crea el índice de una lista ordenada por inicio
+func newSessionIndex(sorted []CompletedSession) sessionIndex {
+	maxEnd := make([]time.Time, len(sorted))
+	for i, session := range sorted {
+		maxEnd[i] = sessionEnd(session)
+		if i > 0 && maxEnd[i-1].After(maxEnd[i]) {
+			maxEnd[i] = maxEnd[i-1]
+		}
+	}
+	return sessionIndex{sorted: sorted, maxEnd: maxEnd}
+}
+
+// overlap retorna el índice de la sesión que se solapa con session y empieza más tarde, o -1
+func (x sessionIndex) overlap(session CompletedSession) int {
+	start, end := session.StartTime, sessionEnd(session)
+
+	// Primera sesión que empieza después de que termine la importada
+	idx := sort.Search(len(x.sorted), func(i int) bool {
+		return !x.sorted[i].StartTime.Before(end)
+	})
+
+	// Hacia atrás mientras alguna sesión anterior pueda terminar después del inicio
+	for i := idx - 1; i >= 0 && x.maxEnd[i].After(start); i-- {
+		if sessionEnd(x.sorted[i]).After(start) {
+			return i
+		}
+	}
+
+	// Una sesión de duración cero solo coincide con otra que empiece a la vez
+	if idx < len(x.sorted) && x.sorted[idx].StartTime.Equal(start) {
+		return idx
+	}
+	return -1
+}
+
+// sameSession indica si dos sesiones representan la misma sesión registrada dos veces
+func sameSession(a, b CompletedSession) bool {
+	return a.Type == b.Type &&
+		a.Completed == b.Completed &&
+		absDuration(a.StartTime.Sub(b.StartTime)) <= mergeTolerance &&
+		absDuration(a.ActualTime-b.ActualTime) <= mergeTolerance
+}
+
+// sessionEnd retorna el fin de una sesión, calculándolo si no se registró
+func sessionEnd(session CompletedSession) time.Time {
+	if session.EndTime.IsZero() {
+		return session.StartTime.Add(session.ActualTime)
+	}
+	return session.EndTime
+}
+
+// sortSessions ordena sesiones por inicio
+func sortSessions(sessions []CompletedSession) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+}
+
+// absDuration retorna el valor absoluto de una duración
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
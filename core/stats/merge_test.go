@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// workSession crea un pomodoro completado que empieza en start y dura d
+func workSession(id string, start time.Time, d time.Duration) CompletedSession {
+	return CompletedSession{ID: id, Type: events.KindWork, Completed: true,
+		StartTime: start, EndTime: start.Add(d), Duration: d, ActualTime: d}
+}
+
+func TestMergeSessions(t *testing.T) {
+	base := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	long := workSession("long", at(0), 2*time.Hour) // Sesión larga de otra interfaz
+	short := workSession("short", at(30), 25*time.Minute)
+
+	breakAt := func(id string, minutes int) CompletedSession {
+		s := workSession(id, at(minutes), 5*time.Minute)
+		s.Type = events.KindShortBreak
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		existing   []CompletedSession
+		imported   []CompletedSession
+		added      int
+		duplicates int
+		conflicts  []string
+	}{
+		{name: "new sessions", existing: []CompletedSession{short},
+			imported: []CompletedSession{workSession("b", at(-60), 25*time.Minute), workSession("a", at(180), 25*time.Minute)},
+			added:    2},
+		{name: "same ID", existing: []CompletedSession{short},
+			imported: []CompletedSession{short}, duplicates: 1},
+		{name: "same ID with other data", existing: []CompletedSession{short},
+			imported: []CompletedSession{breakAt("short", 30)}, conflicts: []string{"mismo ID con datos distintos"}},
+		{name: "same session with another ID", existing: []CompletedSession{short},
+			imported: []CompletedSession{workSession("other", at(30).Add(20*time.Second), 25*time.Minute)}, duplicates: 1},
+		// La sesión anterior más cercana (short) termina antes, pero long sigue en curso
+		{name: "overlap with a long earlier session", existing: []CompletedSession{long, short},
+			imported: []CompletedSession{breakAt("late", 90)}, conflicts: []string{"se solapa con una sesión existente"}},
+		{name: "imported sessions overlapping each other",
+			imported: []CompletedSession{workSession("x", at(0), time.Hour), breakAt("y", 40)},
+			added:    1, conflicts: []string{"se solapa con una sesión existente"}},
+		{name: "repeated imported ID",
+			imported: []CompletedSession{workSession("x", at(0), 25*time.Minute), workSession("x", at(0), 25*time.Minute)},
+			added:    1, duplicates: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, result := MergeSessions(tt.existing, tt.imported)
+			if result.Added != tt.added || result.Duplicates != tt.duplicates || len(result.Conflicts) != len(tt.conflicts) {
+				t.Fatalf("got added=%d duplicates=%d conflicts=%d, want %d/%d/%d",
+					result.Added, result.Duplicates, len(result.Conflicts), tt.added, tt.duplicates, len(tt.conflicts))
+			}
+			for i, reason := range tt.conflicts {
+				if result.Conflicts[i].Reason != reason {
+					t.Errorf("conflict %d: got %q, want %q", i, result.Conflicts[i].Reason, reason)
+				}
+			}
+			if len(merged) != len(tt.existing)+tt.added {
+				t.Fatalf("got %d merged sessions, want %d", len(merged), len(tt.existing)+tt.added)
+			}
+			if !sort.SliceIsSorted(merged, func(i, j int) bool { return merged[i].StartTime.Before(merged[j].StartTime) }) {
+				t.Fatalf("merged sessions are not sorted")
+			}
+		})
+	}
+}
+
+func TestMergeSessionsLargeHistory(t *testing.T) {
+	const n = 30000
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// El historial importado (un portátil) es anterior al actual: el peor caso de insertar uno a uno
+	existing := make([]CompletedSession, n)
+	imported := make([]CompletedSession, n)
+	for i := 0; i < n; i++ {
+		imported[i] = workSession(fmt.Sprintf("old-%d", i), base.Add(time.Duration(i)*time.Hour), 25*time.Minute)
+		existing[i] = workSession(fmt.Sprintf("new-%d", i), base.Add(time.Duration(n+i)*time.Hour), 25*time.Minute)
+	}
+	// Y el mismo historial otra vez, todo duplicado
+	imported = append(imported, existing...)
+
+	started := time.Now()
+	merged, result := MergeSessions(existing, imported)
+	if result.Added != n || result.Duplicates != n || len(merged) != 2*n {
+		t.Fatalf("got added=%d duplicates=%d merged=%d", result.Added, result.Duplicates, len(merged))
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("merging %d sessions took %s", 2*n, elapsed)
+	}
+	if merged[0].ID != "old-0" || merged[n].ID != "new-0" {
+		t.Fatalf("unexpected order: %s, %s", merged[0].ID, merged[n].ID)
+	}
+}
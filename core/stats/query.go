@@ -0,0 +1,276 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// SortField define el campo por el que se ordenan los resultados de una consulta
+type SortField string
+
+const (
+	SortByStart    SortField = "start"
+	SortByDuration SortField = "duration"
+	SortByFocus    SortField = "focus"
+)
+
+// ParseSortField convierte un texto a SortField
+func ParseSortField(value string) (SortField, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "start", "date", "fecha", "inicio":
+		return SortByStart, nil
+	case "duration", "duracion", "duración":
+		return SortByDuration, nil
+	case "focus", "enfoque":
+		return SortByFocus, nil
+	default:
+		return "", fmt.Errorf("unknown sort field: %s", value)
+	}
+}
+
+// Query es un constructor de consultas sobre el historial de sesiones.
+// Los métodos modifican la consulta y la retornan para encadenarlos.
+type Query struct {
+	userID      string
+	from, to    time.Time
+	types       map[events.SessionKind]bool
+	completed   *bool
+	minDuration time.Duration
+	task        string
+	project     string
+	tags        []string
+	sortBy      SortField
+	descending  bool
+	offset      int
+	limit       int
+}
+
+// QueryResult contiene la página de sesiones y los totales de todas las coincidencias
+type QueryResult struct {
+	Sessions []SessionRecord
+	Total    int             // Coincidencias antes de paginar
+	Totals   PeriodAggregate // Agregado de todas las coincidencias
+}
+
+// CompletedSessions extrae las sesiones de la página de resultados
+func (r *QueryResult) CompletedSessions() []CompletedSession {
+	return RecordSessions(r.Sessions)
+}
+
+// NewQuery crea una consulta sin filtros, ordenada por inicio ascendente
+func NewQuery() *Query {
+	return &Query{sortBy: SortByStart}
+}
+
+// ForUser limita la consulta a un usuario (vacío incluye a todos)
+func (q *Query) ForUser(userID string) *Query {
+	q.userID = userID
+	return q
+}
+
+// Between limita a las sesiones que empezaron en [from, to); un tiempo cero no acota
+func (q *Query) Between(from, to time.Time) *Query {
+	q.from, q.to = from, to
+	return q
+}
+
+// OfType limita a los tipos de sesión indicados
+func (q *Query) OfType(kinds ...events.SessionKind) *Query {
+	q.types = make(map[events.SessionKind]bool, len(kinds))
+	for _, kind := range kinds {
+		q.types[kind] = true
+	}
+	return q
+}
+
+// WorkOnly limita a los pomodoros
+func (q *Query) WorkOnly() *Query {
+	return q.OfType(events.KindWork)
+}
+
+// BreaksOnly limita a los descansos
+func (q *Query) BreaksOnly() *Query {
+	return q.OfType(events.KindShortBreak, events.KindLongBreak)
+}
+
+// Completed limita a las sesiones completadas
+func (q *Query) Completed() *Query {
+	value := true
+	q.completed = &value
+	return q
+}
+
+// Skipped limita a las sesiones saltadas
+func (q *Query) Skipped() *Query {
+	value := false
+	q.completed = &value
+	return q
+}
+
+// MinDuration descarta las sesiones con menos tiempo real que el indicado
+func (q *Query) MinDuration(d time.Duration) *Query {
+	q.minDuration = d
+	return q
+}
+
+// WithTask limita a una tarea (sin distinguir mayúsculas)
+func (q *Query) WithTask(task string) *Query {
+	q.task = task
+	return q
+}
+
+// WithProject limita a un proyecto (sin distinguir mayúsculas)
+func (q *Query) WithProject(project string) *Query {
+	q.project = project
+	return q
+}
+
+// WithTags limita a las sesiones que tengan todos los tags indicados
+func (q *Query) WithTags(tags ...string) *Query {
+	q.tags = ParseTags(strings.Join(tags, ","))
+	return q
+}
+
+// SortBy ordena los resultados por el campo indicado
+func (q *Query) SortBy(field SortField, descending bool) *Query {
+	q.sortBy = field
+	q.descending = descending
+	return q
+}
+
+// Page limita los resultados a limit sesiones saltando las primeras offset
+// (limit 0 = sin límite; un offset negativo se toma como 0)
+func (q *Query) Page(offset, limit int) *Query {
+	q.offset, q.limit = max(offset, 0), limit
+	return q
+}
+
+// Run ejecuta la consulta sobre un Store. El rango temporal se resuelve en el propio
+// almacenamiento (búsqueda binaria) y el resto de filtros en una sola pasada.
+func (q *Query) Run(store Store) (*QueryResult, error) {
+	records, err := store.Range(q.userID, q.from, q.to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session history: %w", err)
+	}
+	return q.apply(records, true), nil
+}
+
+// Apply ejecuta la consulta sobre una lista de registros en memoria
+func (q *Query) Apply(records []SessionRecord) *QueryResult {
+	return q.apply(records, false)
+}
+
+// Query ejecuta una consulta sobre las sesiones en memoria
+func (s *SessionStats) Query(q *Query) *QueryResult {
+	return q.Apply(s.sessionRecords())
+}
+
+// apply filtra, agrega, ordena y pagina; inRange indica si el rango ya está aplicado
+func (q *Query) apply(records []SessionRecord, inRange bool) *QueryResult {
+	matches := make([]SessionRecord, 0, len(records))
+	for _, record := range records {
+		if !inRange && !q.inRange(record) {
+			continue
+		}
+		if q.matches(record) {
+			matches = append(matches, record)
+		}
+	}
+
+	result := &QueryResult{
+		Total:  len(matches),
+		Totals: aggregate(RecordSessions(matches), q.from, q.to),
+	}
+
+	q.sort(matches)
+
+	start := min(max(q.offset, 0), len(matches))
+	end := len(matches)
+	if q.limit > 0 && q.limit < end-start {
+		end = start + q.limit
+	}
+	result.Sessions = matches[start:end]
+
+	return result
+}
+
+// inRange comprueba usuario y rango temporal
+func (q *Query) inRange(record SessionRecord) bool {
+	if q.userID != "" && record.UserID != q.userID {
+		return false
+	}
+	if !q.from.IsZero() && record.StartTime.Before(q.from) {
+		return false
+	}
+	if !q.to.IsZero() && !record.StartTime.Before(q.to) {
+		return false
+	}
+	return true
+}
+
+// matches comprueba el resto de filtros
+func (q *Query) matches(record SessionRecord) bool {
+	if len(q.types) > 0 && !q.types[record.Type] {
+		return false
+	}
+	if q.completed != nil && record.Completed != *q.completed {
+		return false
+	}
+	if q.minDuration > 0 && record.ActualTime < q.minDuration {
+		return false
+	}
+	if q.task != "" && !strings.EqualFold(record.Task, q.task) {
+		return false
+	}
+	if q.project != "" && !strings.EqualFold(record.Project, q.project) {
+		return false
+	}
+	for _, tag := range q.tags {
+		if !hasTag(record.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// sort ordena los resultados; el historial ya viene ordenado por inicio
+func (q *Query) sort(records []SessionRecord) {
+	var less func(a, b SessionRecord) bool
+	switch q.sortBy {
+	case SortByDuration:
+		less = func(a, b SessionRecord) bool { return a.ActualTime < b.ActualTime }
+	case SortByFocus:
+		less = func(a, b SessionRecord) bool { return a.FocusScore() < b.FocusScore() }
+	default:
+		less = func(a, b SessionRecord) bool { return a.StartTime.Before(b.StartTime) }
+		if sort.SliceIsSorted(records, func(i, j int) bool { return less(records[i], records[j]) }) {
+			if q.descending {
+				for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+					records[i], records[j] = records[j], records[i]
+				}
+			}
+			return
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if q.descending {
+			return less(records[j], records[i])
+		}
+		return less(records[i], records[j])
+	})
+}
+
+// hasTag indica si la lista contiene el tag (sin distinguir mayúsculas)
+func hasTag(tags []string, tag string) bool {
+	for _, candidate := range tags {
+		if strings.EqualFold(candidate, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+func TestQueryPage(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		store.Append(SessionRecord{UserID: "u", CompletedSession: CompletedSession{
+			Type: events.KindWork, StartTime: start.Add(time.Duration(i) * time.Hour), Duration: 25 * time.Minute}})
+	}
+
+	tests := []struct {
+		name          string
+		offset, limit int
+		want          int
+	}{
+		{"first page", 0, 2, 2},
+		{"last page", 4, 2, 1},
+		{"past the end", 10, 2, 0},
+		{"negative offset", -3, 2, 2},
+		{"no limit", 1, 0, 4},
+		{"huge limit", 1, math.MaxInt, 4},
+		{"huge offset", math.MaxInt, math.MaxInt, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewQuery().SortBy(SortByStart, false).Page(tt.offset, tt.limit).Run(store)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Sessions) != tt.want {
+				t.Fatalf("got %d sessions, want %d", len(result.Sessions), tt.want)
+			}
+			if result.Total != 5 {
+				t.Fatalf("total = %d, want 5", result.Total)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/kubaliski/pomodoro-core/events"
+)
+
+// Recorder escucha los eventos del engine y guarda cada sesión terminada en un Store
+type Recorder struct {
+	store    Store
+	userID   string
+	eventBus *events.EventBus
+}
+
+// NewRecorder crea un recorder que asocia las sesiones al usuario indicado
+func NewRecorder(store Store, userID string) *Recorder {
+	return &Recorder{
+		store:  store,
+		userID: userID,
+	}
+}
+
+// Subscribe registra el recorder en los eventos de fin de sesión del bus
+func (r *Recorder) Subscribe(eventBus *events.EventBus) {
+	r.eventBus = eventBus
+
+	eventBus.Subscribe(events.PomodoroCompleted, r)
+	eventBus.Subscribe(events.PomodoroSkipped, r)
+	eventBus.Subscribe(events.BreakCompleted, r)
+	eventBus.Subscribe(events.BreakSkipped, r)
+}
+
+// HandleEvent implementa events.EventHandler
+func (r *Recorder) HandleEvent(event events.Event) {
+	session, ok := sessionFromEvent(event)
+	if !ok {
+		return
+	}
+
+	record := SessionRecord{
+		UserID:           r.userID,
+		CompletedSession: session,
+	}
+
+	if err := r.store.Append(record); err != nil && r.eventBus != nil {
+		r.eventBus.Publish(events.ErrorOccurred, events.ErrorEventData{
+			Message: fmt.Sprintf("failed to record session: %v", err),
+			Code:    "HISTORY_WRITE_FAILED",
+			Source:  "stats.Recorder",
+		})
+	}
+}
+
+// sessionFromEvent convierte un evento de fin de sesión en una CompletedSession
+func sessionFromEvent(event events.Event) (CompletedSession, bool) {
+	completed := event.Type == events.PomodoroCompleted || event.Type == events.BreakCompleted
+
+	switch data := event.Data.(type) {
+	case events.PomodoroEventData:
+		return CompletedSession{
+			ID:         data.SessionID,
+			Type:       events.KindWork,
+			Duration:   data.Duration,
+			ActualTime: data.ActualTime,
+			StartTime:  data.StartTime,
+			EndTime:    data.EndTime,
+			Completed:  completed,
+			PauseCount: data.PauseCount,
+			PausedTime: data.PausedTime,
+			ActiveTime: data.ActiveTime,
+			Rule:       data.Rule,
+			Labels: Labels{
+				Task:     data.Task,
+				Project:  data.Project,
+				Tags:     data.Tags,
+				Estimate: data.Estimate,
+			},
+		}, true
+	case events.BreakEventData:
+		return CompletedSession{
+			ID:         data.SessionID,
+			Type:       data.Type,
+			Duration:   data.Duration,
+			ActualTime: data.ActualTime,
+			StartTime:  data.StartTime,
+			EndTime:    data.EndTime,
+			Completed:  completed,
+			PauseCount: data.PauseCount,
+			PausedTime: data.PausedTime,
+			ActiveTime: data.ActiveTime,
+			Rule:       data.Rule,
+		}, true
+	default:
+		return CompletedSession{}, false
+	}
+}
@@ -0,0 +1,300 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReportPeriod define la granularidad de un reporte
+type ReportPeriod string
+
+const (
+	PeriodDay   ReportPeriod = "day"
+	PeriodWeek  ReportPeriod = "week"
+	PeriodMonth ReportPeriod = "month"
+)
+
+// PeriodAggregate contiene los totales de un intervalo [Start, End)
+type PeriodAggregate struct {
+	Start              time.Time
+	End                time.Time
+	PomodorosCompleted int
+	PomodorosSkipped   int
+	BreaksCompleted    int
+	BreaksSkipped      int
+	FocusTime          time.Duration
+	BreakTime          time.Duration
+	Efficiency         float64
+	FocusScore         float64
+	Pauses             int
+	LongestStreak      int
+}
+
+// Report representa un reporte agregado del historial para un período
+type Report struct {
+	Period   ReportPeriod
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+	Total    PeriodAggregate
+	Days     []PeriodAggregate
+	BestDay  *PeriodAggregate
+	WorstDay *PeriodAggregate
+}
+
+// ParseReportPeriod convierte un texto (en inglés o español) a ReportPeriod
+func ParseReportPeriod(value string) (ReportPeriod, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "day", "daily", "dia", "día", "hoy", "today":
+		return PeriodDay, nil
+	case "", "week", "weekly", "semana":
+		return PeriodWeek, nil
+	case "month", "monthly", "mes":
+		return PeriodMonth, nil
+	default:
+		return "", fmt.Errorf("unknown report period: %s", value)
+	}
+}
+
+// PeriodBounds retorna el inicio y fin del período que contiene ref en la zona horaria indicada.
+// Las semanas empiezan en lunes.
+func PeriodBounds(period ReportPeriod, ref time.Time, loc *time.Location) (time.Time, time.Time) {
+	if loc == nil {
+		loc = time.Local
+	}
+	ref = ref.In(loc)
+	day := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, loc)
+
+	switch period {
+	case PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // lunes = 0
+		start := day.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		start := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+// AggregateSessions agrupa las sesiones en intervalos consecutivos de la granularidad indicada.
+// Solo se incluyen intervalos con actividad, ordenados cronológicamente.
+func AggregateSessions(sessions []CompletedSession, period ReportPeriod, loc *time.Location) []PeriodAggregate {
+	buckets := make(map[time.Time][]CompletedSession)
+	for _, session := range sessions {
+		start, _ := PeriodBounds(period, session.StartTime, loc)
+		buckets[start] = append(buckets[start], session)
+	}
+
+	result := make([]PeriodAggregate, 0, len(buckets))
+	for start, bucket := range buckets {
+		_, end := PeriodBounds(period, start, loc)
+		result = append(result, aggregate(bucket, start, end))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result
+}
+
+// GenerateReport construye el reporte del período que contiene ref a partir del historial
+func GenerateReport(store Store, userID string, period ReportPeriod, ref time.Time, loc *time.Location) (*Report, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	start, end := PeriodBounds(period, ref, loc)
+	result, err := NewQuery().ForUser(userID).Between(start, end).Run(store)
+	if err != nil {
+		return nil, err
+	}
+
+	// Los días compactados por la política de retención solo existen como agregados. Pueden
+	// venir de otra zona horaria: se amplía la búsqueda un día y addDaily filtra por fecha.
+	daily, err := storeDaily(store, userID, start.AddDate(0, 0, -1), end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read daily aggregates: %w", err)
+	}
+
+	report := BuildReport(result.CompletedSessions(), period, ref, loc)
+	report.addDaily(daily)
+	return report, nil
+}
+
+// BuildReport construye el reporte del período que contiene ref a partir de una lista de sesiones
+func BuildReport(sessions []CompletedSession, period ReportPeriod, ref time.Time, loc *time.Location) *Report {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	start, end := PeriodBounds(period, ref, loc)
+
+	inPeriod := make([]CompletedSession, 0, len(sessions))
+	for _, session := range sessions {
+		if !session.StartTime.Before(start) && session.StartTime.Before(end) {
+			inPeriod = append(inPeriod, session)
+		}
+	}
+
+	report := &Report{
+		Period:   period,
+		Start:    start,
+		End:      end,
+		Location: loc,
+		Total:    aggregate(inPeriod, start, end),
+		Days:     AggregateSessions(inPeriod, PeriodDay, loc),
+	}
+	report.rankDays()
+
+	return report
+}
+
+// rankDays busca el mejor y el peor día entre los días con pomodoros
+func (r *Report) rankDays() {
+	r.BestDay, r.WorstDay = nil, nil
+	for i := range r.Days {
+		day := &r.Days[i]
+		if day.PomodorosCompleted+day.PomodorosSkipped == 0 {
+			continue
+		}
+		if r.BestDay == nil || betterDay(*day, *r.BestDay) {
+			r.BestDay = day
+		}
+		if r.WorstDay == nil || betterDay(*r.WorstDay, *day) {
+			r.WorstDay = day
+		}
+	}
+}
+
+// addDaily suma al reporte los agregados de días compactados del período. Cada agregado se
+// asigna a su fecha del calendario (DayIn) aunque se compactara en otra zona horaria.
+func (r *Report) addDaily(daily []DailyAggregate) {
+	if len(daily) == 0 {
+		return
+	}
+
+	for _, aggregate := range daily {
+		day := aggregate.DayIn(r.Location)
+		if day.Before(r.Start) || !day.Before(r.End) {
+			continue
+		}
+		period := aggregate.Period()
+		period.Start, period.End = day, day.AddDate(0, 0, 1)
+		mergePeriod(&r.Total, period)
+
+		found := false
+		for i := range r.Days {
+			if r.Days[i].Start.Equal(period.Start) {
+				mergePeriod(&r.Days[i], period)
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.Days = append(r.Days, period)
+		}
+	}
+
+	sort.Slice(r.Days, func(i, j int) bool {
+		return r.Days[i].Start.Before(r.Days[j].Start)
+	})
+	r.rankDays()
+}
+
+// RecordSessions extrae las sesiones de una lista de registros del historial
+func RecordSessions(records []SessionRecord) []CompletedSession {
+	sessions := make([]CompletedSession, len(records))
+	for i, record := range records {
+		sessions[i] = record.CompletedSession
+	}
+	return sessions
+}
+
+// aggregate calcula los totales de un conjunto de sesiones
+func aggregate(sessions []CompletedSession, start, end time.Time) PeriodAggregate {
+	// El historial suele llegar ya ordenado; solo se copia y ordena si hace falta
+	sorted := sessions
+	if !sort.SliceIsSorted(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	}) {
+		sorted = make([]CompletedSession, len(sessions))
+		copy(sorted, sessions)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].StartTime.Before(sorted[j].StartTime)
+		})
+	}
+
+	agg := PeriodAggregate{Start: start, End: end}
+	streak := 0
+
+	for _, session := range sorted {
+		if session.Type.IsWork() {
+			agg.FocusTime += session.ActualTime
+			if session.Completed {
+				agg.PomodorosCompleted++
+				streak++
+				if streak > agg.LongestStreak {
+					agg.LongestStreak = streak
+				}
+			} else {
+				agg.PomodorosSkipped++
+				streak = 0
+			}
+			continue
+		}
+
+		agg.BreakTime += session.ActualTime
+		if session.Completed {
+			agg.BreaksCompleted++
+		} else {
+			agg.BreaksSkipped++
+		}
+	}
+
+	focus := summarizeFocus(sorted)
+	agg.FocusScore = focus.Score
+	agg.Pauses = focus.Pauses
+	agg.Efficiency = workEfficiency(agg.PomodorosCompleted, agg.PomodorosSkipped, focus)
+
+	return agg
+}
+
+// mergePeriod suma los totales de src en dst. La puntuación de enfoque se pondera
+// por pomodoros y la racha más larga se aproxima con el máximo de ambas.
+func mergePeriod(dst *PeriodAggregate, src PeriodAggregate) {
+	dstWork := dst.PomodorosCompleted + dst.PomodorosSkipped
+	srcWork := src.PomodorosCompleted + src.PomodorosSkipped
+	if total := dstWork + srcWork; total > 0 {
+		dst.FocusScore = (dst.FocusScore*float64(dstWork) + src.FocusScore*float64(srcWork)) / float64(total)
+	}
+
+	dst.PomodorosCompleted += src.PomodorosCompleted
+	dst.PomodorosSkipped += src.PomodorosSkipped
+	dst.BreaksCompleted += src.BreaksCompleted
+	dst.BreaksSkipped += src.BreaksSkipped
+	dst.FocusTime += src.FocusTime
+	dst.BreakTime += src.BreakTime
+	dst.Pauses += src.Pauses
+	if src.LongestStreak > dst.LongestStreak {
+		dst.LongestStreak = src.LongestStreak
+	}
+	dst.Efficiency = periodEfficiency(*dst)
+}
+
+// periodEfficiency recalcula la eficiencia de un agregado a partir de sus totales
+func periodEfficiency(period PeriodAggregate) float64 {
+	work := period.PomodorosCompleted + period.PomodorosSkipped
+	return workEfficiency(period.PomodorosCompleted, period.PomodorosSkipped, focusSummary{Sessions: work, Score: period.FocusScore})
+}
+
+// betterDay indica si el día a es más productivo que el día b
+func betterDay(a, b PeriodAggregate) bool {
+	if a.PomodorosCompleted != b.PomodorosCompleted {
+		return a.PomodorosCompleted > b.PomodorosCompleted
+	}
+	return a.FocusTime > b.FocusTime
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReportCompactedDayInOtherZone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Compactado en UTC+2: el pomodoro de las 00:30 del día 5 es del día 4 en UTC
+	madrid := time.FixedZone("UTC+2", 2*60*60)
+	start := time.Date(2026, 1, 5, 0, 30, 0, 0, madrid)
+	session := workSession("early", start, 25*time.Minute)
+	if err := store.Append(SessionRecord{UserID: "ana", CompletedSession: session}); err != nil {
+		t.Fatal(err)
+	}
+	policy := RetentionPolicy{RawDays: 1, Location: madrid}
+	if result, err := store.Compact(policy, start.AddDate(0, 0, 10)); err != nil || result.Compacted != 1 {
+		t.Fatalf("compact: %+v, %v", result, err)
+	}
+
+	// Al reabrir, el día llega con el desfase guardado en el archivo
+	store.Close()
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+
+	tests := []struct {
+		name      string
+		period    ReportPeriod
+		ref       time.Time
+		loc       *time.Location
+		completed int
+	}{
+		{name: "same date in UTC", period: PeriodDay, ref: time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC), loc: time.UTC, completed: 1},
+		{name: "previous date in UTC", period: PeriodDay, ref: time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC), loc: time.UTC},
+		{name: "same zone", period: PeriodDay, ref: time.Date(2026, 1, 5, 12, 0, 0, 0, madrid), loc: madrid, completed: 1},
+		{name: "week in UTC", period: PeriodWeek, ref: time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC), loc: time.UTC, completed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := GenerateReport(store, "ana", tt.period, tt.ref, tt.loc)
+			if err != nil {
+				t.Fatalf("report: %v", err)
+			}
+			if got := report.Total.PomodorosCompleted; got != tt.completed {
+				t.Fatalf("got %d pomodoros, want %d", got, tt.completed)
+			}
+			if tt.completed == 0 {
+				return
+			}
+			want := time.Date(2026, 1, 5, 0, 0, 0, 0, tt.loc)
+			if len(report.Days) != 1 || !report.Days[0].Start.Equal(want) {
+				t.Fatalf("expected the aggregate on %s, got %+v", want, report.Days)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// RetentionPolicy define cuánto tiempo se conservan las sesiones individuales del historial.
+// Las sesiones más antiguas se compactan en agregados diarios.
+type RetentionPolicy struct {
+	RawDays  int            // Días completos con sesiones individuales (0 = conservar todo)
+	Location *time.Location // Zona horaria de los días (nil = local)
+}
+
+// Enabled indica si la política compacta algo
+func (p RetentionPolicy) Enabled() bool {
+	return p.RawDays > 0
+}
+
+// Cutoff retorna el inicio del primer día que se conserva sin compactar
+func (p RetentionPolicy) Cutoff(now time.Time) time.Time {
+	today, _ := PeriodBounds(PeriodDay, now, p.location())
+	return today.AddDate(0, 0, -p.RawDays)
+}
+
+// location retorna la zona horaria de la política
+func (p RetentionPolicy) location() *time.Location {
+	if p.Location == nil {
+		return time.Local
+	}
+	return p.Location
+}
+
+// DailyAggregate resume las sesiones de un usuario en un día ya compactado
+type DailyAggregate struct {
+	UserID             string        `json:"user_id"`
+	Day                time.Time     `json:"day"` // Inicio del día
+	PomodorosCompleted int           `json:"pomodoros_completed"`
+	PomodorosSkipped   int           `json:"pomodoros_skipped"`
+	BreaksCompleted    int           `json:"breaks_completed"`
+	BreaksSkipped      int           `json:"breaks_skipped"`
+	FocusTime          time.Duration `json:"focus_time"`
+	BreakTime          time.Duration `json:"break_time"`
+	Pauses             int           `json:"pauses"`
+	FocusScore         float64       `json:"focus_score"`
+	LongestStreak      int           `json:"longest_streak"`
+}
+
+// Period convierte el agregado diario en un PeriodAggregate
+func (d DailyAggregate) Period() PeriodAggregate {
+	period := PeriodAggregate{
+		Start:              d.Day,
+		End:                d.Day.AddDate(0, 0, 1),
+		PomodorosCompleted: d.PomodorosCompleted,
+		PomodorosSkipped:   d.PomodorosSkipped,
+		BreaksCompleted:    d.BreaksCompleted,
+		BreaksSkipped:      d.BreaksSkipped,
+		FocusTime:          d.FocusTime,
+		BreakTime:          d.BreakTime,
+		Pauses:             d.Pauses,
+		FocusScore:         d.FocusScore,
+		LongestStreak:      d.LongestStreak,
+	}
+	period.Efficiency = periodEfficiency(period)
+	return period
+}
+
+// DayIn retorna el inicio del mismo día del calendario en otra zona horaria. Day conserva el
+// desfase de la zona en la que se compactó, así que su fecha es la del día agregado: como las
+// sesiones ya no existen por separado, el día entero se asigna a esa fecha en cualquier zona.
+func (d DailyAggregate) DayIn(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Date(d.Day.Year(), d.Day.Month(), d.Day.Day(), 0, 0, 0, 0, loc)
+}
+
+// Contains indica si un instante cae dentro del día agregado
+func (d DailyAggregate) Contains(t time.Time) bool {
+	return !t.Before(d.Day) && t.Before(d.Day.AddDate(0, 0, 1))
+}
+
+// dailyFromPeriod construye un agregado diario a partir de los totales de un día
+func dailyFromPeriod(userID string, period PeriodAggregate) DailyAggregate {
+	return DailyAggregate{
+		UserID:             userID,
+		Day:                period.Start,
+		PomodorosCompleted: period.PomodorosCompleted,
+		PomodorosSkipped:   period.PomodorosSkipped,
+		BreaksCompleted:    period.BreaksCompleted,
+		BreaksSkipped:      period.BreaksSkipped,
+		FocusTime:          period.FocusTime,
+		BreakTime:          period.BreakTime,
+		Pauses:             period.Pauses,
+		FocusScore:         period.FocusScore,
+		LongestStreak:      period.LongestStreak,
+	}
+}
+
+// DailyStore es un Store que además guarda agregados diarios de sesiones compactadas
+type DailyStore interface {
+	Store
+	// Daily retorna los agregados de un usuario cuyo día empieza en [from, to)
+	Daily(userID string, from, to time.Time) ([]DailyAggregate, error)
+}
+
+// CompactResult resume el resultado de una compactación
+type CompactResult struct {
+	Compacted int       // Sesiones individuales convertidas en agregados
+	Days      int       // Días (por usuario) creados o actualizados
+	Cutoff    time.Time // Las sesiones anteriores a este instante se compactaron
+}
+
+// CompactRecords aplica la política de retención: las sesiones que empezaron antes del corte
+// se suman a los agregados diarios (existentes o nuevos) y el resto se conserva tal cual
+func CompactRecords(records []SessionRecord, daily []DailyAggregate, policy RetentionPolicy, now time.Time) ([]SessionRecord, []DailyAggregate, CompactResult) {
+	result := CompactResult{Cutoff: policy.Cutoff(now)}
+	if !policy.Enabled() {
+		return records, daily, result
+	}
+
+	type dayKey struct {
+		userID string
+		day    time.Time
+	}
+
+	loc := policy.location()
+	old := make(map[dayKey][]CompletedSession)
+	kept := make([]SessionRecord, 0, len(records))
+	for _, record := range records {
+		if !record.StartTime.Before(result.Cutoff) {
+			kept = append(kept, record)
+			continue
+		}
+		day, _ := PeriodBounds(PeriodDay, record.StartTime, loc)
+		key := dayKey{record.UserID, day}
+		old[key] = append(old[key], record.CompletedSession)
+		result.Compacted++
+	}
+
+	if result.Compacted == 0 {
+		return records, daily, result
+	}
+
+	merged := make([]DailyAggregate, len(daily))
+	copy(merged, daily)
+	index := make(map[dayKey]int, len(merged))
+	for i, aggregate := range merged {
+		index[dayKey{aggregate.UserID, aggregate.Day.UTC()}] = i
+	}
+
+	for key, sessions := range old {
+		total := aggregate(sessions, key.day, key.day.AddDate(0, 0, 1))
+		if i, ok := index[dayKey{key.userID, key.day.UTC()}]; ok {
+			existing := merged[i].Period()
+			mergePeriod(&existing, total)
+			merged[i] = dailyFromPeriod(key.userID, existing)
+		} else {
+			merged = append(merged, dailyFromPeriod(key.userID, total))
+		}
+		result.Days++
+	}
+
+	sortDaily(merged)
+	return kept, merged, result
+}
+
+// filterDaily retorna los agregados de un usuario cuyo día empieza en [from, to)
+func filterDaily(daily []DailyAggregate, userID string, from, to time.Time) []DailyAggregate {
+	result := make([]DailyAggregate, 0)
+	for _, aggregate := range daily {
+		if userID != "" && aggregate.UserID != userID {
+			continue
+		}
+		if !from.IsZero() && aggregate.Day.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !aggregate.Day.Before(to) {
+			continue
+		}
+		result = append(result, aggregate)
+	}
+	return result
+}
+
+// storeDaily obtiene los agregados diarios si el almacenamiento los soporta
+func storeDaily(store Store, userID string, from, to time.Time) ([]DailyAggregate, error) {
+	dailyStore, ok := store.(DailyStore)
+	if !ok {
+		return nil, nil
+	}
+	return dailyStore.Daily(userID, from, to)
+}
+
+// sortDaily ordena los agregados por día y usuario
+func sortDaily(daily []DailyAggregate) {
+	sort.Slice(daily, func(i, j int) bool {
+		if !daily[i].Day.Equal(daily[j].Day) {
+			return daily[i].Day.Before(daily[j].Day)
+		}
+		return daily[i].UserID < daily[j].UserID
+	})
+}
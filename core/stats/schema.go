@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion es la versión actual del formato en disco de estadísticas e historial.
+//
+//   - 1: sin campo de versión, tipos de sesión en español y sesiones sin ID
+//   - 2: tipos de sesión canónicos (events.SessionKind), IDs estables y agregados diarios
+const SchemaVersion = 2
+
+// exportMigrations actualiza una exportación JSON de la versión indicada a la siguiente
+var exportMigrations = map[int]func(doc map[string]json.RawMessage) error{
+	1: migrateExportV1,
+}
+
+// MigrateExportJSON actualiza una exportación de SessionStats (ExportJSON) a la versión
+// actual del esquema. Las exportaciones sin versión se consideran de la versión 1.
+func MigrateExportJSON(data []byte) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stats: %w", err)
+	}
+
+	version, err := documentVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+	if version == SchemaVersion {
+		return data, nil
+	}
+
+	for ; version < SchemaVersion; version++ {
+		if err := exportMigrations[version](doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate stats from schema version %d: %w", version, err)
+		}
+	}
+
+	doc["schema_version"], _ = json.Marshal(SchemaVersion)
+	return json.Marshal(doc)
+}
+
+// documentVersion lee la versión del esquema de un documento JSON
+func documentVersion(doc map[string]json.RawMessage) (int, error) {
+	raw, ok := doc["schema_version"]
+	if !ok {
+		return 1, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("failed to parse schema version: %w", err)
+	}
+	if err := checkSchemaVersion(version); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// checkSchemaVersion rechaza versiones desconocidas o más nuevas que la soportada
+func checkSchemaVersion(version int) error {
+	if version < 1 || version > SchemaVersion {
+		return fmt.Errorf("unsupported schema version %d (supported up to %d)", version, SchemaVersion)
+	}
+	return nil
+}
+
+// migrateExportV1 normaliza los tipos de sesión y asigna IDs estables
+func migrateExportV1(doc map[string]json.RawMessage) error {
+	raw, ok := doc["completed_sessions"]
+	if !ok {
+		return nil
+	}
+
+	// CompletedSession ya lee los tipos antiguos; al volver a serializar quedan canónicos
+	var sessions []CompletedSession
+	if err := json.Unmarshal(raw, &sessions); err != nil {
+		return fmt.Errorf("failed to unmarshal sessions: %w", err)
+	}
+	upgradeSessions(sessions)
+
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sessions: %w", err)
+	}
+	doc["completed_sessions"] = data
+	return nil
+}
+
+// upgradeSessions asigna a las sesiones antiguas el ID derivado de su tipo e inicio
+func upgradeSessions(sessions []CompletedSession) {
+	for i := range sessions {
+		sessions[i].ID = SessionKey(sessions[i])
+	}
+}
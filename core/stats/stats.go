@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/kubaliski/pomodoro-core/events"
 )
 
 // SessionStats maneja las estadísticas de la sesión de forma thread-safe
@@ -27,16 +29,34 @@ type SessionStats struct {
 
 	// Historial de sesiones
 	CompletedSessions []CompletedSession `json:"completed_sessions"`
+
+	// Racha diaria (calculada desde el historial persistente si está configurado)
+	historyStore    Store
+	historyUserID   string
+	dayStreakConfig DayStreakConfig
+
+	// Última racha diaria calculada y el día al que corresponde. Se recalcula al
+	// terminar una sesión, al cambiar de día o tras InvalidateDayStreak.
+	streakMu    sync.Mutex
+	streak      DayStreak
+	streakDay   time.Time
+	streakValid bool
 }
 
 // CompletedSession representa una sesión individual completada
 type CompletedSession struct {
-	Type       string        `json:"type"`        // "TRABAJO", "DESCANSO", "DESCANSO LARGO"
-	Duration   time.Duration `json:"duration"`    // Duración configurada
-	ActualTime time.Duration `json:"actual_time"` // Tiempo real transcurrido
-	StartTime  time.Time     `json:"start_time"`  // Cuando empezó
-	EndTime    time.Time     `json:"end_time"`    // Cuando terminó
-	Completed  bool          `json:"completed"`   // true si se completó, false si se saltó
+	ID         string             `json:"id,omitempty"`          // Identificador estable de la sesión
+	Type       events.SessionKind `json:"type"`                  // Lee también "TRABAJO", "DESCANSO" y "DESCANSO LARGO"
+	Duration   time.Duration      `json:"duration"`              // Duración configurada
+	ActualTime time.Duration      `json:"actual_time"`           // Tiempo real transcurrido
+	StartTime  time.Time          `json:"start_time"`            // Cuando empezó
+	EndTime    time.Time          `json:"end_time"`              // Cuando terminó
+	Completed  bool               `json:"completed"`             // true si se completó, false si se saltó
+	PauseCount int                `json:"pause_count,omitempty"` // Número de pausas
+	PausedTime time.Duration      `json:"paused_time,omitempty"` // Tiempo total en pausa
+	ActiveTime time.Duration      `json:"active_time,omitempty"` // Tiempo real sin pausas
+	Rule       string             `json:"rule,omitempty"`        // Regla de horario aplicada
+	Labels                        // Tarea, proyecto y tags (solo pomodoros)
 }
 
 // StatsSnapshot representa una instantánea inmutable de las estadísticas
@@ -51,8 +71,14 @@ type StatsSnapshot struct {
 	TotalWorkTime       time.Duration
 	TotalBreakTime      time.Duration
 	SessionDuration     time.Duration
-	WorkEfficiency      float64
+	WorkEfficiency      float64 // Tasa de completados ponderada por la calidad de enfoque
+	CompletionRate      float64 // Pomodoros completados / (completados + saltados)
+	FocusScore          float64 // Media de enfoque de las sesiones de trabajo (0 a 100)
+	TotalPauses         int
+	TotalPausedTime     time.Duration
+	TotalActiveTime     time.Duration
 	TotalSessions       int
+	DayStreak           DayStreak
 }
 
 // NewSessionStats crea una nueva instancia de estadísticas
@@ -60,98 +86,123 @@ func NewSessionStats() *SessionStats {
 	return &SessionStats{
 		SessionStartTime:  time.Now(),
 		CompletedSessions: make([]CompletedSession, 0),
+		dayStreakConfig:   DefaultDayStreakConfig(),
 	}
 }
 
-// AddCompletedPomodoro registra un pomodoro completado
-func (s *SessionStats) AddCompletedPomodoro(duration, actualTime time.Duration, startTime, endTime time.Time) {
+// AddSession registra una sesión terminada actualizando contadores, tiempos y rachas
+func (s *SessionStats) AddSession(session CompletedSession) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.PomodorosCompleted++
-	s.TotalWorkTime += actualTime
-	s.CurrentStreakCount++
-
-	// Actualizar mejor racha
-	if s.CurrentStreakCount > s.BestStreakCount {
-		s.BestStreakCount = s.CurrentStreakCount
+	if session.ID == "" {
+		session.ID = NewSessionID()
 	}
 
-	// Agregar al historial
-	session := CompletedSession{
-		Type:       "TRABAJO",
+	s.applySession(session)
+	s.CompletedSessions = append(s.CompletedSessions, session)
+	s.invalidateDayStreak()
+}
+
+// AddCompletedPomodoro registra un pomodoro completado
+func (s *SessionStats) AddCompletedPomodoro(duration, actualTime time.Duration, startTime, endTime time.Time) {
+	s.AddSession(CompletedSession{
+		Type:       events.KindWork,
 		Duration:   duration,
 		ActualTime: actualTime,
 		StartTime:  startTime,
 		EndTime:    endTime,
 		Completed:  true,
-	}
-	s.CompletedSessions = append(s.CompletedSessions, session)
+	})
 }
 
 // AddSkippedPomodoro registra un pomodoro saltado
 func (s *SessionStats) AddSkippedPomodoro(duration, actualTime time.Duration, startTime, endTime time.Time) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.PomodorosSkipped++
-	s.TotalWorkTime += actualTime
-	s.CurrentStreakCount = 0 // Rompe la racha
-
-	// Agregar al historial
-	session := CompletedSession{
-		Type:       "TRABAJO",
+	s.AddSession(CompletedSession{
+		Type:       events.KindWork,
 		Duration:   duration,
 		ActualTime: actualTime,
 		StartTime:  startTime,
 		EndTime:    endTime,
 		Completed:  false,
-	}
-	s.CompletedSessions = append(s.CompletedSessions, session)
+	})
 }
 
 // AddCompletedBreak registra un descanso completado
-func (s *SessionStats) AddCompletedBreak(breakType string, duration, actualTime time.Duration, startTime, endTime time.Time) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.BreaksCompleted++
-	s.TotalBreakTime += actualTime
-
-	if breakType == "DESCANSO LARGO" {
-		s.LongBreaksCompleted++
-	}
-
-	// Agregar al historial
-	session := CompletedSession{
+func (s *SessionStats) AddCompletedBreak(breakType events.SessionKind, duration, actualTime time.Duration, startTime, endTime time.Time) {
+	s.AddSession(CompletedSession{
 		Type:       breakType,
 		Duration:   duration,
 		ActualTime: actualTime,
 		StartTime:  startTime,
 		EndTime:    endTime,
 		Completed:  true,
-	}
-	s.CompletedSessions = append(s.CompletedSessions, session)
+	})
 }
 
 // AddSkippedBreak registra un descanso saltado
-func (s *SessionStats) AddSkippedBreak(breakType string, duration, actualTime time.Duration, startTime, endTime time.Time) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.BreaksSkipped++
-	s.TotalBreakTime += actualTime
-
-	// Agregar al historial
-	session := CompletedSession{
+func (s *SessionStats) AddSkippedBreak(breakType events.SessionKind, duration, actualTime time.Duration, startTime, endTime time.Time) {
+	s.AddSession(CompletedSession{
 		Type:       breakType,
 		Duration:   duration,
 		ActualTime: actualTime,
 		StartTime:  startTime,
 		EndTime:    endTime,
 		Completed:  false,
+	})
+}
+
+// applySession actualiza contadores, tiempos y rachas con una sesión (debe llamarse con lock)
+func (s *SessionStats) applySession(session CompletedSession) {
+	if session.Type.IsWork() {
+		s.TotalWorkTime += session.ActualTime
+
+		if !session.Completed {
+			s.PomodorosSkipped++
+			s.CurrentStreakCount = 0 // Rompe la racha
+			return
+		}
+
+		s.PomodorosCompleted++
+		s.CurrentStreakCount++
+
+		// Actualizar mejor racha
+		if s.CurrentStreakCount > s.BestStreakCount {
+			s.BestStreakCount = s.CurrentStreakCount
+		}
+		return
+	}
+
+	s.TotalBreakTime += session.ActualTime
+
+	if !session.Completed {
+		s.BreaksSkipped++
+		return
+	}
+
+	s.BreaksCompleted++
+	if session.Type == events.KindLongBreak {
+		s.LongBreaksCompleted++
+	}
+}
+
+// recalculate reconstruye contadores y rachas a partir del historial (debe llamarse con lock)
+func (s *SessionStats) recalculate() {
+	sortSessions(s.CompletedSessions)
+
+	s.PomodorosCompleted = 0
+	s.PomodorosSkipped = 0
+	s.BreaksCompleted = 0
+	s.BreaksSkipped = 0
+	s.LongBreaksCompleted = 0
+	s.TotalWorkTime = 0
+	s.TotalBreakTime = 0
+	s.CurrentStreakCount = 0
+	s.BestStreakCount = 0
+
+	for _, session := range s.CompletedSessions {
+		s.applySession(session)
 	}
-	s.CompletedSessions = append(s.CompletedSessions, session)
 }
 
 // GetSnapshot retorna una instantánea inmutable de las estadísticas actuales
@@ -159,6 +210,8 @@ func (s *SessionStats) GetSnapshot() StatsSnapshot {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	focus := summarizeFocus(s.CompletedSessions)
+
 	return StatsSnapshot{
 		PomodorosCompleted:  s.PomodorosCompleted,
 		PomodorosSkipped:    s.PomodorosSkipped,
@@ -170,8 +223,14 @@ func (s *SessionStats) GetSnapshot() StatsSnapshot {
 		TotalWorkTime:       s.TotalWorkTime,
 		TotalBreakTime:      s.TotalBreakTime,
 		SessionDuration:     time.Since(s.SessionStartTime),
-		WorkEfficiency:      s.calculateWorkEfficiency(),
+		WorkEfficiency:      workEfficiency(s.PomodorosCompleted, s.PomodorosSkipped, focus),
+		CompletionRate:      s.calculateCompletionRate(),
+		FocusScore:          focus.Score,
+		TotalPauses:         focus.Pauses,
+		TotalPausedTime:     focus.PausedTime,
+		TotalActiveTime:     focus.ActiveTime,
 		TotalSessions:       s.getTotalSessions(),
+		DayStreak:           s.calculateDayStreak(),
 	}
 }
 
@@ -180,8 +239,8 @@ func (s *SessionStats) getTotalSessions() int {
 	return s.PomodorosCompleted + s.PomodorosSkipped + s.BreaksCompleted + s.BreaksSkipped
 }
 
-// GetWorkEfficiency calcula el porcentaje de pomodoros completados vs saltados (debe llamarse con lock)
-func (s *SessionStats) calculateWorkEfficiency() float64 {
+// calculateCompletionRate calcula el porcentaje de pomodoros completados vs saltados (debe llamarse con lock)
+func (s *SessionStats) calculateCompletionRate() float64 {
 	total := s.PomodorosCompleted + s.PomodorosSkipped
 	if total == 0 {
 		return 0
@@ -212,6 +271,7 @@ func (s *SessionStats) Reset() {
 	s.CurrentStreakCount = 0
 	s.BestStreakCount = 0
 	s.CompletedSessions = make([]CompletedSession, 0)
+	s.invalidateDayStreak()
 }
 
 // ExportJSON exporta las estadísticas completas a JSON
@@ -221,6 +281,7 @@ func (s *SessionStats) ExportJSON() ([]byte, error) {
 
 	// Crear estructura sin mutex para exportar
 	data := struct {
+		SchemaVersion       int                `json:"schema_version"`
 		PomodorosCompleted  int                `json:"pomodoros_completed"`
 		PomodorosSkipped    int                `json:"pomodoros_skipped"`
 		BreaksCompleted     int                `json:"breaks_completed"`
@@ -234,6 +295,7 @@ func (s *SessionStats) ExportJSON() ([]byte, error) {
 		CompletedSessions   []CompletedSession `json:"completed_sessions"`
 		ExportedAt          time.Time          `json:"exported_at"`
 	}{
+		SchemaVersion:       SchemaVersion,
 		PomodorosCompleted:  s.PomodorosCompleted,
 		PomodorosSkipped:    s.PomodorosSkipped,
 		BreaksCompleted:     s.BreaksCompleted,
@@ -251,8 +313,13 @@ func (s *SessionStats) ExportJSON() ([]byte, error) {
 	return json.MarshalIndent(data, "", "  ")
 }
 
-// ImportJSON importa estadísticas desde JSON
+// ImportJSON importa estadísticas desde JSON, migrando exportaciones de versiones anteriores
 func (s *SessionStats) ImportJSON(data []byte) error {
+	data, err := MigrateExportJSON(data)
+	if err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -287,6 +354,7 @@ func (s *SessionStats) ImportJSON(data []byte) error {
 	s.CurrentStreakCount = imported.CurrentStreakCount
 	s.BestStreakCount = imported.BestStreakCount
 	s.CompletedSessions = imported.CompletedSessions
+	s.invalidateDayStreak()
 
 	return nil
 }
@@ -329,6 +397,7 @@ func (s *SessionStats) GetStatsDisplay() string {
 
 📈 Eficiencia:
    • Eficiencia de trabajo: %.1f%%
+   • Enfoque: %.1f%% (%d pausas, %s en pausa)
    • Total de sesiones: %d
 
 🎯 Productividad:
@@ -344,6 +413,9 @@ func (s *SessionStats) GetStatsDisplay() string {
 		FormatDuration(snapshot.TotalBreakTime),
 		FormatDuration(snapshot.SessionDuration),
 		snapshot.WorkEfficiency,
+		snapshot.FocusScore,
+		snapshot.TotalPauses,
+		FormatDuration(snapshot.TotalPausedTime),
 		snapshot.TotalSessions)
 
 	// Añadir barra de progreso visual para eficiencia
@@ -398,50 +470,26 @@ func (s *SessionStats) GetCompletedSessions() []CompletedSession {
 
 // GetRecentSessions retorna las últimas N sesiones
 func (s *SessionStats) GetRecentSessions(count int) []CompletedSession {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	if count <= 0 {
 		return []CompletedSession{}
 	}
 
-	total := len(s.CompletedSessions)
-	if count > total {
-		count = total
+	result := s.Query(NewQuery().SortBy(SortByStart, true).Page(0, count))
+	sessions := result.CompletedSessions()
+	for i, j := 0, len(sessions)-1; i < j; i, j = i+1, j-1 {
+		sessions[i], sessions[j] = sessions[j], sessions[i]
 	}
-
-	start := total - count
-	sessions := make([]CompletedSession, count)
-	copy(sessions, s.CompletedSessions[start:])
 	return sessions
 }
 
 // GetWorkSessions retorna solo las sesiones de trabajo (pomodoros)
 func (s *SessionStats) GetWorkSessions() []CompletedSession {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	var workSessions []CompletedSession
-	for _, session := range s.CompletedSessions {
-		if session.Type == "TRABAJO" {
-			workSessions = append(workSessions, session)
-		}
-	}
-	return workSessions
+	return s.Query(NewQuery().WorkOnly()).CompletedSessions()
 }
 
 // GetBreakSessions retorna solo las sesiones de descanso
 func (s *SessionStats) GetBreakSessions() []CompletedSession {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	var breakSessions []CompletedSession
-	for _, session := range s.CompletedSessions {
-		if session.Type != "TRABAJO" {
-			breakSessions = append(breakSessions, session)
-		}
-	}
-	return breakSessions
+	return s.Query(NewQuery().BreaksOnly()).CompletedSessions()
 }
 
 // FormatDuration convierte duración a formato legible
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SessionRecord representa una sesión persistida en el historial junto a su usuario
+type SessionRecord struct {
+	UserID string `json:"user_id"`
+	CompletedSession
+}
+
+// Store define la interfaz de almacenamiento del historial de sesiones
+type Store interface {
+	// Append agrega una sesión al historial
+	Append(record SessionRecord) error
+	// Range retorna las sesiones de un usuario que empezaron en [from, to).
+	// Un userID vacío incluye a todos los usuarios y un tiempo cero no acota ese extremo.
+	Range(userID string, from, to time.Time) ([]SessionRecord, error)
+	// Close libera los recursos del almacenamiento
+	Close() error
+}
+
+// MemoryStore implementa Store en memoria (útil para tests o cuando no se quiere persistir)
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records []SessionRecord
+}
+
+// NewMemoryStore crea un nuevo almacenamiento en memoria
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make([]SessionRecord, 0),
+	}
+}
+
+// Append agrega una sesión manteniendo el orden por fecha de inicio
+func (m *MemoryStore) Append(record SessionRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = insertSorted(m.records, record)
+	return nil
+}
+
+// Range retorna las sesiones dentro del rango indicado
+func (m *MemoryStore) Range(userID string, from, to time.Time) ([]SessionRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return filterRange(m.records, userID, from, to), nil
+}
+
+// Close no hace nada para el almacenamiento en memoria
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// DefaultHistoryPath retorna la ruta por defecto del historial siguiendo XDG
+func DefaultHistoryPath() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "gomodoro", "history.jsonl")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "gomodoro-history.jsonl"
+	}
+	return filepath.Join(home, ".local", "share", "gomodoro", "history.jsonl")
+}
+
+// insertSorted inserta un registro manteniendo el slice ordenado por StartTime
+func insertSorted(records []SessionRecord, record SessionRecord) []SessionRecord {
+	// Caso común: las sesiones llegan en orden cronológico
+	n := len(records)
+	if n == 0 || !record.StartTime.Before(records[n-1].StartTime) {
+		return append(records, record)
+	}
+
+	idx := sort.Search(n, func(i int) bool {
+		return records[i].StartTime.After(record.StartTime)
+	})
+	records = append(records, SessionRecord{})
+	copy(records[idx+1:], records[idx:])
+	records[idx] = record
+	return records
+}
+
+// filterRange filtra registros ordenados por usuario y rango de inicio [from, to)
+func filterRange(records []SessionRecord, userID string, from, to time.Time) []SessionRecord {
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(records), func(i int) bool {
+			return !records[i].StartTime.Before(from)
+		})
+	}
+
+	end := len(records)
+	if !to.IsZero() {
+		end = sort.Search(len(records), func(i int) bool {
+			return !records[i].StartTime.Before(to)
+		})
+	}
+
+	result := make([]SessionRecord, 0)
+	for i := start; i < end; i++ {
+		if userID == "" || records[i].UserID == userID {
+			result = append(result, records[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,180 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Labels identifica en qué se trabajó durante un pomodoro
+type Labels struct {
+	Task     string   `json:"task,omitempty"`
+	Project  string   `json:"project,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Estimate int      `json:"estimate,omitempty"` // Pomodoros estimados para la tarea
+}
+
+// IsEmpty indica si no hay ninguna etiqueta asignada
+func (l Labels) IsEmpty() bool {
+	return l.Task == "" && l.Project == "" && len(l.Tags) == 0
+}
+
+// Summary formatea las etiquetas como "tarea @proyecto #tag"
+func (l Labels) Summary() string {
+	var parts []string
+	if l.Task != "" {
+		parts = append(parts, l.Task)
+	}
+	if l.Project != "" {
+		parts = append(parts, "@"+l.Project)
+	}
+	for _, tag := range l.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if l.Estimate > 0 {
+		parts = append(parts, fmt.Sprintf("(~%d 🍅)", l.Estimate))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseTags convierte una lista separada por comas en tags normalizados sin duplicados
+func ParseTags(value string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// GroupBy define el criterio de agrupación de las estadísticas por etiqueta
+type GroupBy string
+
+const (
+	GroupByTask    GroupBy = "task"
+	GroupByProject GroupBy = "project"
+	GroupByTag     GroupBy = "tag"
+	GroupByRule    GroupBy = "rule" // Regla de horario con la que se hizo el pomodoro
+)
+
+// ParseGroupBy convierte un texto (en inglés o español) a GroupBy
+func ParseGroupBy(value string) (GroupBy, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "task", "tasks", "tarea", "tareas":
+		return GroupByTask, nil
+	case "project", "projects", "proyecto", "proyectos":
+		return GroupByProject, nil
+	case "tag", "tags", "etiqueta", "etiquetas":
+		return GroupByTag, nil
+	case "rule", "rules", "regla", "reglas":
+		return GroupByRule, nil
+	default:
+		return "", fmt.Errorf("unknown group: %s", value)
+	}
+}
+
+// LabelStats contiene los totales de una tarea, proyecto, tag o regla de horario
+type LabelStats struct {
+	Key                string // Vacío para los pomodoros sin etiqueta
+	PomodorosCompleted int
+	PomodorosSkipped   int
+	FocusTime          time.Duration
+	SkipRate           float64
+	FirstWorked        time.Time
+	LastWorked         time.Time
+	Estimate           int // Suma de las estimaciones de las tareas del grupo (0 si no hay)
+}
+
+// HasEstimate indica si el grupo tiene pomodoros estimados
+func (l LabelStats) HasEstimate() bool {
+	return l.Estimate > 0
+}
+
+// EstimateProgress retorna los pomodoros completados respecto a los estimados (en %)
+func (l LabelStats) EstimateProgress() float64 {
+	if l.Estimate == 0 {
+		return 0
+	}
+	return float64(l.PomodorosCompleted) / float64(l.Estimate) * 100
+}
+
+// AggregateByLabel agrupa los pomodoros por tarea, proyecto, tag o regla de horario, ordenados por tiempo de enfoque.
+// Con GroupByTag un pomodoro cuenta en cada uno de sus tags.
+func AggregateByLabel(sessions []CompletedSession, groupBy GroupBy) []LabelStats {
+	groups := make(map[string]*LabelStats)
+	estimates := make(map[string]map[string]int) // grupo -> tarea -> última estimación
+
+	sorted := make([]CompletedSession, len(sessions))
+	copy(sorted, sessions)
+	sortSessions(sorted)
+
+	for _, session := range sorted {
+		if !session.Type.IsWork() {
+			continue
+		}
+
+		for _, key := range labelKeys(session, groupBy) {
+			group, ok := groups[key]
+			if !ok {
+				group = &LabelStats{Key: key, FirstWorked: session.StartTime}
+				groups[key] = group
+				estimates[key] = make(map[string]int)
+			}
+
+			group.FocusTime += session.Active()
+			group.LastWorked = sessionEnd(session)
+			if session.Completed {
+				group.PomodorosCompleted++
+			} else {
+				group.PomodorosSkipped++
+			}
+
+			if session.Estimate > 0 {
+				estimates[key][session.Task] = session.Estimate
+			}
+		}
+	}
+
+	result := make([]LabelStats, 0, len(groups))
+	for key, group := range groups {
+		if total := group.PomodorosCompleted + group.PomodorosSkipped; total > 0 {
+			group.SkipRate = float64(group.PomodorosSkipped) / float64(total) * 100
+		}
+		for _, estimate := range estimates[key] {
+			group.Estimate += estimate
+		}
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FocusTime != result[j].FocusTime {
+			return result[i].FocusTime > result[j].FocusTime
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// labelKeys retorna las claves de agrupación de una sesión
+func labelKeys(session CompletedSession, groupBy GroupBy) []string {
+	labels := session.Labels
+	switch groupBy {
+	case GroupByRule:
+		return []string{session.Rule}
+	case GroupByProject:
+		return []string{labels.Project}
+	case GroupByTag:
+		if len(labels.Tags) == 0 {
+			return []string{""}
+		}
+		return labels.Tags
+	default:
+		return []string{labels.Task}
+	}
+}
@@ -2,6 +2,7 @@ package timer
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -30,6 +31,7 @@ type Timer struct {
 	startedAt   time.Time
 	pausedAt    time.Time
 	totalPaused time.Duration
+	pauseCount  int
 
 	// Control de contexto
 	ctx    context.Context
@@ -50,6 +52,7 @@ type TimerSnapshot struct {
 	StartedAt     time.Time
 	ElapsedActive time.Duration
 	TotalPaused   time.Duration
+	PauseCount    int
 }
 
 // NewTimer crea un nuevo timer con la duración especificada
@@ -80,6 +83,7 @@ func (t *Timer) Start() error {
 	if t.state == StateIdle {
 		t.startedAt = time.Now()
 		t.totalPaused = 0
+		t.pauseCount = 0
 	} else if t.state == StatePaused {
 		// Reanudar desde pausa
 		pauseDuration := time.Since(t.pausedAt)
@@ -101,6 +105,7 @@ func (t *Timer) Pause() error {
 
 	t.state = StatePaused
 	t.pausedAt = time.Now()
+	t.pauseCount++
 	return nil
 }
 
@@ -123,6 +128,23 @@ func (t *Timer) Skip() {
 	}
 }
 
+// Extend añade tiempo a la sesión en curso (corriendo o pausada)
+func (t *Timer) Extend(d time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if d <= 0 {
+		return fmt.Errorf("extension must be positive")
+	}
+	if t.state != StateRunning && t.state != StatePaused {
+		return fmt.Errorf("timer is not active")
+	}
+
+	t.duration += d
+	t.remaining += d
+	return nil
+}
+
 // Stop detiene el timer completamente
 func (t *Timer) Stop() {
 	t.mu.Lock()
@@ -147,6 +169,7 @@ func (t *Timer) Reset() {
 	t.startedAt = time.Time{}
 	t.pausedAt = time.Time{}
 	t.totalPaused = 0
+	t.pauseCount = 0
 }
 
 // Tick actualiza el timer (llamado cada segundo)
@@ -209,6 +232,7 @@ func (t *Timer) createSnapshot() TimerSnapshot {
 		StartedAt:     t.startedAt,
 		ElapsedActive: elapsedActive,
 		TotalPaused:   t.totalPaused,
+		PauseCount:    t.pauseCount,
 	}
 }
 